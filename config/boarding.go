@@ -0,0 +1,18 @@
+package config
+
+import (
+	"crypto/sha256"
+	"errors"
+	"os"
+)
+
+// GetBoardingPassKey returns the key boarding pass codes are signed with.
+// BOARDING_PASS_SECRET can be any long random string; it is hashed to the key size.
+func GetBoardingPassKey() ([]byte, error) {
+	secret := os.Getenv("BOARDING_PASS_SECRET")
+	if secret == "" {
+		return nil, errors.New("BOARDING_PASS_SECRET is not set")
+	}
+	key := sha256.Sum256([]byte(secret))
+	return key[:], nil
+}
@@ -27,7 +27,8 @@ func ConnectDatabase() *gorm.DB {
 
 	maxAttempts := 10
 	for i := 1; i <= maxAttempts; i++ {
-		db, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		// TranslateError maps duplicate key errors to gorm.ErrDuplicatedKey, the payment code assignment retries on it
+		db, err = gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
 		if err == nil {
 			log.Println("✅ Connected to database!")
 			break
@@ -46,13 +47,39 @@ func ConnectDatabase() *gorm.DB {
 
 func AutoMigrate(db *gorm.DB) error {
 	return db.AutoMigrate(
+		&entities.Role{},
+		&entities.RolePermission{},
 		&entities.User{},
+		&entities.UserSession{},
+		&entities.RefreshToken{},
+		&entities.PasswordResetToken{},
+		&entities.VerificationCode{},
+		&entities.LoginThrottle{},
+		&entities.LoginAuditLog{},
+		&entities.TwoFactorRecoveryCode{},
+		&entities.TwoFactorPolicy{},
+		&entities.SavedPassenger{},
+		&entities.Partner{},
+		&entities.APIKey{},
+		&entities.APIKeyUsage{},
+		&entities.OutboxMessage{},
+		&entities.Notification{},
+		&entities.WebhookEndpoint{},
+		&entities.WebhookDelivery{},
+		&entities.WebhookDeliveryAttempt{},
 		&entities.Route{},
 		&entities.Schedule{},
+		&entities.TripIncident{},
 		&entities.Seat{},
 		&entities.Booking{},
 		&entities.BookingDetail{},
+		&entities.BookingReminder{},
 		&entities.Payment{},
+		&entities.BankStatementImport{},
+		&entities.BankStatementEntry{},
+		&entities.Invoice{},
+		&entities.InvoiceItem{},
+		&entities.InvoiceSequence{},
 	)
 }
 
@@ -60,8 +87,12 @@ func AutoMigrate(db *gorm.DB) error {
 func SeedData(db *gorm.DB) error {
 	log.Println("Starting database seeding...")
 
-	// Create admin user
-	adminPassword, _ := bcrypt.GenerateFromPassword([]byte("admin123"), bcrypt.DefaultCost)
+	// Create admin user, set SEED_ADMIN_PASSWORD outside local development
+	seedAdminPassword := getEnvOrDefault("SEED_ADMIN_PASSWORD", "admin123")
+	if seedAdminPassword == "admin123" {
+		log.Println("⚠️  Seeding admin with the default password, set SEED_ADMIN_PASSWORD to change it")
+	}
+	adminPassword, _ := bcrypt.GenerateFromPassword([]byte(seedAdminPassword), bcrypt.DefaultCost)
 	admin := entities.User{
 		Email:       "admin@malakashuttle.com",
 		Password:    string(adminPassword),
@@ -70,6 +101,7 @@ func SeedData(db *gorm.DB) error {
 		LastName:    "System",
 		PhoneNumber: "081234567890",
 	}
+	admin.MarkVerified()
 	if err := db.FirstOrCreate(&admin, entities.User{Email: admin.Email}).Error; err != nil {
 		return fmt.Errorf("failed to create admin user: %v", err)
 	}
@@ -84,10 +116,26 @@ func SeedData(db *gorm.DB) error {
 		LastName:    "One",
 		PhoneNumber: "081234567891",
 	}
+	staff.MarkVerified()
 	if err := db.FirstOrCreate(&staff, entities.User{Email: staff.Email}).Error; err != nil {
 		return fmt.Errorf("failed to create staff user: %v", err)
 	}
 
+	// Create driver user
+	driverPassword, _ := bcrypt.GenerateFromPassword([]byte("driver123"), bcrypt.DefaultCost)
+	driver := entities.User{
+		Email:       "driver@malakashuttle.com",
+		Password:    string(driverPassword),
+		Role:        "driver",
+		FirstName:   "Driver",
+		LastName:    "One",
+		PhoneNumber: "081234567880",
+	}
+	driver.MarkVerified()
+	if err := db.FirstOrCreate(&driver, entities.User{Email: driver.Email}).Error; err != nil {
+		return fmt.Errorf("failed to create driver user: %v", err)
+	}
+
 	// Create 10 regular users
 	for i := 1; i <= 10; i++ {
 		password, _ := bcrypt.GenerateFromPassword([]byte(fmt.Sprintf("user%d123", i)), bcrypt.DefaultCost)
@@ -99,6 +147,7 @@ func SeedData(db *gorm.DB) error {
 			LastName:    "Test",
 			PhoneNumber: fmt.Sprintf("08123456789%d", i),
 		}
+		user.MarkVerified()
 		if err := db.FirstOrCreate(&user, entities.User{Email: user.Email}).Error; err != nil {
 			return fmt.Errorf("failed to create user %d: %v", i, err)
 		}
@@ -163,6 +212,7 @@ func SeedData(db *gorm.DB) error {
 				Price:          50000 + float64(rand.Intn(100000)), // Random price between 50k-150k
 				TotalSeats:     totalSeats,
 				AvailableSeats: totalSeats,
+				DriverID:       &driver.ID,
 			}
 
 			if err := db.Create(&schedule).Error; err != nil {
@@ -207,13 +257,39 @@ func ResetDatabase(db *gorm.DB) error {
 
 	// Drop all tables
 	if err := db.Migrator().DropTable(
+		&entities.InvoiceItem{},
+		&entities.Invoice{},
+		&entities.InvoiceSequence{},
+		&entities.BankStatementEntry{},
+		&entities.BankStatementImport{},
 		&entities.Payment{},
+		&entities.BookingReminder{},
 		&entities.BookingDetail{},
 		&entities.Booking{},
 		&entities.Seat{},
+		&entities.TripIncident{},
 		&entities.Schedule{},
 		&entities.Route{},
+		&entities.WebhookDeliveryAttempt{},
+		&entities.WebhookDelivery{},
+		&entities.WebhookEndpoint{},
+		&entities.Notification{},
+		&entities.OutboxMessage{},
+		&entities.APIKeyUsage{},
+		&entities.APIKey{},
+		&entities.Partner{},
+		&entities.SavedPassenger{},
+		&entities.TwoFactorPolicy{},
+		&entities.TwoFactorRecoveryCode{},
+		&entities.LoginAuditLog{},
+		&entities.LoginThrottle{},
+		&entities.VerificationCode{},
+		&entities.PasswordResetToken{},
+		&entities.RefreshToken{},
+		&entities.UserSession{},
 		&entities.User{},
+		&entities.RolePermission{},
+		&entities.Role{},
 	); err != nil {
 		return fmt.Errorf("failed to drop tables: %v", err)
 	}
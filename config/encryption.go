@@ -0,0 +1,18 @@
+package config
+
+import (
+	"crypto/sha256"
+	"errors"
+	"os"
+)
+
+// GetDataEncryptionKey returns the 32-byte key used to encrypt sensitive data at rest.
+// DATA_ENCRYPTION_KEY can be any long random string; it is hashed to the key size.
+func GetDataEncryptionKey() ([]byte, error) {
+	secret := os.Getenv("DATA_ENCRYPTION_KEY")
+	if secret == "" {
+		return nil, errors.New("DATA_ENCRYPTION_KEY is not set")
+	}
+	key := sha256.Sum256([]byte(secret))
+	return key[:], nil
+}
@@ -0,0 +1,8 @@
+package config
+
+import "strings"
+
+// GetDefaultLocale returns the language used when neither the user nor the request asks for one
+func GetDefaultLocale() string {
+	return strings.ToLower(getEnvOrDefault("DEFAULT_LOCALE", "en"))
+}
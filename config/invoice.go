@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// CompanyProfile holds the seller details printed on invoices
+type CompanyProfile struct {
+	Name    string
+	Address string
+	Phone   string
+	Email   string
+	NPWP    string
+}
+
+// GetCompanyProfile returns the company details used on invoices
+func GetCompanyProfile() CompanyProfile {
+	return CompanyProfile{
+		Name:    getEnvOrDefault("COMPANY_NAME", "PT Malaka Shuttle Indonesia"),
+		Address: getEnvOrDefault("COMPANY_ADDRESS", "Jakarta, Indonesia"),
+		Phone:   os.Getenv("COMPANY_PHONE"),
+		Email:   getEnvOrDefault("COMPANY_EMAIL", "billing@malakashuttle.com"),
+		NPWP:    os.Getenv("COMPANY_NPWP"),
+	}
+}
+
+// GetInvoiceTaxRate returns the PPN rate applied on invoices (e.g. 0.11 for 11%)
+func GetInvoiceTaxRate() float64 {
+	rate, err := strconv.ParseFloat(os.Getenv("INVOICE_TAX_RATE"), 64)
+	if err != nil || rate < 0 || rate >= 1 {
+		return 0.11 // default PPN 11%
+	}
+	return rate
+}
+
+// getEnvOrDefault returns the environment variable value or the fallback when it's empty
+func getEnvOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -9,10 +9,20 @@ func GetJWTSecret() []byte {
 	return []byte(os.Getenv("JWT_SECRET"))
 }
 
+// GetJWTDuration returns the access token lifetime
 func GetJWTDuration() time.Duration {
 	duration, err := time.ParseDuration(os.Getenv("JWT_EXPIRATION"))
 	if err != nil {
-		return 24 * time.Hour // default duration time
+		return 15 * time.Minute // default duration time
+	}
+	return duration
+}
+
+// GetRefreshTokenDuration returns how long a refresh token stays valid after it is issued
+func GetRefreshTokenDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("REFRESH_TOKEN_EXPIRATION"))
+	if err != nil || duration <= 0 {
+		return 7 * 24 * time.Hour
 	}
 	return duration
 }
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// LoginProtectionConfig holds the brute-force protection settings for login
+type LoginProtectionConfig struct {
+	MaxAccountFailures int           // failures per email before the account is locked
+	MaxIPFailures      int           // failures per client IP before the IP is locked
+	FailureWindow      time.Duration // failures older than this are forgotten
+	LockoutDuration    time.Duration
+	DelayBase          time.Duration // wait after the first failure, doubled after each further failure
+	DelayMax           time.Duration
+}
+
+func GetLoginProtectionConfig() LoginProtectionConfig {
+	return LoginProtectionConfig{
+		MaxAccountFailures: getIntOrDefault("LOGIN_MAX_ACCOUNT_FAILURES", 5),
+		MaxIPFailures:      getIntOrDefault("LOGIN_MAX_IP_FAILURES", 20),
+		FailureWindow:      getDurationOrDefault("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+		LockoutDuration:    getDurationOrDefault("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		DelayBase:          getDurationOrDefault("LOGIN_DELAY_BASE", time.Second),
+		DelayMax:           getDurationOrDefault("LOGIN_DELAY_MAX", 30*time.Second),
+	}
+}
+
+func getIntOrDefault(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// GetTwoFactorChallengeTTL returns how long the user has to enter the TOTP code after the password
+func GetTwoFactorChallengeTTL() time.Duration {
+	return getDurationOrDefault("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute)
+}
+
+// GetTwoFactorIssuer returns the issuer name shown in authenticator apps
+func GetTwoFactorIssuer() string {
+	return getEnvOrDefault("TWO_FACTOR_ISSUER", "Malaka Shuttle")
+}
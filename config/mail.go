@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MailConfig holds the outgoing mail settings
+type MailConfig struct {
+	Driver   string // "smtp" or "file"
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	FileDir  string
+}
+
+// GetMailConfig returns the mail settings. The "file" driver writes messages to FileDir
+// instead of sending them, which is the default for local development.
+func GetMailConfig() MailConfig {
+	port, err := strconv.Atoi(os.Getenv("SMTP_PORT"))
+	if err != nil || port <= 0 {
+		port = 587
+	}
+
+	return MailConfig{
+		Driver:   strings.ToLower(getEnvOrDefault("MAIL_DRIVER", "file")),
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     port,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     getEnvOrDefault("MAIL_FROM", "Malaka Shuttle <no-reply@malakashuttle.com>"),
+		FileDir:  getEnvOrDefault("MAIL_FILE_DIR", "storage/mail"),
+	}
+}
+
+// GetFrontendURL returns the base URL of the web client used in emailed links
+func GetFrontendURL() string {
+	return strings.TrimRight(getEnvOrDefault("FRONTEND_URL", "http://localhost:3000"), "/")
+}
+
+// GetPasswordResetTokenTTL returns how long a password reset token stays valid
+func GetPasswordResetTokenTTL() time.Duration {
+	return getDurationOrDefault("PASSWORD_RESET_TOKEN_TTL", 30*time.Minute)
+}
+
+// GetPasswordResetRateLimit returns how many reset emails can be requested per email address
+// within the rate limit window
+func GetPasswordResetRateLimit() (int, time.Duration) {
+	limit, err := strconv.Atoi(os.Getenv("PASSWORD_RESET_RATE_LIMIT"))
+	if err != nil || limit <= 0 {
+		limit = 3
+	}
+	return limit, getDurationOrDefault("PASSWORD_RESET_RATE_WINDOW", time.Hour)
+}
+
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || duration <= 0 {
+		return defaultValue
+	}
+	return duration
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// NoShowConfig holds how no-shows are detected and how repeat no-show customers are treated.
+// A threshold of 0 turns the rule off.
+type NoShowConfig struct {
+	CheckInterval time.Duration
+	// GracePeriod is how long after departure a passenger who did not board becomes a no-show
+	GracePeriod time.Duration
+	// Lookback limits the automatic marking to schedules that departed recently
+	Lookback time.Duration
+	// PolicyWindow is how long a missed trip counts for the policy
+	PolicyWindow time.Duration
+	// Customers with PenaltyThreshold missed trips in the window can book at most PenaltyMaxPassengers seats
+	PenaltyThreshold     int
+	PenaltyMaxPassengers int
+	// Customers with BlockThreshold missed trips in the window cannot book at all
+	BlockThreshold int
+}
+
+// GetNoShowConfig returns the no-show detection and policy settings
+func GetNoShowConfig() NoShowConfig {
+	return NoShowConfig{
+		CheckInterval:        getDurationOrDefault("NO_SHOW_CHECK_INTERVAL", 5*time.Minute),
+		GracePeriod:          getDurationOrDefault("NO_SHOW_GRACE_PERIOD", 30*time.Minute),
+		Lookback:             getDurationOrDefault("NO_SHOW_LOOKBACK", 48*time.Hour),
+		PolicyWindow:         getDurationOrDefault("NO_SHOW_POLICY_WINDOW", 90*24*time.Hour),
+		PenaltyThreshold:     getThresholdOrDefault("NO_SHOW_PENALTY_THRESHOLD", 2),
+		PenaltyMaxPassengers: getIntOrDefault("NO_SHOW_PENALTY_MAX_PASSENGERS", 1),
+		BlockThreshold:       getThresholdOrDefault("NO_SHOW_BLOCK_THRESHOLD", 3),
+	}
+}
+
+// getThresholdOrDefault is like getIntOrDefault but accepts 0 to turn a rule off
+func getThresholdOrDefault(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// NotificationConfig holds the settings of the notification dispatcher
+type NotificationConfig struct {
+	Channels         []string // enabled channels: "email", "sms", "whatsapp"
+	DispatchInterval time.Duration
+	BatchSize        int
+	MaxAttempts      int
+	RetryBackoff     time.Duration // delay before the first retry, doubled on every further attempt
+	MaxRetryBackoff  time.Duration
+}
+
+// GetNotificationConfig returns the notification settings. Only email is enabled by default.
+func GetNotificationConfig() NotificationConfig {
+	var channels []string
+	for _, channel := range strings.Split(getEnvOrDefault("NOTIFICATION_CHANNELS", "email"), ",") {
+		if channel = strings.ToLower(strings.TrimSpace(channel)); channel != "" {
+			channels = append(channels, channel)
+		}
+	}
+
+	return NotificationConfig{
+		Channels:         channels,
+		DispatchInterval: getDurationOrDefault("NOTIFICATION_DISPATCH_INTERVAL", 30*time.Second),
+		BatchSize:        getIntOrDefault("NOTIFICATION_BATCH_SIZE", 50),
+		MaxAttempts:      getIntOrDefault("NOTIFICATION_MAX_ATTEMPTS", 5),
+		RetryBackoff:     getDurationOrDefault("NOTIFICATION_RETRY_BACKOFF", time.Minute),
+		MaxRetryBackoff:  getDurationOrDefault("NOTIFICATION_MAX_RETRY_BACKOFF", time.Hour),
+	}
+}
+
+// WhatsAppConfig holds the WhatsApp Business API settings
+type WhatsAppConfig struct {
+	Driver  string // "http" or "file"
+	APIURL  string
+	Token   string
+	FileDir string
+}
+
+// GetWhatsAppConfig returns the WhatsApp settings. The "file" driver writes messages to FileDir
+// instead of sending them, which is the default for local development.
+func GetWhatsAppConfig() WhatsAppConfig {
+	return WhatsAppConfig{
+		Driver:  strings.ToLower(getEnvOrDefault("WHATSAPP_DRIVER", "file")),
+		APIURL:  os.Getenv("WHATSAPP_API_URL"),
+		Token:   os.Getenv("WHATSAPP_API_TOKEN"),
+		FileDir: getEnvOrDefault("WHATSAPP_FILE_DIR", "storage/whatsapp"),
+	}
+}
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// GetAPIKeyRotationGracePeriod returns how long a rotated API key keeps working next to its replacement
+func GetAPIKeyRotationGracePeriod() time.Duration {
+	return getDurationOrDefault("API_KEY_ROTATION_GRACE_PERIOD", 24*time.Hour)
+}
+
+// GetAPIKeyDefaultRateLimit returns the requests per minute of API keys created without a limit
+func GetAPIKeyDefaultRateLimit() int {
+	return getIntOrDefault("API_KEY_DEFAULT_RATE_LIMIT", 60)
+}
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// IsPassengerIdentityRequired reports whether every booked passenger needs an ID number, phone and gender
+func IsPassengerIdentityRequired() bool {
+	required, err := strconv.ParseBool(os.Getenv("PASSENGER_IDENTITY_REQUIRED"))
+	if err != nil {
+		return false // default: identity details are optional
+	}
+	return required
+}
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// GetPaymentUniqueCodeMax returns the largest unique code that can be added to a transfer amount
+func GetPaymentUniqueCodeMax() int {
+	max, err := strconv.Atoi(os.Getenv("PAYMENT_UNIQUE_CODE_MAX"))
+	if err != nil || max <= 0 || max > 999 {
+		return 999 // default: last 3 digits
+	}
+	return max
+}
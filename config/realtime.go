@@ -0,0 +1,23 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// RealtimeConfig holds the settings of the seat availability stream
+type RealtimeConfig struct {
+	PubSubDriver      string // "memory"
+	HeartbeatInterval time.Duration
+	SubscriberBuffer  int // events buffered per stream before slow clients start missing updates
+}
+
+// GetRealtimeConfig returns the realtime settings. The in-memory broker only fans out within
+// a single instance, replicas need a shared broker to see each other's seat changes.
+func GetRealtimeConfig() RealtimeConfig {
+	return RealtimeConfig{
+		PubSubDriver:      strings.ToLower(getEnvOrDefault("PUBSUB_DRIVER", "memory")),
+		HeartbeatInterval: getDurationOrDefault("SSE_HEARTBEAT_INTERVAL", 25*time.Second),
+		SubscriberBuffer:  getIntOrDefault("SSE_SUBSCRIBER_BUFFER", 16),
+	}
+}
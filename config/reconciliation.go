@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// BankStatementMapping describes the column layout of a bank statement (mutasi rekening) CSV export.
+// Column indexes are zero based; TypeColumn is -1 when the export has no separate CR/DB column.
+type BankStatementMapping struct {
+	Bank              string
+	Delimiter         rune
+	SkipRows          int
+	DateColumn        int
+	DateLayout        string
+	DescriptionColumn int
+	AmountColumn      int
+	TypeColumn        int
+	CreditMarker      string
+	ThousandSeparator string
+	DecimalSeparator  string
+}
+
+// bankStatementMappings contains the default column mappings for supported bank exports
+var bankStatementMappings = map[string]BankStatementMapping{
+	// KlikBCA: Tanggal, Keterangan, Cabang, Jumlah, CR/DB, Saldo
+	"bca": {
+		Bank:              "bca",
+		Delimiter:         ',',
+		SkipRows:          1,
+		DateColumn:        0,
+		DateLayout:        "02/01/2006",
+		DescriptionColumn: 1,
+		AmountColumn:      3,
+		TypeColumn:        4,
+		CreditMarker:      "CR",
+		ThousandSeparator: ",",
+		DecimalSeparator:  ".",
+	},
+	// Mandiri: Tanggal, Keterangan, Debet, Kredit, Saldo
+	"mandiri": {
+		Bank:              "mandiri",
+		Delimiter:         ';',
+		SkipRows:          1,
+		DateColumn:        0,
+		DateLayout:        "02/01/2006",
+		DescriptionColumn: 1,
+		AmountColumn:      3,
+		TypeColumn:        -1,
+		ThousandSeparator: ".",
+		DecimalSeparator:  ",",
+	},
+}
+
+// GetBankStatementMapping returns the default column mapping for a bank export
+func GetBankStatementMapping(bank string) (BankStatementMapping, bool) {
+	mapping, ok := bankStatementMappings[strings.ToLower(bank)]
+	return mapping, ok
+}
+
+// GetReconciliationDateWindow returns how far a bank transaction date may be from the booking time
+func GetReconciliationDateWindow() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("RECONCILIATION_DATE_WINDOW"))
+	if err != nil {
+		return 48 * time.Hour // default date window
+	}
+	return duration
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GetPaymentReminderLead returns how long before a pending booking expires the payment reminder is sent
+func GetPaymentReminderLead() time.Duration {
+	return getDurationOrDefault("PAYMENT_REMINDER_LEAD", 10*time.Minute)
+}
+
+// GetDepartureReminderOffsets returns how long before departure the reminders are sent, largest first.
+// DEPARTURE_REMINDER_OFFSETS is a comma separated list of durations, e.g. "24h,2h".
+func GetDepartureReminderOffsets() []time.Duration {
+	value, ok := os.LookupEnv("DEPARTURE_REMINDER_OFFSETS")
+	if !ok {
+		value = "24h,2h"
+	}
+
+	seen := make(map[time.Duration]bool)
+	var offsets []time.Duration
+	for _, item := range strings.Split(value, ",") {
+		offset, err := time.ParseDuration(strings.TrimSpace(item))
+		if err != nil || offset <= 0 || seen[offset] {
+			continue
+		}
+		seen[offset] = true
+		offsets = append(offsets, offset)
+	}
+
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+	return offsets
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// GetTrustedProxies returns the addresses or CIDRs of the reverse proxies in front of the API, from
+// TRUSTED_PROXIES as a comma separated list. Only these may set X-Forwarded-For, so the client IP used
+// by API key allowlists and login throttling cannot be forged. Without proxies the connection's
+// address is used.
+func GetTrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// GetPaymentProofMaxDimension returns the maximum width/height (in pixels) allowed for payment proof images
+func GetPaymentProofMaxDimension() int {
+	dimension, err := strconv.Atoi(os.Getenv("PAYMENT_PROOF_MAX_DIMENSION"))
+	if err != nil || dimension <= 0 {
+		return 4096 // default max dimension
+	}
+	return dimension
+}
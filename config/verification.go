@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VerificationConfig holds the email and phone verification settings
+type VerificationConfig struct {
+	EmailTTL       time.Duration
+	PhoneTTL       time.Duration
+	ResendCooldown time.Duration
+	MaxAttempts    int
+}
+
+func GetVerificationConfig() VerificationConfig {
+	maxAttempts, err := strconv.Atoi(os.Getenv("VERIFICATION_MAX_ATTEMPTS"))
+	if err != nil || maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+
+	return VerificationConfig{
+		EmailTTL:       getDurationOrDefault("VERIFICATION_EMAIL_TTL", 24*time.Hour),
+		PhoneTTL:       getDurationOrDefault("VERIFICATION_PHONE_TTL", 10*time.Minute),
+		ResendCooldown: getDurationOrDefault("VERIFICATION_RESEND_COOLDOWN", time.Minute),
+		MaxAttempts:    maxAttempts,
+	}
+}
+
+// SMSConfig holds the SMS gateway settings
+type SMSConfig struct {
+	Driver     string // "http" or "file"
+	GatewayURL string
+	APIKey     string
+	Sender     string
+	FileDir    string
+}
+
+// GetSMSConfig returns the SMS settings. The "file" driver writes messages to FileDir
+// instead of sending them, which is the default for local development.
+func GetSMSConfig() SMSConfig {
+	return SMSConfig{
+		Driver:     strings.ToLower(getEnvOrDefault("SMS_DRIVER", "file")),
+		GatewayURL: os.Getenv("SMS_GATEWAY_URL"),
+		APIKey:     os.Getenv("SMS_API_KEY"),
+		Sender:     getEnvOrDefault("SMS_SENDER", "MALAKA"),
+		FileDir:    getEnvOrDefault("SMS_FILE_DIR", "storage/sms"),
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// WebhookConfig holds the settings of the webhook dispatcher
+type WebhookConfig struct {
+	DispatchInterval time.Duration
+	BatchSize        int
+	Timeout          time.Duration
+	MaxAttempts      int
+	RetryBackoff     time.Duration // delay before the first retry, doubled on every further attempt
+	MaxRetryBackoff  time.Duration
+	// AllowPrivateNetworks lets endpoints point at loopback, private and link-local addresses, for local development only
+	AllowPrivateNetworks bool
+}
+
+// GetWebhookConfig returns the webhook delivery settings
+func GetWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		DispatchInterval:     getDurationOrDefault("WEBHOOK_DISPATCH_INTERVAL", 15*time.Second),
+		BatchSize:            getIntOrDefault("WEBHOOK_BATCH_SIZE", 50),
+		Timeout:              getDurationOrDefault("WEBHOOK_TIMEOUT", 10*time.Second),
+		MaxAttempts:          getIntOrDefault("WEBHOOK_MAX_ATTEMPTS", 8),
+		RetryBackoff:         getDurationOrDefault("WEBHOOK_RETRY_BACKOFF", 30*time.Second),
+		MaxRetryBackoff:      getDurationOrDefault("WEBHOOK_MAX_RETRY_BACKOFF", 6*time.Hour),
+		AllowPrivateNetworks: allowPrivateWebhookNetworks(),
+	}
+}
+
+func allowPrivateWebhookNetworks() bool {
+	allowed, err := strconv.ParseBool(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS"))
+	if err != nil {
+		return false // default: only public addresses receive webhooks
+	}
+	return allowed
+}
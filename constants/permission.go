@@ -0,0 +1,74 @@
+package constants
+
+// Permissions checked by route guards. Roles are stored in the database and map to these.
+const (
+	PERMISSION_BOOKINGS_CREATE   = "bookings.create"
+	PERMISSION_BOOKINGS_READ_OWN = "bookings.read_own"
+	PERMISSION_BOOKINGS_READ_ALL = "bookings.read_all"
+	PERMISSION_BOOKINGS_VERIFY   = "bookings.verify"
+	PERMISSION_INVOICES_ISSUE    = "invoices.issue"
+	PERMISSION_PAYMENTS_COUNTER  = "payments.counter"
+	PERMISSION_PAYMENTS_CASH_ALL = "payments.cash_summary_all"
+	PERMISSION_RECONCILIATION    = "reconciliation.manage"
+	PERMISSION_SCHEDULES_READ    = "schedules.read"
+	PERMISSION_SCHEDULES_WRITE   = "schedules.write"
+	PERMISSION_ROUTES_WRITE      = "routes.write"
+	PERMISSION_USERS_MANAGE      = "users.manage"
+	PERMISSION_SECURITY_MANAGE   = "security.manage"
+	PERMISSION_ROLES_MANAGE      = "roles.manage"
+	PERMISSION_PARTNERS_MANAGE   = "partners.manage"
+	PERMISSION_WEBHOOKS_MANAGE   = "webhooks.manage"
+	PERMISSION_BOARDING_SCAN     = "boarding.scan"
+	PERMISSION_MANIFESTS_READ    = "manifests.read"
+	PERMISSION_TRIPS_DRIVE       = "trips.drive"
+)
+
+// PermissionDescriptions lists every known permission
+var PermissionDescriptions = map[string]string{
+	PERMISSION_BOOKINGS_CREATE:   "Create own bookings and upload payment proofs",
+	PERMISSION_BOOKINGS_READ_OWN: "View own bookings, receipts and invoices",
+	PERMISSION_BOOKINGS_READ_ALL: "View all bookings, payment proofs, receipts and invoices",
+	PERMISSION_BOOKINGS_VERIFY:   "Approve or reject payments of bookings",
+	PERMISSION_INVOICES_ISSUE:    "Issue invoices for any booking, including discounts",
+	PERMISSION_PAYMENTS_COUNTER:  "Record cash and EDC payments at the counter",
+	PERMISSION_PAYMENTS_CASH_ALL: "View the cash summary of all cashiers",
+	PERMISSION_RECONCILIATION:    "Import bank statements and resolve reconciliation entries",
+	PERMISSION_SCHEDULES_READ:    "Search and view schedules",
+	PERMISSION_SCHEDULES_WRITE:   "Create, update and delete schedules",
+	PERMISSION_ROUTES_WRITE:      "Create, update and delete routes",
+	PERMISSION_USERS_MANAGE:      "Manage users and revoke their sessions",
+	PERMISSION_SECURITY_MANAGE:   "Unlock logins, view login audit logs and manage two-factor settings",
+	PERMISSION_ROLES_MANAGE:      "Create and edit roles and their permissions",
+	PERMISSION_PARTNERS_MANAGE:   "Manage partners, their API keys, usage and commission reports",
+	PERMISSION_WEBHOOKS_MANAGE:   "Manage webhook endpoints, view their delivery log and redeliver events",
+	PERMISSION_BOARDING_SCAN:     "Scan boarding passes and board passengers",
+	PERMISSION_MANIFESTS_READ:    "View and export the passenger manifests of schedules",
+	PERMISSION_TRIPS_DRIVE:       "View, start and end assigned trips, board their passengers and report incidents",
+}
+
+// DefaultRolePermissions are the permissions the system roles are created with.
+// The admin role always has every permission. API keys of partners are limited to
+// the permissions of the partner role.
+var DefaultRolePermissions = map[string][]string{
+	ROLE_USER: {
+		PERMISSION_BOOKINGS_CREATE,
+		PERMISSION_BOOKINGS_READ_OWN,
+		PERMISSION_SCHEDULES_READ,
+	},
+	ROLE_PARTNER: {
+		PERMISSION_BOOKINGS_CREATE,
+		PERMISSION_BOOKINGS_READ_OWN,
+		PERMISSION_SCHEDULES_READ,
+	},
+	ROLE_STAFF: {
+		PERMISSION_BOOKINGS_READ_ALL,
+		PERMISSION_BOOKINGS_VERIFY,
+		PERMISSION_INVOICES_ISSUE,
+		PERMISSION_PAYMENTS_COUNTER,
+		PERMISSION_BOARDING_SCAN,
+		PERMISSION_MANIFESTS_READ,
+	},
+	ROLE_DRIVER: {
+		PERMISSION_TRIPS_DRIVE,
+	},
+}
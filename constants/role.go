@@ -1,7 +1,9 @@
 package constants
 
 const (
-	ROLE_ADMIN = "admin"
-	ROLE_USER  = "user"
-	ROLE_STAFF = "staff"
+	ROLE_ADMIN   = "admin"
+	ROLE_USER    = "user"
+	ROLE_STAFF   = "staff"
+	ROLE_PARTNER = "partner" // service accounts of travel agents, used through API keys
+	ROLE_DRIVER  = "driver"
 )
@@ -5,6 +5,7 @@ import (
 	"malakashuttle/dto"
 	"malakashuttle/services"
 	"malakashuttle/utils"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -49,7 +50,8 @@ func (ac *AuthController) Login(c *gin.Context) {
 	}
 
 	// Call service
-	response, err := ac.authService.Login(req)
+	client := newClientInfo(c)
+	response, err := ac.authService.Login(req, client)
 	if err != nil {
 		utils.Response.BuildErrorResponse(c, err)
 		return
@@ -57,3 +59,119 @@ func (ac *AuthController) Login(c *gin.Context) {
 
 	utils.Response.OK(c, "Login successful", response)
 }
+
+func (ac *AuthController) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	// Validate input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := ac.authService.Refresh(req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Token refreshed successfully", response)
+}
+
+func (ac *AuthController) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+
+	// Validate input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	if err := ac.authService.Logout(req); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Logout successful", nil)
+}
+
+// RevokeUserSessions forces logout of every session of a user (for admin)
+func (ac *AuthController) RevokeUserSessions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	response, err := ac.authService.RevokeUserSessions(uint(id))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "User sessions revoked successfully", response)
+}
+
+func (ac *AuthController) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+
+	// Validate input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, req)
+		return
+	}
+
+	client := newClientInfo(c)
+	if err := ac.authService.ForgotPassword(req, client); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "If the email is registered, a password reset link has been sent", nil)
+}
+
+func (ac *AuthController) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+
+	// Validate input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	if err := ac.authService.ResetPassword(req); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Password has been reset, please login again", nil)
+}
+
+// VerifyTwoFactor completes the login of a two-factor account
+func (ac *AuthController) VerifyTwoFactor(c *gin.Context) {
+	var req dto.VerifyTwoFactorLoginRequest
+
+	// Validate input
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	client := newClientInfo(c)
+	response, err := ac.authService.VerifyTwoFactor(req, client)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Login successful", response)
+}
+
+// newClientInfo describes the client of a login request. The IP address is the throttling key, so it
+// comes from ClientIP, which only honours X-Forwarded-For from TRUSTED_PROXIES.
+func newClientInfo(c *gin.Context) dto.ClientInfo {
+	return dto.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+	}
+}
@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"fmt"
+	"malakashuttle/constants"
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BoardingController struct {
+	boardingService *services.BoardingService
+}
+
+func NewBoardingController(boardingService *services.BoardingService) *BoardingController {
+	return &BoardingController{
+		boardingService: boardingService,
+	}
+}
+
+// GetBoardingPass returns the QR code of a passenger's boarding pass as PNG image
+func (bc *BoardingController) GetBoardingPass(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+	bookingID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid booking ID", nil)
+		return
+	}
+	passengerID, err := strconv.ParseUint(c.Param("passengerId"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid passenger ID", nil)
+		return
+	}
+
+	// Users without bookings.read_all only get the passes of their own bookings
+	var userID *uint
+	if !principal.HasPermission(constants.PERMISSION_BOOKINGS_READ_ALL) {
+		userID = &principal.UserID
+	}
+
+	image, err := bc.boardingService.GetBoardingPassImage(uint(bookingID), uint(passengerID), userID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=boarding_pass_%d_%d.png", bookingID, passengerID))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "image/png", image)
+}
+
+// ScanBoardingPass validates a scanned boarding pass and boards the passenger
+func (bc *BoardingController) ScanBoardingPass(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.ScanBoardingPassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, req)
+		return
+	}
+
+	response, err := bc.boardingService.Scan(req, principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Passenger boarded successfully", response)
+}
+
+// CheckIn validates a scanned boarding pass and checks the passenger in
+func (bc *BoardingController) CheckIn(c *gin.Context) {
+	var req dto.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, req)
+		return
+	}
+
+	response, err := bc.boardingService.CheckIn(req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Passenger checked in successfully", response)
+}
+
+// UpdateBoardingStatus corrects the boarding status of a passenger
+func (bc *BoardingController) UpdateBoardingStatus(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+	passengerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid passenger ID", nil)
+		return
+	}
+
+	var req dto.UpdateBoardingStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, req)
+		return
+	}
+
+	response, err := bc.boardingService.UpdateBoardingStatus(uint(passengerID), req, principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Boarding status updated successfully", response)
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"fmt"
+	"malakashuttle/constants"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -31,8 +32,8 @@ func NewBookingController(bookingService *services.BookingService) *BookingContr
 
 // CreateBooking creates a new booking
 func (c *BookingController) CreateBooking(ctx *gin.Context) {
-	// Get user email from JWT token
-	userEmail, exists := ctx.Get("user_email")
+	// Get authenticated user from JWT token
+	principal, exists := utils.GetPrincipal(ctx)
 	if !exists {
 		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
 		return
@@ -50,17 +51,31 @@ func (c *BookingController) CreateBooking(ctx *gin.Context) {
 		return
 	}
 	// Create booking
-	booking, err := c.bookingService.CreateBooking(userEmail.(string), req)
+	// Bookings made with a partner API key count toward the partner's commission
+	var attribution *dto.BookingAttribution
+	if principal.PartnerID != nil {
+		attribution = &dto.BookingAttribution{
+			PartnerID:      *principal.PartnerID,
+			CommissionRate: principal.PartnerCommissionRate,
+		}
+	}
+
+	booking, err := c.bookingService.CreateBooking(principal.UserID, req, attribution)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
 			return
 		}
-		if strings.Contains(err.Error(), "already booked") {
+		if strings.Contains(err.Error(), "already booked") || strings.Contains(err.Error(), "no unique payment code") {
 			utils.ErrorResponse(ctx, http.StatusConflict, err.Error(), nil)
 			return
 		}
-		if strings.Contains(err.Error(), "cannot book past") {
+		if strings.Contains(err.Error(), "not verified") || strings.Contains(err.Error(), "booking blocked") {
+			utils.ErrorResponse(ctx, http.StatusForbidden, err.Error(), nil)
+			return
+		}
+		if strings.Contains(err.Error(), "cannot book past") || strings.Contains(err.Error(), "invalid passenger") ||
+			strings.Contains(err.Error(), "too many passengers") {
 			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
 			return
 		}
@@ -79,22 +94,20 @@ func (c *BookingController) GetBookingByID(ctx *gin.Context) {
 		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid booking ID", nil)
 		return
 	}
-	// Get user email from JWT token
-	userEmail, exists := ctx.Get("user_email")
+	// Get authenticated user from JWT token
+	principal, exists := utils.GetPrincipal(ctx)
 	if !exists {
 		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
 		return
 	}
 
 	// Check if user is staff (can view all bookings) or regular user (can only view own bookings)
-	userRole, _ := ctx.Get("user_role")
-	var userEmailPtr *string
-	if userRole != "staff" && userRole != "admin" {
-		email := userEmail.(string)
-		userEmailPtr = &email
+	var userIDPtr *uint
+	if !principal.HasPermission(constants.PERMISSION_BOOKINGS_READ_ALL) {
+		userIDPtr = &principal.UserID
 	}
 
-	booking, err := c.bookingService.GetBookingDetailByID(uint(bookingID), userEmailPtr)
+	booking, err := c.bookingService.GetBookingDetailByID(uint(bookingID), userIDPtr)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
@@ -109,8 +122,8 @@ func (c *BookingController) GetBookingByID(ctx *gin.Context) {
 
 // GetUserBookings gets all bookings for the authenticated user
 func (c *BookingController) GetUserBookings(ctx *gin.Context) {
-	// Get user email from JWT token
-	userEmail, exists := ctx.Get("user_email")
+	// Get authenticated user from JWT token
+	principal, exists := utils.GetPrincipal(ctx)
 	if !exists {
 		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
 		return
@@ -129,7 +142,7 @@ func (c *BookingController) GetUserBookings(ctx *gin.Context) {
 		}
 	}
 
-	bookings, err := c.bookingService.GetUserBookingsList(userEmail.(string), params, statusFilter)
+	bookings, err := c.bookingService.GetUserBookingsList(principal.UserID, params, statusFilter)
 	if err != nil {
 		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to get bookings", err.Error())
 		return
@@ -171,8 +184,8 @@ func (c *BookingController) UploadPaymentProof(ctx *gin.Context) {
 		return
 	}
 
-	// Get user email from JWT token
-	userEmail, exists := ctx.Get("user_email")
+	// Get authenticated user from JWT token
+	principal, exists := utils.GetPrincipal(ctx)
 	if !exists {
 		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
 		return
@@ -188,7 +201,7 @@ func (c *BookingController) UploadPaymentProof(ctx *gin.Context) {
 	// Get uploaded file
 	file, err := ctx.FormFile("proof_image")
 	if err != nil {
-		utils.ErrorResponse(ctx, http.StatusBadRequest, "Payment proof image is required", err.Error())
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Payment proof file is required", err.Error())
 		return
 	}
 
@@ -198,33 +211,20 @@ func (c *BookingController) UploadPaymentProof(ctx *gin.Context) {
 		return
 	}
 
-	// Validate file type
-	allowedTypes := []string{"image/jpeg", "image/jpg", "image/png"}
-	fileHeader := file.Header.Get("Content-Type")
-	isAllowed := false
-	for _, allowedType := range allowedTypes {
-		if fileHeader == allowedType {
-			isAllowed = true
-			break
-		}
-	}
-	if !isAllowed {
-		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid file type. Only JPEG, JPG, and PNG are allowed", nil)
-		return
-	}
-
+	// File type is validated by content in the service (magic bytes + decoding),
+	// the client supplied Content-Type header is not trusted
 	// Upload payment proof
-	err = c.bookingService.UploadPaymentProof(uint(bookingID), userEmail.(string), file, paymentMethod)
+	err = c.bookingService.UploadPaymentProof(uint(bookingID), principal.UserID, file, paymentMethod)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "not eligible") {
 			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
 			return
 		}
-		if strings.Contains(err.Error(), "expired") {
+		if strings.Contains(err.Error(), "expired") || strings.Contains(err.Error(), "invalid payment proof") {
 			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
 			return
 		}
-		if strings.Contains(err.Error(), "already uploaded") {
+		if strings.Contains(err.Error(), "already uploaded") || strings.Contains(err.Error(), "already been used") {
 			utils.ErrorResponse(ctx, http.StatusConflict, err.Error(), nil)
 			return
 		}
@@ -358,23 +358,21 @@ func (c *BookingController) DownloadReceipt(ctx *gin.Context) {
 		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid booking ID", nil)
 		return
 	}
-	// Get user email from JWT token
-	userEmail, exists := ctx.Get("user_email")
+	// Get authenticated user from JWT token
+	principal, exists := utils.GetPrincipal(ctx)
 	if !exists {
 		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
 		return
 	}
 
 	// Check if user is staff (can access any receipt) or regular user (can only access own receipt)
-	userRole, _ := ctx.Get("user_role")
-	var userEmailPtr *string
-	if userRole != "staff" && userRole != "admin" {
-		email := userEmail.(string)
-		userEmailPtr = &email
+	var userIDPtr *uint
+	if !principal.HasPermission(constants.PERMISSION_BOOKINGS_READ_ALL) {
+		userIDPtr = &principal.UserID
 	}
 
 	// Generate receipt
-	receiptPath, err := c.bookingService.GenerateBookingReceipt(uint(bookingID), userEmailPtr)
+	receiptPath, err := c.bookingService.GenerateBookingReceipt(uint(bookingID), userIDPtr, utils.RequestLocale(ctx))
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
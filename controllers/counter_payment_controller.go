@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"malakashuttle/constants"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CounterPaymentController struct {
+	counterPaymentService *services.CounterPaymentService
+}
+
+func NewCounterPaymentController(counterPaymentService *services.CounterPaymentService) *CounterPaymentController {
+	return &CounterPaymentController{
+		counterPaymentService: counterPaymentService,
+	}
+}
+
+// RecordCounterPayment records a cash/EDC payment for a pending booking (for staff)
+func (c *CounterPaymentController) RecordCounterPayment(ctx *gin.Context) {
+	bookingID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid booking ID", nil)
+		return
+	}
+
+	principal, exists := utils.GetPrincipal(ctx)
+	if !exists {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.RecordCounterPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	payment, err := c.counterPaymentService.RecordCounterPayment(uint(bookingID), principal.UserID, req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		if strings.Contains(err.Error(), "not pending") || strings.Contains(err.Error(), "expired") ||
+			strings.Contains(err.Error(), "amount") || strings.Contains(err.Error(), "invalid payment method") {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to record payment", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Payment recorded successfully", payment)
+}
+
+// GetCashSummary gets the end-of-shift cash summary. Staff only see their own payments,
+// admin can see all cashiers or filter by cashier_id.
+func (c *CounterPaymentController) GetCashSummary(ctx *gin.Context) {
+	principal, exists := utils.GetPrincipal(ctx)
+	if !exists {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var query dto.CashSummaryQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid query parameters", err.Error())
+		return
+	}
+
+	var userIDPtr *uint
+	if !principal.HasPermission(constants.PERMISSION_PAYMENTS_CASH_ALL) {
+		userIDPtr = &principal.UserID
+	}
+
+	summary, err := c.counterPaymentService.GetCashSummary(query, userIDPtr)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		if strings.Contains(err.Error(), "invalid") || strings.Contains(err.Error(), "must be after") {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to get cash summary", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Cash summary retrieved successfully", summary)
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InboxController struct {
+	inboxService *services.InboxService
+}
+
+func NewInboxController(inboxService *services.InboxService) *InboxController {
+	return &InboxController{
+		inboxService: inboxService,
+	}
+}
+
+// GetNotifications lists the in-app notifications of the authenticated user, newest first
+func (ic *InboxController) GetNotifications(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+	params := utils.GetPaginationParams(c)
+
+	response, err := ic.inboxService.GetNotifications(principal.UserID, params, c.Query("status"))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Notifications retrieved successfully", response)
+}
+
+// GetUnreadCount returns the number of unread notifications of the authenticated user
+func (ic *InboxController) GetUnreadCount(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	response, err := ic.inboxService.GetUnreadCount(principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Unread notification count retrieved successfully", response)
+}
+
+// MarkRead marks a notification of the authenticated user as read
+func (ic *InboxController) MarkRead(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid notification ID", nil)
+		return
+	}
+
+	response, err := ic.inboxService.MarkRead(principal.UserID, uint(id))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Notification marked as read", response)
+}
+
+// MarkAllRead marks every notification of the authenticated user as read
+func (ic *InboxController) MarkAllRead(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	response, err := ic.inboxService.MarkAllRead(principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "All notifications marked as read", response)
+}
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"fmt"
+	"malakashuttle/constants"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InvoiceController struct {
+	invoiceService *services.InvoiceService
+}
+
+func NewInvoiceController(invoiceService *services.InvoiceService) *InvoiceController {
+	return &InvoiceController{
+		invoiceService: invoiceService,
+	}
+}
+
+// GetInvoice gets the invoice of a booking once it has been issued
+func (c *InvoiceController) GetInvoice(ctx *gin.Context) {
+	bookingID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid booking ID", nil)
+		return
+	}
+
+	userIDPtr, ok := invoiceOwnerScope(ctx, constants.PERMISSION_BOOKINGS_READ_ALL)
+	if !ok {
+		return
+	}
+
+	invoice, err := c.invoiceService.GetInvoice(uint(bookingID), userIDPtr)
+	if err != nil {
+		handleInvoiceError(ctx, err, "Failed to get invoice")
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Invoice retrieved successfully", invoice)
+}
+
+// IssueInvoice issues the invoice of a booking with customer billing details
+func (c *InvoiceController) IssueInvoice(ctx *gin.Context) {
+	bookingID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid booking ID", nil)
+		return
+	}
+
+	userIDPtr, ok := invoiceOwnerScope(ctx, constants.PERMISSION_INVOICES_ISSUE)
+	if !ok {
+		return
+	}
+
+	// Only staff and admin can give discounts
+	var req dto.AdminIssueInvoiceRequest
+	if userIDPtr != nil {
+		var userReq dto.IssueInvoiceRequest
+		if err := ctx.ShouldBindJSON(&userReq); err != nil {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid request body", err.Error())
+			return
+		}
+		req.IssueInvoiceRequest = userReq
+	} else if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	issuer, _ := utils.GetPrincipal(ctx)
+	invoice, err := c.invoiceService.IssueInvoice(uint(bookingID), userIDPtr, issuer.UserID, req)
+	if err != nil {
+		handleInvoiceError(ctx, err, "Failed to issue invoice")
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Invoice issued successfully", invoice)
+}
+
+// DownloadInvoice generates and downloads the invoice PDF of a booking
+func (c *InvoiceController) DownloadInvoice(ctx *gin.Context) {
+	bookingID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid booking ID", nil)
+		return
+	}
+
+	userIDPtr, ok := invoiceOwnerScope(ctx, constants.PERMISSION_BOOKINGS_READ_ALL)
+	if !ok {
+		return
+	}
+
+	invoicePath, err := c.invoiceService.GenerateInvoicePDF(uint(bookingID), userIDPtr)
+	if err != nil {
+		handleInvoiceError(ctx, err, "Failed to generate invoice")
+		return
+	}
+
+	// Check if file exists
+	if _, err := os.Stat(invoicePath); os.IsNotExist(err) {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Invoice file not found", nil)
+		return
+	}
+
+	// Set headers for PDF download
+	ctx.Header("Content-Description", "File Transfer")
+	ctx.Header("Content-Transfer-Encoding", "binary")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=malaka_shuttle_invoice_%d.pdf", bookingID))
+	ctx.Header("Content-Type", "application/pdf")
+
+	ctx.File(invoicePath)
+}
+
+// invoiceOwnerScope returns the user ID that limits access to own bookings,
+// or nil when the user's role grants the permission for any booking
+func invoiceOwnerScope(ctx *gin.Context, permission string) (*uint, bool) {
+	principal, exists := utils.GetPrincipal(ctx)
+	if !exists {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
+		return nil, false
+	}
+
+	if !principal.HasPermission(permission) {
+		return &principal.UserID, true
+	}
+	return nil, true
+}
+
+// handleInvoiceError maps invoice service errors to responses
+func handleInvoiceError(ctx *gin.Context, err error, fallbackMessage string) {
+	if strings.Contains(err.Error(), "not found") {
+		utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+	if strings.Contains(err.Error(), "already been issued") {
+		utils.ErrorResponse(ctx, http.StatusConflict, err.Error(), nil)
+		return
+	}
+	if strings.Contains(err.Error(), "only be issued for successful") || strings.Contains(err.Error(), "discount") {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+	utils.ErrorResponse(ctx, http.StatusInternalServerError, fallbackMessage, err.Error())
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LoginProtectionController struct {
+	loginProtectionService *services.LoginProtectionService
+}
+
+func NewLoginProtectionController(loginProtectionService *services.LoginProtectionService) *LoginProtectionController {
+	return &LoginProtectionController{
+		loginProtectionService: loginProtectionService,
+	}
+}
+
+// UnlockUser clears the login lockout of a user (for admin)
+func (lc *LoginProtectionController) UnlockUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	response, err := lc.loginProtectionService.UnlockUser(uint(id))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "User login unlocked successfully", response)
+}
+
+// GetAuditLogs gets the failed login audit log (for admin)
+func (lc *LoginProtectionController) GetAuditLogs(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+
+	response, err := lc.loginProtectionService.GetAuditLogs(params, c.Query("email"), c.Query("ip_address"))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Login audit logs retrieved successfully", response)
+}
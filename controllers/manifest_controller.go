@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"fmt"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ManifestController struct {
+	manifestService *services.ManifestService
+}
+
+func NewManifestController(manifestService *services.ManifestService) *ManifestController {
+	return &ManifestController{
+		manifestService: manifestService,
+	}
+}
+
+// GetManifest returns the passenger manifest of a schedule
+func (mc *ManifestController) GetManifest(c *gin.Context) {
+	scheduleID, ok := manifestScheduleID(c)
+	if !ok {
+		return
+	}
+
+	response, err := mc.manifestService.GetManifest(scheduleID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Manifest retrieved successfully", response)
+}
+
+// DownloadManifestPDF downloads the passenger manifest of a schedule as PDF
+func (mc *ManifestController) DownloadManifestPDF(c *gin.Context) {
+	scheduleID, ok := manifestScheduleID(c)
+	if !ok {
+		return
+	}
+
+	content, err := mc.manifestService.ExportManifestPDF(scheduleID, utils.RequestLocale(c))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=malaka_shuttle_manifest_%d.pdf", scheduleID))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "application/pdf", content)
+}
+
+// DownloadManifestCSV downloads the passengers of the manifest of a schedule as CSV
+func (mc *ManifestController) DownloadManifestCSV(c *gin.Context) {
+	scheduleID, ok := manifestScheduleID(c)
+	if !ok {
+		return
+	}
+
+	content, err := mc.manifestService.ExportManifestCSV(scheduleID, utils.RequestLocale(c))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=malaka_shuttle_manifest_%d.csv", scheduleID))
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", content)
+}
+
+func manifestScheduleID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid schedule ID", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NoShowController struct {
+	noShowService *services.NoShowService
+}
+
+func NewNoShowController(noShowService *services.NoShowService) *NoShowController {
+	return &NoShowController{
+		noShowService: noShowService,
+	}
+}
+
+// GetMyNoShowStats returns the no-show statistics of the current user
+func (nc *NoShowController) GetMyNoShowStats(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	response, err := nc.noShowService.GetStats(principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "No-show statistics retrieved successfully", response)
+}
+
+// GetUserNoShowStats returns the no-show statistics of a user
+func (nc *NoShowController) GetUserNoShowStats(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	response, err := nc.noShowService.GetStats(uint(userID))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "No-show statistics retrieved successfully", response)
+}
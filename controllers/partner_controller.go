@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PartnerController struct {
+	partnerService *services.PartnerService
+}
+
+func NewPartnerController(partnerService *services.PartnerService) *PartnerController {
+	return &PartnerController{
+		partnerService: partnerService,
+	}
+}
+
+// CreatePartner registers a travel agent or OTA
+func (pc *PartnerController) CreatePartner(c *gin.Context) {
+	var req dto.CreatePartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := pc.partnerService.CreatePartner(req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.Created(c, "Partner created successfully", response)
+}
+
+// GetPartners lists all partners
+func (pc *PartnerController) GetPartners(c *gin.Context) {
+	response, err := pc.partnerService.GetPartners()
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Partners retrieved successfully", response)
+}
+
+// GetPartnerByID gets a single partner
+func (pc *PartnerController) GetPartnerByID(c *gin.Context) {
+	id, ok := parsePartnerID(c)
+	if !ok {
+		return
+	}
+
+	response, err := pc.partnerService.GetPartnerByID(id)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Partner retrieved successfully", response)
+}
+
+// UpdatePartner changes the details, commission rate or status of a partner
+func (pc *PartnerController) UpdatePartner(c *gin.Context) {
+	id, ok := parsePartnerID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdatePartnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := pc.partnerService.UpdatePartner(id, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Partner updated successfully", response)
+}
+
+// CreateAPIKey issues an API key for a partner
+func (pc *PartnerController) CreateAPIKey(c *gin.Context) {
+	id, ok := parsePartnerID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := pc.partnerService.CreateAPIKey(id, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.Created(c, "API key created, store the key now because it is not shown again", response)
+}
+
+// GetAPIKeys lists the API keys of a partner
+func (pc *PartnerController) GetAPIKeys(c *gin.Context) {
+	id, ok := parsePartnerID(c)
+	if !ok {
+		return
+	}
+
+	response, err := pc.partnerService.GetAPIKeys(id)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "API keys retrieved successfully", response)
+}
+
+// RotateAPIKey replaces an API key, the old key keeps working during the grace period
+func (pc *PartnerController) RotateAPIKey(c *gin.Context) {
+	id, ok := parsePartnerID(c)
+	if !ok {
+		return
+	}
+	keyID, err := strconv.ParseUint(c.Param("keyId"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid API key ID", nil)
+		return
+	}
+
+	response, err := pc.partnerService.RotateAPIKey(id, uint(keyID))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "API key rotated, store the new key now because it is not shown again", response)
+}
+
+// RevokeAPIKey stops an API key from working
+func (pc *PartnerController) RevokeAPIKey(c *gin.Context) {
+	id, ok := parsePartnerID(c)
+	if !ok {
+		return
+	}
+	keyID, err := strconv.ParseUint(c.Param("keyId"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid API key ID", nil)
+		return
+	}
+
+	if err := pc.partnerService.RevokeAPIKey(id, uint(keyID)); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "API key revoked successfully", nil)
+}
+
+// GetUsage shows the daily request counts of a partner's API keys
+func (pc *PartnerController) GetUsage(c *gin.Context) {
+	id, ok := parsePartnerID(c)
+	if !ok {
+		return
+	}
+
+	var query dto.PartnerReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.Response.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	response, err := pc.partnerService.GetUsage(id, query)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "API key usage retrieved successfully", response)
+}
+
+// GetCommission shows the bookings and commission of a partner for a period
+func (pc *PartnerController) GetCommission(c *gin.Context) {
+	id, ok := parsePartnerID(c)
+	if !ok {
+		return
+	}
+
+	var query dto.PartnerReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		utils.Response.BadRequest(c, "Invalid query parameters", nil)
+		return
+	}
+
+	response, err := pc.partnerService.GetCommission(id, query)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Commission report retrieved successfully", response)
+}
+
+func parsePartnerID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid partner ID", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
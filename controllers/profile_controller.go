@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileController struct {
+	profileService *services.ProfileService
+}
+
+func NewProfileController(profileService *services.ProfileService) *ProfileController {
+	return &ProfileController{
+		profileService: profileService,
+	}
+}
+
+// GetProfile gets the profile of the authenticated user
+func (pc *ProfileController) GetProfile(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	response, err := pc.profileService.GetProfile(principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Profile retrieved successfully", response)
+}
+
+// UpdateProfile changes the name or phone number of the authenticated user
+func (pc *ProfileController) UpdateProfile(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := pc.profileService.UpdateProfile(principal.UserID, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Profile updated successfully", response)
+}
+
+// ChangePassword changes the password of the authenticated user
+func (pc *ProfileController) ChangePassword(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	if err := pc.profileService.ChangePassword(principal.UserID, principal.SessionID, req); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Password changed successfully, refresh your access token to continue", nil)
+}
+
+// ChangeEmail sends a verification link to the new email of the authenticated user
+func (pc *ProfileController) ChangeEmail(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := pc.profileService.ChangeEmail(principal.UserID, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Verification link sent, the email changes once the new address is verified", response)
+}
+
+// GetSavedPassengers lists the saved passengers of the authenticated user
+func (pc *ProfileController) GetSavedPassengers(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	response, err := pc.profileService.GetSavedPassengers(principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Saved passengers retrieved successfully", response)
+}
+
+// CreateSavedPassenger saves a frequent traveller for the authenticated user
+func (pc *ProfileController) CreateSavedPassenger(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.SavedPassengerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := pc.profileService.CreateSavedPassenger(principal.UserID, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.Created(c, "Passenger saved successfully", response)
+}
+
+// GetSavedPassenger gets a saved passenger of the authenticated user with full identity details
+func (pc *ProfileController) GetSavedPassenger(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid passenger ID", nil)
+		return
+	}
+
+	response, err := pc.profileService.GetSavedPassenger(principal.UserID, uint(id))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Saved passenger retrieved successfully", response)
+}
+
+// UpdateSavedPassenger updates a saved passenger of the authenticated user
+func (pc *ProfileController) UpdateSavedPassenger(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid passenger ID", nil)
+		return
+	}
+
+	var req dto.SavedPassengerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := pc.profileService.UpdateSavedPassenger(principal.UserID, uint(id), req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Saved passenger updated successfully", response)
+}
+
+// DeleteSavedPassenger removes a saved passenger of the authenticated user
+func (pc *ProfileController) DeleteSavedPassenger(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid passenger ID", nil)
+		return
+	}
+
+	if err := pc.profileService.DeleteSavedPassenger(principal.UserID, uint(id)); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Saved passenger deleted successfully", nil)
+}
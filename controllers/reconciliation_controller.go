@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationController struct {
+	reconciliationService *services.ReconciliationService
+}
+
+func NewReconciliationController(reconciliationService *services.ReconciliationService) *ReconciliationController {
+	return &ReconciliationController{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// ImportBankStatement uploads a bank statement CSV and reconciles it against waiting bookings
+func (c *ReconciliationController) ImportBankStatement(ctx *gin.Context) {
+	principal, exists := utils.GetPrincipal(ctx)
+	if !exists {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.ImportBankStatementRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid request data", err.Error())
+		return
+	}
+
+	file, err := ctx.FormFile("statement")
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Bank statement file is required", err.Error())
+		return
+	}
+
+	// Validate file size (max 5MB)
+	if file.Size > 5*1024*1024 {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "File size too large (max 5MB)", nil)
+		return
+	}
+
+	result, err := c.reconciliationService.ImportBankStatement(principal.UserID, file, req)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid bank statement") || strings.Contains(err.Error(), "unsupported bank") {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to import bank statement", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusCreated, "Bank statement imported successfully", result)
+}
+
+// GetImports gets all bank statement imports
+func (c *ReconciliationController) GetImports(ctx *gin.Context) {
+	params := utils.GetPaginationParams(ctx)
+
+	imports, err := c.reconciliationService.GetImports(params)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to get imports", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Imports retrieved successfully", imports)
+}
+
+// GetImportByID gets a bank statement import with its entries
+func (c *ReconciliationController) GetImportByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid import ID", nil)
+		return
+	}
+
+	statementImport, err := c.reconciliationService.GetImportByID(uint(id))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to get import", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Import retrieved successfully", statementImport)
+}
+
+// GetEntries gets statement entries, defaults to the review queue
+func (c *ReconciliationController) GetEntries(ctx *gin.Context) {
+	params := utils.GetPaginationParams(ctx)
+	statusStr := ctx.Query("status")
+
+	// Parse status filter
+	var statusFilter []entities.BankStatementEntryStatus
+	if statusStr != "" {
+		for _, s := range strings.Split(statusStr, ",") {
+			statusFilter = append(statusFilter, entities.BankStatementEntryStatus(strings.TrimSpace(s)))
+		}
+	}
+
+	entries, err := c.reconciliationService.GetEntries(params, statusFilter)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to get statement entries", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Statement entries retrieved successfully", entries)
+}
+
+// ResolveEntry matches a statement entry from the review queue to a booking
+func (c *ReconciliationController) ResolveEntry(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid entry ID", nil)
+		return
+	}
+
+	principal, exists := utils.GetPrincipal(ctx)
+	if !exists {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.ResolveBankStatementEntryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	entry, err := c.reconciliationService.ResolveEntry(uint(id), principal.UserID, req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		if strings.Contains(err.Error(), "already been reconciled") || strings.Contains(err.Error(), "does not match") ||
+			strings.Contains(err.Error(), "not in waiting") {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to resolve statement entry", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Statement entry resolved successfully", entry)
+}
+
+// DismissEntry dismisses a statement entry from the review queue
+func (c *ReconciliationController) DismissEntry(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid entry ID", nil)
+		return
+	}
+
+	principal, exists := utils.GetPrincipal(ctx)
+	if !exists {
+		utils.ErrorResponse(ctx, http.StatusUnauthorized, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.DismissBankStatementEntryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	entry, err := c.reconciliationService.DismissEntry(uint(id), principal.UserID, req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			utils.ErrorResponse(ctx, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		if strings.Contains(err.Error(), "already been reconciled") {
+			utils.ErrorResponse(ctx, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to dismiss statement entry", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Statement entry dismissed successfully", entry)
+}
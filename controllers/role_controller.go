@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleController struct {
+	roleService *services.RoleService
+}
+
+func NewRoleController(roleService *services.RoleService) *RoleController {
+	return &RoleController{
+		roleService: roleService,
+	}
+}
+
+// GetPermissions lists every permission that can be granted to a role
+func (rc *RoleController) GetPermissions(c *gin.Context) {
+	utils.Response.OK(c, "Permissions retrieved successfully", rc.roleService.GetAllPermissions())
+}
+
+// GetRoles lists all roles with their permissions
+func (rc *RoleController) GetRoles(c *gin.Context) {
+	response, err := rc.roleService.GetRoles()
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Roles retrieved successfully", response)
+}
+
+// GetRoleByName gets a single role
+func (rc *RoleController) GetRoleByName(c *gin.Context) {
+	response, err := rc.roleService.GetRoleByName(c.Param("name"))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Role retrieved successfully", response)
+}
+
+// CreateRole adds a new role
+func (rc *RoleController) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := rc.roleService.CreateRole(req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.Created(c, "Role created successfully", response)
+}
+
+// UpdateRole replaces the permissions of a role
+func (rc *RoleController) UpdateRole(c *gin.Context) {
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := rc.roleService.UpdateRole(c.Param("name"), req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Role updated successfully", response)
+}
+
+// DeleteRole removes a custom role that is not assigned to any user
+func (rc *RoleController) DeleteRole(c *gin.Context) {
+	if err := rc.roleService.DeleteRole(c.Param("name")); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Role deleted successfully", nil)
+}
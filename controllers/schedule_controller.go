@@ -1,10 +1,14 @@
 package controllers
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
+	"malakashuttle/config"
 	"malakashuttle/dto"
 	"malakashuttle/services"
 	"malakashuttle/utils"
@@ -14,11 +18,13 @@ import (
 
 type ScheduleController struct {
 	scheduleService *services.ScheduleService
+	seatService     *services.SeatAvailabilityService
 }
 
-func NewScheduleController(scheduleService *services.ScheduleService) *ScheduleController {
+func NewScheduleController(scheduleService *services.ScheduleService, seatService *services.SeatAvailabilityService) *ScheduleController {
 	return &ScheduleController{
 		scheduleService: scheduleService,
+		seatService:     seatService,
 	}
 }
 
@@ -92,6 +98,37 @@ func (c *ScheduleController) DeleteSchedule(ctx *gin.Context) {
 	utils.SuccessResponse(ctx, http.StatusOK, "Schedule deleted successfully", nil)
 }
 
+// CancelSchedule - Cancel schedule beserta bookingnya (Admin only)
+func (c *ScheduleController) CancelSchedule(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid schedule ID", nil)
+		return
+	}
+
+	var req dto.CancelScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid request data", err.Error())
+		return
+	}
+
+	response, err := c.scheduleService.CancelSchedule(uint(id), req)
+	if err != nil {
+		switch err.Error() {
+		case "schedule not found":
+			utils.ErrorResponse(ctx, http.StatusNotFound, "Schedule not found", nil)
+		case "schedule is already cancelled":
+			utils.ErrorResponse(ctx, http.StatusConflict, err.Error(), nil)
+		default:
+			utils.ErrorResponse(ctx, http.StatusBadRequest, "Failed to cancel schedule", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(ctx, http.StatusOK, "Schedule cancelled successfully", response)
+}
+
 // GetAllSchedules - Get all schedules with pagination (Admin only)
 func (c *ScheduleController) GetAllSchedules(ctx *gin.Context) {
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
@@ -150,3 +187,52 @@ func (c *ScheduleController) GetScheduleByID(ctx *gin.Context) {
 
 	utils.SuccessResponse(ctx, http.StatusOK, "Schedule retrieved successfully", schedule)
 }
+
+// StreamSeatAvailability - Stream perubahan kursi schedule lewat Server-Sent Events.
+// Event pertama berisi kondisi kursi saat ini, setelah itu setiap perubahan dikirim sebagai event "seats".
+func (c *ScheduleController) StreamSeatAvailability(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.ErrorResponse(ctx, http.StatusBadRequest, "Invalid schedule ID", nil)
+		return
+	}
+
+	snapshot, subscription, err := c.seatService.Subscribe(uint(id))
+	if err != nil {
+		if err.Error() == "schedule not found" {
+			utils.ErrorResponse(ctx, http.StatusNotFound, "Schedule not found", nil)
+			return
+		}
+		utils.ErrorResponse(ctx, http.StatusInternalServerError, "Failed to stream seats", err.Error())
+		return
+	}
+	defer subscription.Close()
+
+	heartbeat := time.NewTicker(config.GetRealtimeConfig().HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no") // jangan di-buffer oleh reverse proxy
+	ctx.SSEvent("seats", snapshot)
+	ctx.Writer.Flush()
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case message, ok := <-subscription.Messages():
+			if !ok {
+				return false
+			}
+			ctx.SSEvent("seats", json.RawMessage(message))
+			return true
+		case <-heartbeat.C:
+			// Komentar SSE menjaga koneksi tetap hidup melewati proxy
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
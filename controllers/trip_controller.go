@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TripController struct {
+	tripService *services.TripService
+}
+
+func NewTripController(tripService *services.TripService) *TripController {
+	return &TripController{
+		tripService: tripService,
+	}
+}
+
+// AssignDriver assigns a driver to a schedule or removes the assignment
+func (tc *TripController) AssignDriver(c *gin.Context) {
+	scheduleID, ok := manifestScheduleID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AssignDriverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, req)
+		return
+	}
+
+	response, err := tc.tripService.AssignDriver(scheduleID, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Driver assigned successfully", response)
+}
+
+// GetScheduleIncidents lists the incidents reported on a schedule
+func (tc *TripController) GetScheduleIncidents(c *gin.Context) {
+	scheduleID, ok := manifestScheduleID(c)
+	if !ok {
+		return
+	}
+
+	response, err := tc.tripService.GetScheduleIncidents(scheduleID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Incidents retrieved successfully", response)
+}
+
+// GetUpcomingTrips lists the trips of the authenticated driver that have not ended yet
+func (tc *TripController) GetUpcomingTrips(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+	params := utils.GetPaginationParams(c)
+
+	response, err := tc.tripService.GetUpcomingTrips(principal.UserID, params)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Trips retrieved successfully", response)
+}
+
+// GetTrip returns a trip of the authenticated driver
+func (tc *TripController) GetTrip(c *gin.Context) {
+	driverID, scheduleID, ok := driverTripParams(c)
+	if !ok {
+		return
+	}
+
+	response, err := tc.tripService.GetTrip(scheduleID, driverID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Trip retrieved successfully", response)
+}
+
+// GetTripManifest returns the passenger manifest of a trip of the authenticated driver
+func (tc *TripController) GetTripManifest(c *gin.Context) {
+	driverID, scheduleID, ok := driverTripParams(c)
+	if !ok {
+		return
+	}
+
+	response, err := tc.tripService.GetTripManifest(scheduleID, driverID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Manifest retrieved successfully", response)
+}
+
+// ScanBoardingPass boards a passenger of a trip of the authenticated driver
+func (tc *TripController) ScanBoardingPass(c *gin.Context) {
+	driverID, scheduleID, ok := driverTripParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.DriverScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, req)
+		return
+	}
+
+	response, err := tc.tripService.ScanBoardingPass(scheduleID, driverID, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Passenger boarded successfully", response)
+}
+
+// StartTrip marks a trip of the authenticated driver as departed
+func (tc *TripController) StartTrip(c *gin.Context) {
+	driverID, scheduleID, ok := driverTripParams(c)
+	if !ok {
+		return
+	}
+
+	response, err := tc.tripService.StartTrip(scheduleID, driverID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Trip started successfully", response)
+}
+
+// EndTrip marks a trip of the authenticated driver as completed
+func (tc *TripController) EndTrip(c *gin.Context) {
+	driverID, scheduleID, ok := driverTripParams(c)
+	if !ok {
+		return
+	}
+
+	response, err := tc.tripService.EndTrip(scheduleID, driverID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Trip ended successfully", response)
+}
+
+// ReportIncident reports an incident on a trip of the authenticated driver
+func (tc *TripController) ReportIncident(c *gin.Context) {
+	driverID, scheduleID, ok := driverTripParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReportIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, req)
+		return
+	}
+
+	response, err := tc.tripService.ReportIncident(scheduleID, driverID, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.Created(c, "Incident reported successfully", response)
+}
+
+// GetTripIncidents lists the incidents reported on a trip of the authenticated driver
+func (tc *TripController) GetTripIncidents(c *gin.Context) {
+	driverID, scheduleID, ok := driverTripParams(c)
+	if !ok {
+		return
+	}
+
+	response, err := tc.tripService.GetTripIncidents(scheduleID, driverID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Incidents retrieved successfully", response)
+}
+
+// driverTripParams returns the authenticated driver and the trip in the URL
+func driverTripParams(c *gin.Context) (uint, uint, bool) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return 0, 0, false
+	}
+	scheduleID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid trip ID", nil)
+		return 0, 0, false
+	}
+	return principal.UserID, uint(scheduleID), true
+}
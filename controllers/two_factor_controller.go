@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TwoFactorController struct {
+	twoFactorService *services.TwoFactorService
+}
+
+func NewTwoFactorController(twoFactorService *services.TwoFactorService) *TwoFactorController {
+	return &TwoFactorController{
+		twoFactorService: twoFactorService,
+	}
+}
+
+// GetStatus shows the two-factor state of the authenticated user
+func (tc *TwoFactorController) GetStatus(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	response, err := tc.twoFactorService.GetStatus(principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Two-factor status retrieved successfully", response)
+}
+
+// Setup generates a TOTP secret and provisioning URI for the authenticated user
+func (tc *TwoFactorController) Setup(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	response, err := tc.twoFactorService.Setup(principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Scan the provisioning URI with your authenticator app and confirm a code to enable two-factor authentication", response)
+}
+
+// Enable confirms the setup with a code from the authenticator app
+func (tc *TwoFactorController) Enable(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := tc.twoFactorService.Enable(principal.UserID, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Two-factor authentication enabled, store the recovery codes in a safe place", response)
+}
+
+// Disable turns off two-factor authentication for the authenticated user
+func (tc *TwoFactorController) Disable(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	if err := tc.twoFactorService.Disable(principal.UserID, req); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Two-factor authentication disabled", nil)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the authenticated user
+func (tc *TwoFactorController) RegenerateRecoveryCodes(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := tc.twoFactorService.RegenerateRecoveryCodes(principal.UserID, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Recovery codes regenerated successfully", response)
+}
+
+// GetPolicies gets the two-factor policy per role (for admin)
+func (tc *TwoFactorController) GetPolicies(c *gin.Context) {
+	response, err := tc.twoFactorService.GetPolicies()
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Two-factor policies retrieved successfully", response)
+}
+
+// UpdatePolicy requires or stops requiring two-factor authentication for a role (for admin)
+func (tc *TwoFactorController) UpdatePolicy(c *gin.Context) {
+	var req dto.UpdateTwoFactorPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := tc.twoFactorService.UpdatePolicy(c.Param("role"), req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Two-factor policy updated successfully", response)
+}
+
+// ResetUser removes two-factor authentication from a user (for admin)
+func (tc *TwoFactorController) ResetUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	if err := tc.twoFactorService.ResetUser(uint(id)); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Two-factor authentication reset successfully", nil)
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VerificationController struct {
+	verificationService *services.VerificationService
+}
+
+func NewVerificationController(verificationService *services.VerificationService) *VerificationController {
+	return &VerificationController{
+		verificationService: verificationService,
+	}
+}
+
+// GetStatus shows which contact details of the authenticated user are verified
+func (vc *VerificationController) GetStatus(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	response, err := vc.verificationService.GetStatus(principal.UserID)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Verification status retrieved successfully", response)
+}
+
+// Resend sends a new email link or phone code to the authenticated user
+func (vc *VerificationController) Resend(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, req)
+		return
+	}
+
+	if err := vc.verificationService.Resend(principal.UserID, req); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Verification code sent successfully", nil)
+}
+
+// VerifyEmail verifies an email address with the token from the emailed link
+func (vc *VerificationController) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	if err := vc.verificationService.VerifyEmail(req); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Email verified successfully", nil)
+}
+
+// VerifyPhone verifies the phone number of the authenticated user
+func (vc *VerificationController) VerifyPhone(c *gin.Context) {
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		utils.Response.Unauthorized(c, "User not authenticated", nil)
+		return
+	}
+
+	var req dto.VerifyPhoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	if err := vc.verificationService.VerifyPhone(principal.UserID, req); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Phone number verified successfully", nil)
+}
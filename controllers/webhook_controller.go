@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"malakashuttle/dto"
+	"malakashuttle/services"
+	"malakashuttle/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookController struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookController(webhookService *services.WebhookService) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// GetEvents lists the events webhook endpoints can subscribe to
+func (wc *WebhookController) GetEvents(c *gin.Context) {
+	utils.Response.OK(c, "Webhook events retrieved successfully", wc.webhookService.GetEvents())
+}
+
+// CreateEndpoint registers a webhook endpoint
+func (wc *WebhookController) CreateEndpoint(c *gin.Context) {
+	var req dto.CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := wc.webhookService.CreateEndpoint(req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.Created(c, "Webhook endpoint created, store the secret now because it is not shown again", response)
+}
+
+// GetEndpoints lists the webhook endpoints
+func (wc *WebhookController) GetEndpoints(c *gin.Context) {
+	response, err := wc.webhookService.GetEndpoints()
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Webhook endpoints retrieved successfully", response)
+}
+
+// GetEndpoint gets a webhook endpoint
+func (wc *WebhookController) GetEndpoint(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+
+	response, err := wc.webhookService.GetEndpoint(id)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Webhook endpoint retrieved successfully", response)
+}
+
+// UpdateEndpoint changes a webhook endpoint
+func (wc *WebhookController) UpdateEndpoint(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.Response.HandleValidationError(c, err, nil)
+		return
+	}
+
+	response, err := wc.webhookService.UpdateEndpoint(id, req)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Webhook endpoint updated successfully", response)
+}
+
+// DeleteEndpoint removes a webhook endpoint
+func (wc *WebhookController) DeleteEndpoint(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+
+	if err := wc.webhookService.DeleteEndpoint(id); err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Webhook endpoint deleted successfully", nil)
+}
+
+// RotateSecret replaces the signing secret of a webhook endpoint
+func (wc *WebhookController) RotateSecret(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+
+	response, err := wc.webhookService.RotateSecret(id)
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Webhook secret rotated, store the secret now because it is not shown again", response)
+}
+
+// GetDeliveries lists the deliveries of a webhook endpoint
+func (wc *WebhookController) GetDeliveries(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	params := utils.GetPaginationParams(c)
+
+	response, err := wc.webhookService.GetDeliveries(id, params, c.Query("status"))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Webhook deliveries retrieved successfully", response)
+}
+
+// GetDelivery gets a delivery with its payload and attempt log
+func (wc *WebhookController) GetDelivery(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.ParseUint(c.Param("deliveryId"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid delivery ID", nil)
+		return
+	}
+
+	response, err := wc.webhookService.GetDelivery(id, uint(deliveryID))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Webhook delivery retrieved successfully", response)
+}
+
+// Redeliver queues a delivery again
+func (wc *WebhookController) Redeliver(c *gin.Context) {
+	id, ok := parseWebhookEndpointID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.ParseUint(c.Param("deliveryId"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid delivery ID", nil)
+		return
+	}
+
+	response, err := wc.webhookService.Redeliver(id, uint(deliveryID))
+	if err != nil {
+		utils.Response.BuildErrorResponse(c, err)
+		return
+	}
+
+	utils.Response.OK(c, "Webhook delivery queued for redelivery", response)
+}
+
+func parseWebhookEndpointID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.Response.BadRequest(c, "Invalid webhook endpoint ID", nil)
+		return 0, false
+	}
+	return uint(id), true
+}
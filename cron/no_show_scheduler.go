@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"fmt"
+	"log"
+	"malakashuttle/config"
+	"malakashuttle/services"
+
+	"github.com/robfig/cron/v3"
+)
+
+// NoShowScheduler marks passengers who did not board a departed schedule as no-show
+type NoShowScheduler struct {
+	noShowService *services.NoShowService
+	cron          *cron.Cron
+}
+
+func NewNoShowScheduler(noShowService *services.NoShowService) *NoShowScheduler {
+	return &NoShowScheduler{
+		noShowService: noShowService,
+		cron:          cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger))),
+	}
+}
+
+// Start starts checking departed schedules for no-shows every NO_SHOW_CHECK_INTERVAL
+func (s *NoShowScheduler) Start() {
+	interval := config.GetNoShowConfig().CheckInterval
+	_, err := s.cron.AddFunc(fmt.Sprintf("@every %s", interval), func() {
+		marked, err := s.noShowService.MarkNoShows()
+		if err != nil {
+			log.Printf("Error marking no-shows: %v", err)
+		}
+		if marked > 0 {
+			log.Printf("Marked %d passengers as no-show", marked)
+		}
+	})
+	if err != nil {
+		log.Printf("Error scheduling no-show job: %v", err)
+		return
+	}
+
+	s.cron.Start()
+	log.Printf("No-show scheduler started - checking departed schedules every %s", interval)
+}
+
+// Stop stops the scheduler
+func (s *NoShowScheduler) Stop() {
+	if s.cron != nil {
+		s.cron.Stop()
+		log.Println("No-show scheduler stopped")
+	}
+}
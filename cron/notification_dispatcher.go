@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"fmt"
+	"log"
+	"malakashuttle/config"
+	"malakashuttle/services"
+
+	"github.com/robfig/cron/v3"
+)
+
+// NotificationDispatcher delivers queued notifications in the background
+type NotificationDispatcher struct {
+	notificationService *services.NotificationService
+	cron                *cron.Cron
+}
+
+func NewNotificationDispatcher(notificationService *services.NotificationService) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		notificationService: notificationService,
+		// A slow channel must not start a second run on top of the current one
+		cron: cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger))),
+	}
+}
+
+// Start starts delivering due outbox messages every NOTIFICATION_DISPATCH_INTERVAL
+func (d *NotificationDispatcher) Start() {
+	interval := config.GetNotificationConfig().DispatchInterval
+	_, err := d.cron.AddFunc(fmt.Sprintf("@every %s", interval), func() {
+		if err := d.notificationService.DispatchPending(); err != nil {
+			log.Printf("Error dispatching notifications: %v", err)
+		}
+	})
+
+	if err != nil {
+		log.Printf("Error scheduling notification dispatcher: %v", err)
+		return
+	}
+
+	d.cron.Start()
+	log.Printf("Notification dispatcher started - delivering notifications every %s", interval)
+}
+
+// Stop stops the dispatcher
+func (d *NotificationDispatcher) Stop() {
+	if d.cron != nil {
+		d.cron.Stop()
+		log.Println("Notification dispatcher stopped")
+	}
+}
@@ -0,0 +1,65 @@
+package cron
+
+import (
+	"log"
+	"malakashuttle/services"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ReminderScheduler sends payment and departure reminders
+type ReminderScheduler struct {
+	reminderService *services.ReminderService
+	cron            *cron.Cron
+}
+
+func NewReminderScheduler(reminderService *services.ReminderService) *ReminderScheduler {
+	return &ReminderScheduler{
+		reminderService: reminderService,
+		cron:            cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger))),
+	}
+}
+
+// Start schedules the reminder jobs
+func (s *ReminderScheduler) Start() {
+	// Pending bookings only live for 30 minutes, so payment reminders are checked every minute
+	_, err := s.cron.AddFunc("* * * * *", func() {
+		sent, err := s.reminderService.SendPaymentReminders()
+		if err != nil {
+			log.Printf("Error sending payment reminders: %v", err)
+		}
+		if sent > 0 {
+			log.Printf("Queued %d payment reminders", sent)
+		}
+	})
+	if err != nil {
+		log.Printf("Error scheduling payment reminder job: %v", err)
+		return
+	}
+
+	// Departure reminders are checked every 5 minutes
+	_, err = s.cron.AddFunc("*/5 * * * *", func() {
+		sent, err := s.reminderService.SendDepartureReminders()
+		if err != nil {
+			log.Printf("Error sending departure reminders: %v", err)
+		}
+		if sent > 0 {
+			log.Printf("Queued %d departure reminders", sent)
+		}
+	})
+	if err != nil {
+		log.Printf("Error scheduling departure reminder job: %v", err)
+		return
+	}
+
+	s.cron.Start()
+	log.Println("Reminder scheduler started - checking payment reminders every minute and departure reminders every 5 minutes")
+}
+
+// Stop stops the scheduler
+func (s *ReminderScheduler) Stop() {
+	if s.cron != nil {
+		s.cron.Stop()
+		log.Println("Reminder scheduler stopped")
+	}
+}
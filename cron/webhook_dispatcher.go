@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"fmt"
+	"log"
+	"malakashuttle/config"
+	"malakashuttle/services"
+
+	"github.com/robfig/cron/v3"
+)
+
+// WebhookDispatcher delivers queued webhook events in the background
+type WebhookDispatcher struct {
+	webhookService *services.WebhookService
+	cron           *cron.Cron
+}
+
+func NewWebhookDispatcher(webhookService *services.WebhookService) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		webhookService: webhookService,
+		// A slow endpoint must not start a second run on top of the current one
+		cron: cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DefaultLogger))),
+	}
+}
+
+// Start starts delivering due webhook events every WEBHOOK_DISPATCH_INTERVAL
+func (d *WebhookDispatcher) Start() {
+	interval := config.GetWebhookConfig().DispatchInterval
+	_, err := d.cron.AddFunc(fmt.Sprintf("@every %s", interval), func() {
+		if err := d.webhookService.DispatchPending(); err != nil {
+			log.Printf("Error dispatching webhooks: %v", err)
+		}
+	})
+
+	if err != nil {
+		log.Printf("Error scheduling webhook dispatcher: %v", err)
+		return
+	}
+
+	d.cron.Start()
+	log.Printf("Webhook dispatcher started - delivering webhooks every %s", interval)
+}
+
+// Stop stops the dispatcher
+func (d *WebhookDispatcher) Stop() {
+	if d.cron != nil {
+		d.cron.Stop()
+		log.Println("Webhook dispatcher stopped")
+	}
+}
@@ -17,20 +17,57 @@ type (
 		Password string `json:"password" binding:"required,min=8"`
 	}
 
+	RefreshTokenRequest struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	LogoutRequest struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	ForgotPasswordRequest struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	ResetPasswordRequest struct {
+		Token       string `json:"token" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required,min=8"`
+	}
+
+	// ClientInfo describes the client a session was created from
+	ClientInfo struct {
+		UserAgent string
+		IPAddress string
+	}
+
 	RegisterResponse struct {
-		Email string `json:"email"`
+		Email                string `json:"email"`
+		VerificationRequired bool   `json:"verification_required"`
 	}
 
-	// LoginResponse response untuk login
+	// LoginResponse response untuk login. When two-factor authentication is enabled only the
+	// challenge token is returned, it has to be exchanged together with a TOTP code for the tokens.
 	LoginResponse struct {
-		Token string `json:"token"`
+		Token                  string `json:"token,omitempty"`
+		RefreshToken           string `json:"refresh_token,omitempty"`
+		TokenType              string `json:"token_type,omitempty"`
+		ExpiresIn              int64  `json:"expires_in,omitempty"`
+		TwoFactorRequired      bool   `json:"two_factor_required"`
+		ChallengeToken         string `json:"challenge_token,omitempty"`
+		TwoFactorSetupRequired bool   `json:"two_factor_setup_required,omitempty"`
+	}
+
+	RevokeSessionsResponse struct {
+		UserID          uint  `json:"user_id"`
+		RevokedSessions int64 `json:"revoked_sessions"`
 	}
 )
 
 // NewRegisterResponseFromEntity creates RegisterResponse from User entity
 func NewRegisterResponseFromEntity(user *entities.User) *RegisterResponse {
 	return &RegisterResponse{
-		Email: user.Email,
+		Email:                user.Email,
+		VerificationRequired: !user.IsVerified(),
 	}
 }
 
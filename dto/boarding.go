@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+// ScanBoardingPassRequest is sent by the scanner of the staff member or driver boarding a schedule
+type ScanBoardingPassRequest struct {
+	ScheduleID uint   `json:"schedule_id" binding:"required,min=1"`
+	Code       string `json:"code" binding:"required,max=255"`
+}
+
+// BoardingScanResponse is the passenger who was boarded by a scan
+type BoardingScanResponse struct {
+	BookingID     uint      `json:"booking_id"`
+	PassengerID   uint      `json:"passenger_id"`
+	PassengerName string    `json:"passenger_name"`
+	SeatNumber    string    `json:"seat_number"`
+	ScheduleID    uint      `json:"schedule_id"`
+	BoardedAt     time.Time `json:"boarded_at"`
+}
+
+// CheckInRequest checks a passenger in at the pool by scanning their boarding pass
+type CheckInRequest struct {
+	ScheduleID uint   `json:"schedule_id" binding:"required,min=1"`
+	Code       string `json:"code" binding:"required,max=255"`
+}
+
+// CheckInResponse is the passenger who was checked in
+type CheckInResponse struct {
+	BookingID     uint      `json:"booking_id"`
+	PassengerID   uint      `json:"passenger_id"`
+	PassengerName string    `json:"passenger_name"`
+	SeatNumber    string    `json:"seat_number"`
+	ScheduleID    uint      `json:"schedule_id"`
+	CheckedInAt   time.Time `json:"checked_in_at"`
+}
+
+// UpdateBoardingStatusRequest corrects the boarding state of a passenger by hand
+type UpdateBoardingStatusRequest struct {
+	Status entities.BoardingStatus `json:"status" binding:"required,oneof=not_boarded checked_in boarded no_show"`
+}
+
+// BoardingStatusResponse is the boarding state of a passenger
+type BoardingStatusResponse struct {
+	PassengerID    uint                    `json:"passenger_id"`
+	BookingID      uint                    `json:"booking_id"`
+	BoardingStatus entities.BoardingStatus `json:"boarding_status"`
+	CheckedInAt    *time.Time              `json:"checked_in_at,omitempty"`
+	BoardedAt      *time.Time              `json:"boarded_at,omitempty"`
+	NoShowAt       *time.Time              `json:"no_show_at,omitempty"`
+}
@@ -7,10 +7,13 @@ import (
 	"malakashuttle/entities"
 )
 
-// BookingPassenger represents passenger data for booking
+// BookingPassenger represents passenger data for booking. Either the passenger name or a
+// saved passenger of the user is required, fields that are sent override the saved passenger.
 type BookingPassenger struct {
-	PassengerName string `json:"passenger_name" validate:"required,min=2,max=100"`
-	SeatID        uint   `json:"seat_id" validate:"required,min=1"`
+	PassengerName    string `json:"passenger_name" validate:"omitempty,min=2,max=100"`
+	SavedPassengerID *uint  `json:"saved_passenger_id,omitempty" validate:"omitempty,min=1"`
+	SeatID           uint   `json:"seat_id" validate:"required,min=1"`
+	PassengerIdentityRequest
 }
 
 // CreateBookingRequest represents the request payload for creating a booking
@@ -25,6 +28,7 @@ type BookingResponse struct {
 	Status      entities.BookingStatus `json:"status"`
 	ExpiresAt   time.Time              `json:"expires_at"`
 	TotalAmount float64                `json:"total_amount"`
+	UniqueCode  int                    `json:"unique_code"`
 	Schedule    *ScheduleResponse      `json:"schedule,omitempty"`
 	Passengers  []PassengerResponse    `json:"passengers,omitempty"`
 	CreatedAt   time.Time              `json:"created_at"`
@@ -32,8 +36,13 @@ type BookingResponse struct {
 
 // PassengerResponse represents passenger data in response
 type PassengerResponse struct {
-	PassengerName string `json:"passenger_name"`
-	SeatNumber    string `json:"seat_number"`
+	ID            uint                       `json:"id"`
+	PassengerName string                     `json:"passenger_name"`
+	SeatNumber    string                     `json:"seat_number"`
+	Identity      *PassengerIdentityResponse `json:"identity,omitempty"`
+	// BoardingStatus is only set for paid bookings
+	BoardingStatus entities.BoardingStatus `json:"boarding_status,omitempty"`
+	BoardedAt      *time.Time              `json:"boarded_at,omitempty"`
 }
 
 // BookingDetailResponse represents booking detail in response
@@ -89,6 +98,7 @@ type BookingListResponse struct {
 	BookingStatus  entities.BookingStatus `json:"booking_status"`
 	ExpiresAt      string                 `json:"expires_at"`
 	TotalAmount    float64                `json:"total_amount"`
+	UniqueCode     int                    `json:"unique_code"`
 	Origin         string                 `json:"origin"`
 	Destination    string                 `json:"destination"`
 	DepartureTime  string                 `json:"departure_time"`
@@ -104,6 +114,7 @@ type BookingFullResponse struct {
 	BookingStatus    entities.BookingStatus    `json:"booking_status"`
 	ExpiresAt        string                    `json:"expires_at"`
 	TotalAmount      float64                   `json:"total_amount"`
+	UniqueCode       int                       `json:"unique_code"`
 	Origin           string                    `json:"origin"`
 	Destination      string                    `json:"destination"`
 	DepartureTime    string                    `json:"departure_time"`
@@ -118,9 +129,10 @@ type BookingFullResponse struct {
 
 // PassengerDetailResponse represents detailed passenger data
 type PassengerDetailResponse struct {
-	PassengerName string  `json:"passenger_name"`
-	SeatNumber    string  `json:"seat_number"`
-	Price         float64 `json:"price"`
+	PassengerName string                     `json:"passenger_name"`
+	SeatNumber    string                     `json:"seat_number"`
+	Price         float64                    `json:"price"`
+	Identity      *PassengerIdentityResponse `json:"identity,omitempty"`
 }
 
 // PaymentInfoResponse represents payment information
@@ -166,7 +178,12 @@ func (b *BookingResponse) FromEntity(booking *entities.Booking) {
 	b.Passengers = make([]PassengerResponse, len(booking.BookingDetails))
 	for i, detail := range booking.BookingDetails {
 		b.Passengers[i] = PassengerResponse{
+			ID:            detail.ID,
 			PassengerName: detail.PassengerName,
+			BoardedAt:     detail.BoardedAt,
+		}
+		if booking.Status == entities.BookingStatusSuccess {
+			b.Passengers[i].BoardingStatus = detail.BoardingStatus()
 		}
 
 		// Add seat number if seat is loaded
@@ -175,8 +192,9 @@ func (b *BookingResponse) FromEntity(booking *entities.Booking) {
 		}
 	}
 
-	// Use the pre-calculated payment amount from the booking entity
-	b.TotalAmount = booking.PaymentAmount
+	// Use the amount charged for the booking, counter payments are charged without the unique code
+	b.TotalAmount = booking.ChargedAmount()
+	b.UniqueCode = booking.ChargedUniqueCode()
 }
 
 // NewBookingResponseFromEntity creates a new BookingResponse from a Booking entity
@@ -202,7 +220,8 @@ func (b *BookingListResponse) FromEntity(booking *entities.Booking) {
 	}
 	// Set passenger count and use pre-calculated payment amount
 	b.PassengerCount = len(booking.BookingDetails)
-	b.TotalAmount = booking.PaymentAmount
+	b.TotalAmount = booking.ChargedAmount()
+	b.UniqueCode = booking.ChargedUniqueCode()
 }
 
 // NewBookingListResponseFromEntity creates a new BookingListResponse from a Booking entity
@@ -242,8 +261,9 @@ func (b *BookingFullResponse) FromEntity(booking *entities.Booking) {
 		}
 	}
 
-	// Use pre-calculated payment amount from booking entity
-	b.TotalAmount = booking.PaymentAmount
+	// Use the amount charged for the booking, counter payments are charged without the unique code
+	b.TotalAmount = booking.ChargedAmount()
+	b.UniqueCode = booking.ChargedUniqueCode()
 
 	// Map payment information if available
 	if booking.Payment != nil {
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"malakashuttle/entities"
+)
+
+// RecordCounterPaymentRequest represents a cash/EDC payment taken at the pool counter
+type RecordCounterPaymentRequest struct {
+	PaymentMethod  string  `json:"payment_method" binding:"required,oneof=cash edc"`
+	AmountReceived float64 `json:"amount_received" binding:"required,gt=0"`
+}
+
+// CashSummaryQuery represents the shift period for the cash summary.
+// Format: "YYYY-MM-DD HH:mm" (WIB), defaults to today until now.
+type CashSummaryQuery struct {
+	From      string `form:"from"`
+	To        string `form:"to"`
+	CashierID uint   `form:"cashier_id"`
+}
+
+// CounterPaymentResponse represents a recorded counter payment
+type CounterPaymentResponse struct {
+	BookingID      uint       `json:"booking_id"`
+	PaymentMethod  string     `json:"payment_method"`
+	Amount         float64    `json:"amount"`
+	AmountReceived float64    `json:"amount_received"`
+	ChangeAmount   float64    `json:"change_amount"`
+	CashierID      uint       `json:"cashier_id"`
+	CashierName    string     `json:"cashier_name,omitempty"`
+	PaymentDate    *time.Time `json:"payment_date"`
+}
+
+// CashSummaryMethodResponse represents totals for one payment method
+type CashSummaryMethodResponse struct {
+	PaymentMethod  string  `json:"payment_method"`
+	Transactions   int     `json:"transactions"`
+	TotalAmount    float64 `json:"total_amount"`
+	AmountReceived float64 `json:"amount_received"`
+	ChangeGiven    float64 `json:"change_given"`
+}
+
+// CashierSummaryResponse represents the end-of-shift summary of one staff user
+type CashierSummaryResponse struct {
+	CashierID    uint                        `json:"cashier_id"`
+	CashierName  string                      `json:"cashier_name"`
+	CashierEmail string                      `json:"cashier_email"`
+	Transactions int                         `json:"transactions"`
+	TotalAmount  float64                     `json:"total_amount"`
+	CashInDrawer float64                     `json:"cash_in_drawer"` // Cash received minus change given
+	ByMethod     []CashSummaryMethodResponse `json:"by_method"`
+	Payments     []CounterPaymentResponse    `json:"payments"`
+}
+
+// CashSummaryResponse represents counter payment totals per staff user for a period
+type CashSummaryResponse struct {
+	From     string                   `json:"from"`
+	To       string                   `json:"to"`
+	Cashiers []CashierSummaryResponse `json:"cashiers"`
+}
+
+// NewCounterPaymentResponseFromEntity creates CounterPaymentResponse from Payment entity
+func NewCounterPaymentResponseFromEntity(payment *entities.Payment) *CounterPaymentResponse {
+	response := &CounterPaymentResponse{
+		BookingID:      payment.BookingID,
+		PaymentMethod:  payment.PaymentMethod,
+		Amount:         payment.Amount,
+		AmountReceived: payment.AmountReceived,
+		ChangeAmount:   payment.ChangeAmount,
+		PaymentDate:    payment.PaymentDate,
+	}
+	if payment.CashierID != nil {
+		response.CashierID = *payment.CashierID
+	}
+	if payment.Cashier != nil {
+		response.CashierName = strings.TrimSpace(payment.Cashier.FirstName + " " + payment.Cashier.LastName)
+	}
+	return response
+}
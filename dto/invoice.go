@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"time"
+
+	"malakashuttle/entities"
+)
+
+// IssueInvoiceRequest represents the customer billing details for issuing an invoice.
+// Empty fields are filled from the booking owner's account.
+type IssueInvoiceRequest struct {
+	BillingName    string `json:"billing_name" binding:"max=150"`
+	BillingCompany string `json:"billing_company" binding:"max=150"`
+	BillingAddress string `json:"billing_address" binding:"max=500"`
+	BillingNPWP    string `json:"billing_npwp" binding:"omitempty,max=30"`
+	BillingEmail   string `json:"billing_email" binding:"omitempty,email"`
+	BillingPhone   string `json:"billing_phone" binding:"max=30"`
+}
+
+// AdminIssueInvoiceRequest represents the request for issuing an invoice by admin/staff, which may include a discount
+type AdminIssueInvoiceRequest struct {
+	IssueInvoiceRequest
+	DiscountAmount float64 `json:"discount_amount" binding:"min=0"`
+	DiscountNote   string  `json:"discount_note" binding:"max=255"`
+}
+
+// InvoicePartyResponse represents seller or customer details on an invoice
+type InvoicePartyResponse struct {
+	Name    string `json:"name"`
+	Company string `json:"company,omitempty"`
+	Address string `json:"address,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	Email   string `json:"email,omitempty"`
+	NPWP    string `json:"npwp,omitempty"`
+}
+
+// InvoiceItemResponse represents an invoice line item
+type InvoiceItemResponse struct {
+	Description string  `json:"description"`
+	Quantity    int     `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
+
+// InvoiceResponse represents an invoice in response
+type InvoiceResponse struct {
+	InvoiceNumber  string                `json:"invoice_number"`
+	BookingID      uint                  `json:"booking_id"`
+	IssuedAt       time.Time             `json:"issued_at"`
+	Company        InvoicePartyResponse  `json:"company"`
+	BillTo         InvoicePartyResponse  `json:"bill_to"`
+	Items          []InvoiceItemResponse `json:"items"`
+	Subtotal       float64               `json:"subtotal"`
+	DiscountAmount float64               `json:"discount_amount"`
+	DiscountNote   string                `json:"discount_note,omitempty"`
+	TaxableAmount  float64               `json:"taxable_amount"`
+	TaxRate        float64               `json:"tax_rate"`
+	TaxAmount      float64               `json:"tax_amount"`
+	TotalAmount    float64               `json:"total_amount"`
+}
+
+// NewInvoiceResponseFromEntity creates InvoiceResponse from Invoice entity
+func NewInvoiceResponseFromEntity(invoice *entities.Invoice) *InvoiceResponse {
+	response := &InvoiceResponse{
+		InvoiceNumber: invoice.InvoiceNumber,
+		BookingID:     invoice.BookingID,
+		IssuedAt:      invoice.IssuedAt,
+		Company: InvoicePartyResponse{
+			Name:    invoice.CompanyName,
+			Address: invoice.CompanyAddress,
+			Phone:   invoice.CompanyPhone,
+			Email:   invoice.CompanyEmail,
+			NPWP:    invoice.CompanyNPWP,
+		},
+		BillTo: InvoicePartyResponse{
+			Name:    invoice.BillingName,
+			Company: invoice.BillingCompany,
+			Address: invoice.BillingAddress,
+			Phone:   invoice.BillingPhone,
+			Email:   invoice.BillingEmail,
+			NPWP:    invoice.BillingNPWP,
+		},
+		Items:          make([]InvoiceItemResponse, len(invoice.Items)),
+		Subtotal:       invoice.Subtotal,
+		DiscountAmount: invoice.DiscountAmount,
+		DiscountNote:   invoice.DiscountNote,
+		TaxableAmount:  invoice.TaxableAmount,
+		TaxRate:        invoice.TaxRate,
+		TaxAmount:      invoice.TaxAmount,
+		TotalAmount:    invoice.TotalAmount,
+	}
+
+	for i, item := range invoice.Items {
+		response.Items[i] = InvoiceItemResponse{
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+		}
+	}
+	return response
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+// LoginAuditLogResponse represents a failed login attempt
+type LoginAuditLogResponse struct {
+	ID        uint      `json:"id"`
+	Email     string    `json:"email"`
+	UserID    *uint     `json:"user_id"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UnlockLoginResponse represents the result of unlocking a user's login
+type UnlockLoginResponse struct {
+	UserID uint   `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// NewLoginAuditLogResponseFromEntity creates LoginAuditLogResponse from LoginAuditLog entity
+func NewLoginAuditLogResponseFromEntity(log *entities.LoginAuditLog) LoginAuditLogResponse {
+	return LoginAuditLogResponse{
+		ID:        log.ID,
+		Email:     log.Email,
+		UserID:    log.UserID,
+		IPAddress: log.IPAddress,
+		UserAgent: log.UserAgent,
+		Reason:    log.Reason,
+		CreatedAt: log.CreatedAt,
+	}
+}
@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+// ManifestResponse lists who sits where on a schedule. Only passengers of paid bookings are listed.
+type ManifestResponse struct {
+	ScheduleID    uint                        `json:"schedule_id"`
+	Origin        string                      `json:"origin"`
+	Destination   string                      `json:"destination"`
+	DepartureTime time.Time                   `json:"departure_time"`
+	ArrivalTime   time.Time                   `json:"arrival_time"`
+	PickupPoint   string                      `json:"pickup_point"`
+	Cancelled     bool                        `json:"cancelled"`
+	Passengers    []ManifestPassengerResponse `json:"passengers"`
+	Summary       ManifestSummaryResponse     `json:"summary"`
+	GeneratedAt   time.Time                   `json:"generated_at"`
+}
+
+// ManifestPassengerResponse is one occupied seat of the manifest
+type ManifestPassengerResponse struct {
+	SeatNumber     string                  `json:"seat_number"`
+	PassengerID    uint                    `json:"passenger_id"`
+	PassengerName  string                  `json:"passenger_name"`
+	BookingID      uint                    `json:"booking_id"`
+	PaymentStatus  string                  `json:"payment_status"`
+	PaymentMethod  string                  `json:"payment_method,omitempty"`
+	PickupPoint    string                  `json:"pickup_point"`
+	BoardingStatus entities.BoardingStatus `json:"boarding_status"`
+	CheckedInAt    *time.Time              `json:"checked_in_at,omitempty"`
+	BoardedAt      *time.Time              `json:"boarded_at,omitempty"`
+}
+
+// ManifestSummaryResponse counts the seats of the schedule
+type ManifestSummaryResponse struct {
+	TotalSeats          int      `json:"total_seats"`
+	OccupiedSeats       int      `json:"occupied_seats"`
+	CheckedInPassengers int      `json:"checked_in_passengers"`
+	BoardedPassengers   int      `json:"boarded_passengers"`
+	NoShowPassengers    int      `json:"no_show_passengers"`
+	EmptySeats          int      `json:"empty_seats"`
+	EmptySeatNumbers    []string `json:"empty_seat_numbers"`
+}
+
+// NewManifestResponse builds the manifest of a schedule from its seats and the passengers
+// of its paid bookings. Passengers need their booking, payment and seat loaded.
+func NewManifestResponse(schedule *entities.Schedule, seats []entities.Seat, passengers []entities.BookingDetail, generatedAt time.Time) *ManifestResponse {
+	// Passengers board in the origin city when the schedule has no specific pickup point
+	pickupPoint := schedule.PickupPoint
+	if pickupPoint == "" {
+		pickupPoint = schedule.Route.OriginCity
+	}
+
+	manifest := &ManifestResponse{
+		ScheduleID:    schedule.ID,
+		Origin:        schedule.Route.OriginCity,
+		Destination:   schedule.Route.DestinationCity,
+		DepartureTime: schedule.DepartureTime,
+		ArrivalTime:   schedule.ArrivalTime,
+		PickupPoint:   pickupPoint,
+		Cancelled:     schedule.CancelledAt != nil,
+		Passengers:    make([]ManifestPassengerResponse, 0, len(passengers)),
+		GeneratedAt:   generatedAt,
+	}
+
+	occupied := make(map[uint]bool, len(passengers))
+	for _, detail := range passengers {
+		occupied[detail.SeatID] = true
+
+		entry := ManifestPassengerResponse{
+			SeatNumber:     detail.Seat.SeatNumber,
+			PassengerID:    detail.ID,
+			PassengerName:  detail.PassengerName,
+			BookingID:      detail.BookingID,
+			PaymentStatus:  string(entities.PaymentStatusSuccess),
+			PickupPoint:    pickupPoint,
+			BoardingStatus: detail.BoardingStatus(),
+			CheckedInAt:    detail.CheckedInAt,
+			BoardedAt:      detail.BoardedAt,
+		}
+		if payment := detail.Booking.Payment; payment != nil {
+			entry.PaymentStatus = string(payment.PaymentStatus)
+			entry.PaymentMethod = payment.PaymentMethod
+		}
+		switch entry.BoardingStatus {
+		case entities.BoardingStatusCheckedIn:
+			manifest.Summary.CheckedInPassengers++
+		case entities.BoardingStatusBoarded:
+			manifest.Summary.BoardedPassengers++
+		case entities.BoardingStatusNoShow:
+			manifest.Summary.NoShowPassengers++
+		}
+		manifest.Passengers = append(manifest.Passengers, entry)
+	}
+
+	manifest.Summary.TotalSeats = len(seats)
+	manifest.Summary.EmptySeatNumbers = []string{}
+	for _, seat := range seats {
+		if !occupied[seat.ID] {
+			manifest.Summary.EmptySeatNumbers = append(manifest.Summary.EmptySeatNumbers, seat.SeatNumber)
+		}
+	}
+	manifest.Summary.EmptySeats = len(manifest.Summary.EmptySeatNumbers)
+	manifest.Summary.OccupiedSeats = manifest.Summary.TotalSeats - manifest.Summary.EmptySeats
+
+	return manifest
+}
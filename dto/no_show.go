@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// NoShowRestriction is how the no-show policy limits the bookings of a customer
+type NoShowRestriction string
+
+const (
+	NoShowRestrictionNone    NoShowRestriction = "none"
+	NoShowRestrictionLimited NoShowRestriction = "limited"
+	NoShowRestrictionBlocked NoShowRestriction = "blocked"
+)
+
+// NoShowStatsResponse counts the passengers of a customer's paid bookings by boarding state
+// and shows whether the no-show policy currently restricts the customer
+type NoShowStatsResponse struct {
+	UserID     uint  `json:"user_id"`
+	Passengers int64 `json:"passengers"`
+	CheckedIn  int64 `json:"checked_in"`
+	Boarded    int64 `json:"boarded"`
+	NoShows    int64 `json:"no_shows"`
+	// RecentMissedTrips counts the bookings with a no-show inside the policy window
+	RecentMissedTrips int               `json:"recent_missed_trips"`
+	LastNoShowAt      *time.Time        `json:"last_no_show_at,omitempty"`
+	Restriction       NoShowRestriction `json:"restriction"`
+	MaxPassengers     *int              `json:"max_passengers,omitempty"`
+	RestrictedUntil   *time.Time        `json:"restricted_until,omitempty"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+type (
+	NotificationResponse struct {
+		ID        uint       `json:"id"`
+		Event     string     `json:"event"`
+		Title     string     `json:"title"`
+		Body      string     `json:"body"`
+		BookingID *uint      `json:"booking_id,omitempty"`
+		IsRead    bool       `json:"is_read"`
+		ReadAt    *time.Time `json:"read_at"`
+		CreatedAt time.Time  `json:"created_at"`
+	}
+
+	UnreadNotificationCountResponse struct {
+		UnreadCount int64 `json:"unread_count"`
+	}
+
+	MarkAllNotificationsReadResponse struct {
+		Updated int64 `json:"updated"`
+	}
+)
+
+func NewNotificationResponseFromEntity(notification *entities.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        notification.ID,
+		Event:     string(notification.Event),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		BookingID: notification.BookingID,
+		IsRead:    notification.ReadAt != nil,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
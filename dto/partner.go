@@ -0,0 +1,135 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+type (
+	CreatePartnerRequest struct {
+		Name           string  `json:"name" binding:"required,min=2,max=100"`
+		ContactEmail   string  `json:"contact_email" binding:"omitempty,email,max=100"`
+		CommissionRate float64 `json:"commission_rate" binding:"gte=0,lte=100"`
+	}
+
+	UpdatePartnerRequest struct {
+		Name           string   `json:"name" binding:"omitempty,min=2,max=100"`
+		ContactEmail   string   `json:"contact_email" binding:"omitempty,email,max=100"`
+		CommissionRate *float64 `json:"commission_rate" binding:"omitempty,gte=0,lte=100"`
+		IsActive       *bool    `json:"is_active"`
+	}
+
+	CreateAPIKeyRequest struct {
+		Name               string     `json:"name" binding:"required,max=100"`
+		Scopes             []string   `json:"scopes" binding:"required,min=1"`
+		RateLimitPerMinute int        `json:"rate_limit_per_minute" binding:"omitempty,gte=1,lte=6000"`
+		AllowedIPs         []string   `json:"allowed_ips"`
+		ExpiresAt          *time.Time `json:"expires_at"`
+	}
+
+	// PartnerReportQuery represents the period of a partner report.
+	// Format: "YYYY-MM-DD" (WIB), defaults to the last 30 days.
+	PartnerReportQuery struct {
+		From string `form:"from"`
+		To   string `form:"to"`
+	}
+
+	PartnerResponse struct {
+		ID             uint      `json:"id"`
+		Name           string    `json:"name"`
+		ContactEmail   string    `json:"contact_email"`
+		CommissionRate float64   `json:"commission_rate"`
+		IsActive       bool      `json:"is_active"`
+		UserID         uint      `json:"user_id"`
+		CreatedAt      time.Time `json:"created_at"`
+		UpdatedAt      time.Time `json:"updated_at"`
+	}
+
+	APIKeyResponse struct {
+		ID                 uint       `json:"id"`
+		PartnerID          uint       `json:"partner_id"`
+		Name               string     `json:"name"`
+		Prefix             string     `json:"prefix"`
+		Scopes             []string   `json:"scopes"`
+		RateLimitPerMinute int        `json:"rate_limit_per_minute"`
+		AllowedIPs         []string   `json:"allowed_ips"`
+		ExpiresAt          *time.Time `json:"expires_at"`
+		RevokedAt          *time.Time `json:"revoked_at"`
+		RotatedToID        *uint      `json:"rotated_to_id,omitempty"`
+		LastUsedAt         *time.Time `json:"last_used_at"`
+		LastUsedIP         string     `json:"last_used_ip,omitempty"`
+		CreatedAt          time.Time  `json:"created_at"`
+	}
+
+	// CreatedAPIKeyResponse contains the plain key, it is only shown once
+	CreatedAPIKeyResponse struct {
+		APIKeyResponse
+		Key string `json:"key"`
+	}
+
+	APIKeyUsageResponse struct {
+		APIKeyID     uint   `json:"api_key_id"`
+		Date         string `json:"date"`
+		RequestCount int64  `json:"request_count"`
+		ErrorCount   int64  `json:"error_count"`
+	}
+
+	PartnerUsageResponse struct {
+		PartnerID     uint                  `json:"partner_id"`
+		From          string                `json:"from"`
+		To            string                `json:"to"`
+		TotalRequests int64                 `json:"total_requests"`
+		TotalErrors   int64                 `json:"total_errors"`
+		Daily         []APIKeyUsageResponse `json:"daily"`
+	}
+
+	PartnerCommissionResponse struct {
+		PartnerID      uint    `json:"partner_id"`
+		PartnerName    string  `json:"partner_name"`
+		From           string  `json:"from"`
+		To             string  `json:"to"`
+		BookingCount   int64   `json:"booking_count"`
+		TicketAmount   float64 `json:"ticket_amount"`
+		Commission     float64 `json:"commission"`
+		CommissionRate float64 `json:"current_commission_rate"`
+	}
+
+	// BookingAttribution links a booking made with a partner API key to the partner
+	BookingAttribution struct {
+		PartnerID      uint
+		CommissionRate float64
+	}
+)
+
+// NewPartnerResponseFromEntity creates PartnerResponse from Partner entity
+func NewPartnerResponseFromEntity(partner *entities.Partner) PartnerResponse {
+	return PartnerResponse{
+		ID:             partner.ID,
+		Name:           partner.Name,
+		ContactEmail:   partner.ContactEmail,
+		CommissionRate: partner.CommissionRate,
+		IsActive:       partner.IsActive,
+		UserID:         partner.UserID,
+		CreatedAt:      partner.CreatedAt,
+		UpdatedAt:      partner.UpdatedAt,
+	}
+}
+
+// NewAPIKeyResponseFromEntity creates APIKeyResponse from APIKey entity
+func NewAPIKeyResponseFromEntity(key *entities.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:                 key.ID,
+		PartnerID:          key.PartnerID,
+		Name:               key.Name,
+		Prefix:             key.Prefix,
+		Scopes:             key.ScopeList(),
+		RateLimitPerMinute: key.RateLimitPerMinute,
+		AllowedIPs:         key.AllowedIPList(),
+		ExpiresAt:          key.ExpiresAt,
+		RevokedAt:          key.RevokedAt,
+		RotatedToID:        key.RotatedToID,
+		LastUsedAt:         key.LastUsedAt,
+		LastUsedIP:         key.LastUsedIP,
+		CreatedAt:          key.CreatedAt,
+	}
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// PassengerIdentityRequest holds the optional identity details of a passenger
+	PassengerIdentityRequest struct {
+		IDType   string `json:"id_type" binding:"omitempty,oneof=nik passport" validate:"omitempty,oneof=nik passport"`
+		IDNumber string `json:"id_number" binding:"max=30" validate:"max=30"`
+		Phone    string `json:"phone" binding:"max=20" validate:"max=20"`
+		Gender   string `json:"gender" binding:"omitempty,oneof=male female" validate:"omitempty,oneof=male female"`
+	}
+
+	PassengerIdentityResponse struct {
+		IDType   string `json:"id_type,omitempty"`
+		IDNumber string `json:"id_number,omitempty"`
+		Phone    string `json:"phone,omitempty"`
+		Gender   string `json:"gender,omitempty"`
+	}
+)
+
+// IsEmpty reports whether no identity field was sent
+func (r PassengerIdentityRequest) IsEmpty() bool {
+	return r.IDType == "" && r.IDNumber == "" && r.Phone == "" && r.Gender == ""
+}
+
+// String masks the ID number and phone, so the request can be logged safely
+func (r PassengerIdentityRequest) String() string {
+	return fmt.Sprintf("{%s %s %s %s}", r.IDType, MaskPII(r.IDNumber), MaskPII(r.Phone), r.Gender)
+}
+
+// Masked hides all but the last digits of the ID number and phone, used in list responses
+func (r PassengerIdentityResponse) Masked() PassengerIdentityResponse {
+	r.IDNumber = MaskPII(r.IDNumber)
+	r.Phone = MaskPII(r.Phone)
+	return r
+}
+
+// String masks the ID number and phone, so the identity can be logged safely
+func (r PassengerIdentityResponse) String() string {
+	masked := r.Masked()
+	return fmt.Sprintf("{%s %s %s %s}", masked.IDType, masked.IDNumber, masked.Phone, masked.Gender)
+}
+
+// MaskPII replaces all but the last 4 characters with asterisks
+func MaskPII(value string) string {
+	const visible = 4
+	if value == "" {
+		return ""
+	}
+	runes := []rune(value)
+	if len(runes) <= visible {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-visible) + string(runes[len(runes)-visible:])
+}
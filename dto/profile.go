@@ -0,0 +1,98 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+type (
+	// UpdateProfileRequest only changes the fields that are sent
+	UpdateProfileRequest struct {
+		FirstName   *string `json:"first_name" binding:"omitempty,min=1,max=50"`
+		LastName    *string `json:"last_name" binding:"omitempty,min=1,max=50"`
+		PhoneNumber *string `json:"phone_number" binding:"omitempty,min=8,max=20"`
+		// Locale is the language of notifications, receipts and API messages
+		Locale *string `json:"locale" binding:"omitempty,oneof=id en"`
+	}
+
+	ChangePasswordRequest struct {
+		CurrentPassword string `json:"current_password" binding:"required"`
+		NewPassword     string `json:"new_password" binding:"required,min=8"`
+	}
+
+	ChangeEmailRequest struct {
+		NewEmail string `json:"new_email" binding:"required,email,max=100"`
+		Password string `json:"password" binding:"required"`
+	}
+
+	SavedPassengerRequest struct {
+		Label string `json:"label" binding:"max=50"`
+		Name  string `json:"name" binding:"required,min=2,max=100"`
+		PassengerIdentityRequest
+	}
+
+	ProfileResponse struct {
+		ID               uint      `json:"id"`
+		Email            string    `json:"email"`
+		EmailVerified    bool      `json:"email_verified"`
+		PendingEmail     string    `json:"pending_email,omitempty"`
+		Role             string    `json:"role"`
+		FirstName        string    `json:"first_name"`
+		LastName         string    `json:"last_name"`
+		PhoneNumber      string    `json:"phone_number"`
+		PhoneVerified    bool      `json:"phone_verified"`
+		Locale           string    `json:"locale,omitempty"`
+		TwoFactorEnabled bool      `json:"two_factor_enabled"`
+		CreatedAt        time.Time `json:"created_at"`
+		UpdatedAt        time.Time `json:"updated_at"`
+	}
+
+	SavedPassengerResponse struct {
+		ID        uint                       `json:"id"`
+		Label     string                     `json:"label"`
+		Name      string                     `json:"name"`
+		Identity  *PassengerIdentityResponse `json:"identity,omitempty"`
+		CreatedAt time.Time                  `json:"created_at"`
+		UpdatedAt time.Time                  `json:"updated_at"`
+	}
+)
+
+// NewProfileResponseFromEntity creates ProfileResponse from User entity
+func NewProfileResponseFromEntity(user *entities.User) *ProfileResponse {
+	return &ProfileResponse{
+		ID:               user.ID,
+		Email:            user.Email,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		PendingEmail:     user.PendingEmail,
+		Role:             user.Role,
+		FirstName:        user.FirstName,
+		LastName:         user.LastName,
+		PhoneNumber:      user.PhoneNumber,
+		PhoneVerified:    user.PhoneVerifiedAt != nil,
+		Locale:           user.Locale,
+		TwoFactorEnabled: user.IsTwoFactorEnabled(),
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+}
+
+// NewSavedPassengerResponseFromEntity creates SavedPassengerResponse from SavedPassenger entity
+func NewSavedPassengerResponseFromEntity(passenger *entities.SavedPassenger) SavedPassengerResponse {
+	return SavedPassengerResponse{
+		ID:        passenger.ID,
+		Label:     passenger.Label,
+		Name:      passenger.Name,
+		CreatedAt: passenger.CreatedAt,
+		UpdatedAt: passenger.UpdatedAt,
+	}
+}
+
+// ToEntity converts SavedPassengerRequest to SavedPassenger entity. The identity is set by the
+// service because it has to be validated and encrypted first.
+func (r *SavedPassengerRequest) ToEntity(userID uint) *entities.SavedPassenger {
+	return &entities.SavedPassenger{
+		UserID: userID,
+		Label:  r.Label,
+		Name:   r.Name,
+	}
+}
@@ -0,0 +1,148 @@
+package dto
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"malakashuttle/config"
+	"malakashuttle/entities"
+)
+
+// ImportBankStatementRequest represents the form fields for importing a bank statement CSV.
+// Optional fields override the default column mapping of the selected bank.
+type ImportBankStatementRequest struct {
+	Bank              string  `form:"bank" binding:"required,oneof=bca mandiri"`
+	Delimiter         *string `form:"delimiter" binding:"omitempty,len=1"`
+	SkipRows          *int    `form:"skip_rows" binding:"omitempty,min=0"`
+	DateColumn        *int    `form:"date_column" binding:"omitempty,min=0"`
+	DateFormat        *string `form:"date_format"`
+	DescriptionColumn *int    `form:"description_column" binding:"omitempty,min=0"`
+	AmountColumn      *int    `form:"amount_column" binding:"omitempty,min=0"`
+	TypeColumn        *int    `form:"type_column" binding:"omitempty,min=-1"`
+	CreditMarker      *string `form:"credit_marker"`
+	ThousandSeparator *string `form:"thousand_separator"`
+	DecimalSeparator  *string `form:"decimal_separator"`
+}
+
+// ResolveBankStatementEntryRequest represents the request for matching a reviewed entry to a booking
+type ResolveBankStatementEntryRequest struct {
+	BookingID uint   `json:"booking_id" binding:"required,min=1"`
+	Notes     string `json:"notes" binding:"max=500"`
+}
+
+// DismissBankStatementEntryRequest represents the request for dismissing a reviewed entry
+type DismissBankStatementEntryRequest struct {
+	Notes string `json:"notes" binding:"max=500"`
+}
+
+// BankStatementImportResponse represents a bank statement import in response
+type BankStatementImportResponse struct {
+	ID            uint                         `json:"id"`
+	Bank          string                       `json:"bank"`
+	FileName      string                       `json:"file_name"`
+	ImportedBy    string                       `json:"imported_by,omitempty"`
+	Status        string                       `json:"status"`
+	Error         string                       `json:"error,omitempty"`
+	TotalRows     int                          `json:"total_rows"`
+	MatchedRows   int                          `json:"matched_rows"`
+	ReviewRows    int                          `json:"review_rows"`
+	UnmatchedRows int                          `json:"unmatched_rows"`
+	Entries       []BankStatementEntryResponse `json:"entries,omitempty"`
+	CreatedAt     time.Time                    `json:"created_at"`
+}
+
+// BankStatementEntryResponse represents a reconciled bank statement row in response
+type BankStatementEntryResponse struct {
+	ID                  uint                              `json:"id"`
+	ImportID            uint                              `json:"import_id"`
+	LineNumber          int                               `json:"line_number"`
+	TransactionDate     string                            `json:"transaction_date"`
+	Description         string                            `json:"description"`
+	Amount              float64                           `json:"amount"`
+	Status              entities.BankStatementEntryStatus `json:"status"`
+	MatchedBookingID    *uint                             `json:"matched_booking_id,omitempty"`
+	CandidateBookingIDs []uint                            `json:"candidate_booking_ids,omitempty"`
+	Notes               string                            `json:"notes,omitempty"`
+	ResolvedAt          *time.Time                        `json:"resolved_at,omitempty"`
+}
+
+// ApplyToMapping overrides the default bank column mapping with the provided fields
+func (r *ImportBankStatementRequest) ApplyToMapping(mapping *config.BankStatementMapping) {
+	if r.Delimiter != nil {
+		mapping.Delimiter = rune((*r.Delimiter)[0])
+	}
+	if r.SkipRows != nil {
+		mapping.SkipRows = *r.SkipRows
+	}
+	if r.DateColumn != nil {
+		mapping.DateColumn = *r.DateColumn
+	}
+	if r.DateFormat != nil && *r.DateFormat != "" {
+		mapping.DateLayout = *r.DateFormat
+	}
+	if r.DescriptionColumn != nil {
+		mapping.DescriptionColumn = *r.DescriptionColumn
+	}
+	if r.AmountColumn != nil {
+		mapping.AmountColumn = *r.AmountColumn
+	}
+	if r.TypeColumn != nil {
+		mapping.TypeColumn = *r.TypeColumn
+	}
+	if r.CreditMarker != nil {
+		mapping.CreditMarker = *r.CreditMarker
+	}
+	if r.ThousandSeparator != nil {
+		mapping.ThousandSeparator = *r.ThousandSeparator
+	}
+	if r.DecimalSeparator != nil {
+		mapping.DecimalSeparator = *r.DecimalSeparator
+	}
+}
+
+// NewBankStatementImportResponseFromEntity creates BankStatementImportResponse from entity
+func NewBankStatementImportResponseFromEntity(statementImport *entities.BankStatementImport) *BankStatementImportResponse {
+	response := &BankStatementImportResponse{
+		ID:            statementImport.ID,
+		Bank:          statementImport.Bank,
+		FileName:      statementImport.FileName,
+		ImportedBy:    statementImport.ImportedBy.Email,
+		Status:        string(statementImport.Status),
+		Error:         statementImport.Error,
+		TotalRows:     statementImport.TotalRows,
+		MatchedRows:   statementImport.MatchedRows,
+		ReviewRows:    statementImport.ReviewRows,
+		UnmatchedRows: statementImport.UnmatchedRows,
+		CreatedAt:     statementImport.CreatedAt,
+	}
+
+	for i := range statementImport.Entries {
+		response.Entries = append(response.Entries, *NewBankStatementEntryResponseFromEntity(&statementImport.Entries[i]))
+	}
+	return response
+}
+
+// NewBankStatementEntryResponseFromEntity creates BankStatementEntryResponse from entity
+func NewBankStatementEntryResponseFromEntity(entry *entities.BankStatementEntry) *BankStatementEntryResponse {
+	response := &BankStatementEntryResponse{
+		ID:               entry.ID,
+		ImportID:         entry.ImportID,
+		LineNumber:       entry.LineNumber,
+		TransactionDate:  entry.TransactionDate.Format("2006-01-02"),
+		Description:      entry.Description,
+		Amount:           entry.Amount,
+		Status:           entry.Status,
+		MatchedBookingID: entry.MatchedBookingID,
+		Notes:            entry.Notes,
+		ResolvedAt:       entry.ResolvedAt,
+	}
+
+	// Candidate IDs are stored comma separated
+	for _, id := range strings.Split(entry.CandidateBookingIDs, ",") {
+		if parsed, err := strconv.ParseUint(strings.TrimSpace(id), 10, 32); err == nil {
+			response.CandidateBookingIDs = append(response.CandidateBookingIDs, uint(parsed))
+		}
+	}
+	return response
+}
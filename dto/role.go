@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+type (
+	CreateRoleRequest struct {
+		Name        string   `json:"name" binding:"required,min=2,max=30,lowercase,alphanum"`
+		Description string   `json:"description" binding:"max=255"`
+		Permissions []string `json:"permissions" binding:"required"`
+	}
+
+	// UpdateRoleRequest replaces the permissions of a role
+	UpdateRoleRequest struct {
+		Description *string  `json:"description" binding:"omitempty,max=255"`
+		Permissions []string `json:"permissions" binding:"required"`
+	}
+
+	RoleResponse struct {
+		ID          uint      `json:"id"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		IsSystem    bool      `json:"is_system"`
+		Permissions []string  `json:"permissions"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedAt   time.Time `json:"updated_at"`
+	}
+
+	PermissionResponse struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+)
+
+// NewRoleResponseFromEntity creates RoleResponse from Role entity
+func NewRoleResponseFromEntity(role *entities.Role) RoleResponse {
+	return RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: role.PermissionNames(),
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
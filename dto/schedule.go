@@ -13,6 +13,7 @@ type CreateScheduleRequest struct {
 	ArrivalTime   string  `json:"arrival_time" validate:"required" binding:"required"`   // Format: "YYYY-MM-DD HH:mm"
 	Price         float64 `json:"price" validate:"required,gt=0" binding:"required"`
 	TotalSeats    int     `json:"total_seats" validate:"required,gt=0" binding:"required"`
+	PickupPoint   string  `json:"pickup_point" binding:"omitempty,max=255"`
 }
 
 // UpdateScheduleRequest - DTO untuk request update schedule (Admin)
@@ -22,6 +23,12 @@ type UpdateScheduleRequest struct {
 	DepartureTime *string  `json:"departure_time,omitempty"` // Format: "YYYY-MM-DD HH:mm"
 	ArrivalTime   *string  `json:"arrival_time,omitempty"`   // Format: "YYYY-MM-DD HH:mm"
 	Price         *float64 `json:"price,omitempty"`
+	PickupPoint   *string  `json:"pickup_point,omitempty" binding:"omitempty,max=255"`
+}
+
+// CancelScheduleRequest - DTO untuk request cancel schedule (Admin)
+type CancelScheduleRequest struct {
+	Reason string `json:"reason" binding:"required,min=3,max=255"`
 }
 
 // ScheduleSearchRequest - DTO untuk pencarian schedule (User)
@@ -35,17 +42,33 @@ type ScheduleSearchRequest struct {
 
 // ScheduleResponse - DTO untuk response schedule (unified untuk admin dan user)
 type ScheduleResponse struct {
-	ID             uint       `json:"id"`
-	Origin         string     `json:"origin"`
-	Destination    string     `json:"destination"`
-	DepartureTime  string     `json:"departure_time"` // Format: "YYYY-MM-DD HH:mm"
-	ArrivalTime    string     `json:"arrival_time"`   // Format: "YYYY-MM-DD HH:mm"
-	Price          float64    `json:"price"`
-	TotalSeats     int        `json:"total_seats,omitempty"` // Bisa null untuk user
-	AvailableSeats int        `json:"available_seats"`
-	Duration       string     `json:"duration"`
-	CreatedAt      *time.Time `json:"created_at,omitempty"` // Bisa null untuk user
-	UpdatedAt      *time.Time `json:"updated_at,omitempty"` // Bisa null untuk user
+	ID                 uint                    `json:"id"`
+	Origin             string                  `json:"origin"`
+	Destination        string                  `json:"destination"`
+	DepartureTime      string                  `json:"departure_time"` // Format: "YYYY-MM-DD HH:mm"
+	ArrivalTime        string                  `json:"arrival_time"`   // Format: "YYYY-MM-DD HH:mm"
+	Price              float64                 `json:"price"`
+	TotalSeats         int                     `json:"total_seats,omitempty"` // Bisa null untuk user
+	AvailableSeats     int                     `json:"available_seats"`
+	Duration           string                  `json:"duration"`
+	PickupPoint        string                  `json:"pickup_point,omitempty"`
+	Status             entities.ScheduleStatus `json:"status,omitempty"`
+	CancelledAt        *time.Time              `json:"cancelled_at,omitempty"`
+	CancellationReason string                  `json:"cancellation_reason,omitempty"`
+	DriverID           *uint                   `json:"driver_id,omitempty"`  // Hanya untuk admin
+	CreatedAt          *time.Time              `json:"created_at,omitempty"` // Bisa null untuk user
+	UpdatedAt          *time.Time              `json:"updated_at,omitempty"` // Bisa null untuk user
+}
+
+// AssignDriverRequest assigns a driver to a schedule, a null driver_id removes the assignment
+type AssignDriverRequest struct {
+	DriverID *uint `json:"driver_id" binding:"omitempty,min=1"`
+}
+
+// CancelScheduleResponse - DTO untuk response cancel schedule
+type CancelScheduleResponse struct {
+	Schedule            ScheduleResponse `json:"schedule"`
+	CancelledBookingIDs []uint           `json:"cancelled_booking_ids"`
 }
 
 // ScheduleWithSeatsResponse - DTO untuk response schedule dengan detail kursi
@@ -57,6 +80,7 @@ type ScheduleWithSeatsResponse struct {
 	ArrivalTime    string         `json:"arrival_time"`   // Format: "YYYY-MM-DD HH:mm"
 	TotalSeats     int            `json:"total_seats"`
 	AvailableSeats int            `json:"available_seats"`
+	PickupPoint    string         `json:"pickup_point,omitempty"`
 	Seats          []SeatResponse `json:"seats"`
 }
 
@@ -80,19 +104,24 @@ func ToScheduleResponse(schedule entities.Schedule, includeAdminFields bool) Sch
 	durationStr := fmt.Sprintf("%dh %dm", int(duration.Hours()), int(duration.Minutes())%60)
 
 	response := ScheduleResponse{
-		ID:             schedule.ID,
-		Origin:         schedule.Route.OriginCity,
-		Destination:    schedule.Route.DestinationCity,
-		DepartureTime:  departureTimeStr,
-		ArrivalTime:    arrivalTimeStr,
-		Price:          schedule.Price,
-		AvailableSeats: schedule.AvailableSeats,
-		Duration:       durationStr,
+		ID:                 schedule.ID,
+		Origin:             schedule.Route.OriginCity,
+		Destination:        schedule.Route.DestinationCity,
+		DepartureTime:      departureTimeStr,
+		ArrivalTime:        arrivalTimeStr,
+		Price:              schedule.Price,
+		AvailableSeats:     schedule.AvailableSeats,
+		Duration:           durationStr,
+		PickupPoint:        schedule.PickupPoint,
+		Status:             schedule.Status,
+		CancelledAt:        schedule.CancelledAt,
+		CancellationReason: schedule.CancellationReason,
 	}
 
 	// Include admin-only fields if requested
 	if includeAdminFields {
 		response.TotalSeats = schedule.TotalSeats
+		response.DriverID = schedule.DriverID
 		response.CreatedAt = &schedule.CreatedAt
 		response.UpdatedAt = &schedule.UpdatedAt
 	}
@@ -138,6 +167,7 @@ func ToScheduleWithSeatsResponse(schedule entities.Schedule, seats []entities.Se
 		ArrivalTime:    arrivalTimeStr,
 		TotalSeats:     len(seats),
 		AvailableSeats: availableCount,
+		PickupPoint:    schedule.PickupPoint,
 		Seats:          seatResponses,
 	}
 }
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+// SeatChangeReason tells stream clients why the seat state of a schedule changed
+type SeatChangeReason string
+
+const (
+	SeatChangeSnapshot          SeatChangeReason = "snapshot" // first event of every stream
+	SeatChangeBookingCreated    SeatChangeReason = "booking.created"
+	SeatChangeBookingExpired    SeatChangeReason = "booking.expired"
+	SeatChangeBookingRejected   SeatChangeReason = "booking.rejected"
+	SeatChangeScheduleCancelled SeatChangeReason = "schedule.cancelled"
+)
+
+// SeatAvailabilityEvent is the full seat state of a schedule pushed over the seat stream.
+// Every event carries all seats so clients can simply replace their state.
+type SeatAvailabilityEvent struct {
+	ScheduleID     uint             `json:"schedule_id"`
+	Reason         SeatChangeReason `json:"reason"`
+	TotalSeats     int              `json:"total_seats"`
+	AvailableSeats int              `json:"available_seats"`
+	BookedSeats    int              `json:"booked_seats"`
+	Seats          []SeatResponse   `json:"seats"`
+	OccurredAt     time.Time        `json:"occurred_at"`
+}
+
+// NewSeatAvailabilityEvent builds a seat availability event from the current seats of a schedule
+func NewSeatAvailabilityEvent(scheduleID uint, reason SeatChangeReason, seats []entities.Seat, occurredAt time.Time) SeatAvailabilityEvent {
+	event := SeatAvailabilityEvent{
+		ScheduleID: scheduleID,
+		Reason:     reason,
+		TotalSeats: len(seats),
+		Seats:      make([]SeatResponse, len(seats)),
+		OccurredAt: occurredAt,
+	}
+	for i, seat := range seats {
+		event.Seats[i] = SeatResponse{
+			ID:         seat.ID,
+			SeatNumber: seat.SeatNumber,
+			IsBooked:   seat.IsBooked,
+		}
+		if seat.IsBooked {
+			event.BookedSeats++
+		} else {
+			event.AvailableSeats++
+		}
+	}
+	return event
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+// TripResponse is a schedule as seen by the driver assigned to it
+type TripResponse struct {
+	ScheduleID    uint                    `json:"schedule_id"`
+	Origin        string                  `json:"origin"`
+	Destination   string                  `json:"destination"`
+	DepartureTime time.Time               `json:"departure_time"`
+	ArrivalTime   time.Time               `json:"arrival_time"`
+	PickupPoint   string                  `json:"pickup_point"`
+	TotalSeats    int                     `json:"total_seats"`
+	Status        entities.ScheduleStatus `json:"status"`
+	DepartedAt    *time.Time              `json:"departed_at,omitempty"`
+	CompletedAt   *time.Time              `json:"completed_at,omitempty"`
+	CancelledAt   *time.Time              `json:"cancelled_at,omitempty"`
+}
+
+// NewTripResponse builds the trip of a schedule. The schedule needs its route loaded.
+func NewTripResponse(schedule *entities.Schedule) TripResponse {
+	pickupPoint := schedule.PickupPoint
+	if pickupPoint == "" {
+		pickupPoint = schedule.Route.OriginCity
+	}
+	return TripResponse{
+		ScheduleID:    schedule.ID,
+		Origin:        schedule.Route.OriginCity,
+		Destination:   schedule.Route.DestinationCity,
+		DepartureTime: schedule.DepartureTime,
+		ArrivalTime:   schedule.ArrivalTime,
+		PickupPoint:   pickupPoint,
+		TotalSeats:    schedule.TotalSeats,
+		Status:        schedule.Status,
+		DepartedAt:    schedule.DepartedAt,
+		CompletedAt:   schedule.CompletedAt,
+		CancelledAt:   schedule.CancelledAt,
+	}
+}
+
+// DriverScanRequest boards a passenger of the driver's trip by scanning their boarding pass
+type DriverScanRequest struct {
+	Code string `json:"code" binding:"required,max=255"`
+}
+
+// ReportIncidentRequest reports an incident on a trip. occurred_at defaults to now.
+type ReportIncidentRequest struct {
+	Category    entities.TripIncidentCategory `json:"category" binding:"required,oneof=breakdown accident delay passenger other"`
+	Description string                        `json:"description" binding:"required,min=3,max=2000"`
+	OccurredAt  *time.Time                    `json:"occurred_at"`
+}
+
+// TripIncidentResponse is an incident reported on a trip
+type TripIncidentResponse struct {
+	ID          uint                          `json:"id"`
+	ScheduleID  uint                          `json:"schedule_id"`
+	DriverID    uint                          `json:"driver_id"`
+	Category    entities.TripIncidentCategory `json:"category"`
+	Description string                        `json:"description"`
+	OccurredAt  time.Time                     `json:"occurred_at"`
+	CreatedAt   time.Time                     `json:"created_at"`
+}
+
+func NewTripIncidentResponse(incident *entities.TripIncident) TripIncidentResponse {
+	return TripIncidentResponse{
+		ID:          incident.ID,
+		ScheduleID:  incident.ScheduleID,
+		DriverID:    incident.DriverID,
+		Category:    incident.Category,
+		Description: incident.Description,
+		OccurredAt:  incident.OccurredAt,
+		CreatedAt:   incident.CreatedAt,
+	}
+}
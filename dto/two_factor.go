@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+type (
+	TwoFactorCodeRequest struct {
+		Code string `json:"code" binding:"required,len=6,numeric"`
+	}
+
+	DisableTwoFactorRequest struct {
+		Password string `json:"password" binding:"required"`
+		Code     string `json:"code" binding:"required,len=6,numeric"`
+	}
+
+	// VerifyTwoFactorLoginRequest completes a login with either a TOTP code or a recovery code
+	VerifyTwoFactorLoginRequest struct {
+		ChallengeToken string `json:"challenge_token" binding:"required"`
+		Code           string `json:"code" binding:"omitempty,len=6,numeric"`
+		RecoveryCode   string `json:"recovery_code"`
+	}
+
+	UpdateTwoFactorPolicyRequest struct {
+		Required *bool `json:"required" binding:"required"`
+	}
+
+	TwoFactorSetupResponse struct {
+		Secret          string `json:"secret"`
+		ProvisioningURI string `json:"provisioning_uri"`
+	}
+
+	TwoFactorRecoveryCodesResponse struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+
+	TwoFactorStatusResponse struct {
+		Enabled                bool       `json:"enabled"`
+		EnabledAt              *time.Time `json:"enabled_at"`
+		Required               bool       `json:"required"`
+		RemainingRecoveryCodes int64      `json:"remaining_recovery_codes"`
+	}
+
+	TwoFactorPolicyResponse struct {
+		Role      string    `json:"role"`
+		Required  bool      `json:"required"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+)
+
+// NewTwoFactorPolicyResponseFromEntity creates TwoFactorPolicyResponse from TwoFactorPolicy entity
+func NewTwoFactorPolicyResponseFromEntity(policy *entities.TwoFactorPolicy) TwoFactorPolicyResponse {
+	return TwoFactorPolicyResponse{
+		Role:      policy.Role,
+		Required:  policy.Required,
+		UpdatedAt: policy.UpdatedAt,
+	}
+}
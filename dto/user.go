@@ -9,7 +9,7 @@ import (
 type CreateUserRequest struct {
 	Email       string `json:"email" binding:"required,email"`
 	Password    string `json:"password" binding:"required,min=8"`
-	Role        string `json:"role" binding:"required,oneof=staff user"`
+	Role        string `json:"role" binding:"required,max=30"`
 	FirstName   string `json:"first_name" binding:"required"`
 	LastName    string `json:"last_name" binding:"required"`
 	PhoneNumber string `json:"phone_number"`
@@ -19,7 +19,7 @@ type CreateUserRequest struct {
 type UpdateUserRequest struct {
 	Email       string `json:"email" binding:"omitempty,email"`
 	Password    string `json:"password" binding:"omitempty,min=8"`
-	Role        string `json:"role" binding:"omitempty,oneof=staff user"`
+	Role        string `json:"role" binding:"omitempty,max=30"`
 	FirstName   string `json:"first_name"`
 	LastName    string `json:"last_name"`
 	PhoneNumber string `json:"phone_number"`
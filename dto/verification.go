@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"malakashuttle/entities"
+	"time"
+)
+
+type (
+	VerifyEmailRequest struct {
+		Token string `json:"token" binding:"required"`
+	}
+
+	VerifyPhoneRequest struct {
+		Code string `json:"code" binding:"required,len=6,numeric"`
+	}
+
+	ResendVerificationRequest struct {
+		Channel string `json:"channel" binding:"required,oneof=email phone"`
+	}
+
+	// VerificationStatusResponse shows which contact details of the user have been verified
+	VerificationStatusResponse struct {
+		Email           string     `json:"email"`
+		EmailVerified   bool       `json:"email_verified"`
+		EmailVerifiedAt *time.Time `json:"email_verified_at"`
+		PendingEmail    string     `json:"pending_email,omitempty"`
+		PhoneNumber     string     `json:"phone_number"`
+		PhoneVerified   bool       `json:"phone_verified"`
+		PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
+	}
+)
+
+// NewVerificationStatusResponseFromEntity creates VerificationStatusResponse from User entity
+func NewVerificationStatusResponseFromEntity(user *entities.User) *VerificationStatusResponse {
+	return &VerificationStatusResponse{
+		Email:           user.Email,
+		EmailVerified:   user.EmailVerifiedAt != nil,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		PendingEmail:    user.PendingEmail,
+		PhoneNumber:     user.PhoneNumber,
+		PhoneVerified:   user.PhoneVerifiedAt != nil,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+	}
+}
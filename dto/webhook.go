@@ -0,0 +1,164 @@
+package dto
+
+import (
+	"encoding/json"
+	"malakashuttle/entities"
+	"time"
+)
+
+type (
+	CreateWebhookEndpointRequest struct {
+		URL         string   `json:"url" binding:"required,url,max=500"`
+		Description string   `json:"description" binding:"max=255"`
+		Events      []string `json:"events" binding:"required,min=1"`
+		PartnerID   *uint    `json:"partner_id"`
+	}
+
+	UpdateWebhookEndpointRequest struct {
+		URL         *string  `json:"url" binding:"omitempty,url,max=500"`
+		Description *string  `json:"description" binding:"omitempty,max=255"`
+		Events      []string `json:"events" binding:"omitempty,min=1"`
+		IsActive    *bool    `json:"is_active"`
+	}
+
+	WebhookEndpointResponse struct {
+		ID          uint      `json:"id"`
+		URL         string    `json:"url"`
+		Description string    `json:"description"`
+		Events      []string  `json:"events"`
+		PartnerID   *uint     `json:"partner_id"`
+		IsActive    bool      `json:"is_active"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedAt   time.Time `json:"updated_at"`
+	}
+
+	// WebhookEndpointSecretResponse contains the signing secret, it is only shown when created or rotated
+	WebhookEndpointSecretResponse struct {
+		WebhookEndpointResponse
+		Secret string `json:"secret"`
+	}
+
+	WebhookDeliveryResponse struct {
+		ID                 uint       `json:"id"`
+		EndpointID         uint       `json:"endpoint_id"`
+		Event              string     `json:"event"`
+		EventID            string     `json:"event_id"`
+		Status             string     `json:"status"`
+		Attempts           int        `json:"attempts"`
+		NextAttemptAt      *time.Time `json:"next_attempt_at,omitempty"`
+		LastResponseStatus int        `json:"last_response_status,omitempty"`
+		LastError          string     `json:"last_error,omitempty"`
+		DeliveredAt        *time.Time `json:"delivered_at"`
+		CreatedAt          time.Time  `json:"created_at"`
+	}
+
+	WebhookDeliveryAttemptResponse struct {
+		ID             uint      `json:"id"`
+		ResponseStatus int       `json:"response_status,omitempty"`
+		ResponseBody   string    `json:"response_body,omitempty"`
+		Error          string    `json:"error,omitempty"`
+		DurationMs     int64     `json:"duration_ms"`
+		CreatedAt      time.Time `json:"created_at"`
+	}
+
+	WebhookDeliveryDetailResponse struct {
+		WebhookDeliveryResponse
+		Payload    json.RawMessage                  `json:"payload"`
+		AttemptLog []WebhookDeliveryAttemptResponse `json:"attempt_log"`
+	}
+
+	// WebhookPayload is the body posted to webhook endpoints
+	WebhookPayload struct {
+		ID        string      `json:"id"`
+		Event     string      `json:"event"`
+		CreatedAt time.Time   `json:"created_at"`
+		Data      interface{} `json:"data"`
+	}
+
+	// WebhookBookingData describes a booking in webhook payloads, it carries no passenger details
+	WebhookBookingData struct {
+		BookingID      uint      `json:"booking_id"`
+		UserID         uint      `json:"user_id"`
+		PartnerID      *uint     `json:"partner_id,omitempty"`
+		Status         string    `json:"status"`
+		ScheduleID     uint      `json:"schedule_id"`
+		Origin         string    `json:"origin"`
+		Destination    string    `json:"destination"`
+		DepartureTime  time.Time `json:"departure_time"`
+		Seats          []string  `json:"seats"`
+		PassengerCount int       `json:"passenger_count"`
+		PaymentAmount  float64   `json:"payment_amount"`
+		ExpiresAt      time.Time `json:"expires_at"`
+		UpdatedAt      time.Time `json:"updated_at"`
+	}
+
+	WebhookScheduleCancelledData struct {
+		ScheduleID          uint       `json:"schedule_id"`
+		Origin              string     `json:"origin"`
+		Destination         string     `json:"destination"`
+		DepartureTime       time.Time  `json:"departure_time"`
+		CancelledAt         *time.Time `json:"cancelled_at"`
+		Reason              string     `json:"reason"`
+		CancelledBookingIDs []uint     `json:"cancelled_booking_ids"`
+	}
+)
+
+// NewWebhookEndpointResponseFromEntity creates WebhookEndpointResponse from WebhookEndpoint entity
+func NewWebhookEndpointResponseFromEntity(endpoint *entities.WebhookEndpoint) WebhookEndpointResponse {
+	events := make([]string, 0)
+	for _, event := range endpoint.EventList() {
+		events = append(events, string(event))
+	}
+
+	return WebhookEndpointResponse{
+		ID:          endpoint.ID,
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		Events:      events,
+		PartnerID:   endpoint.PartnerID,
+		IsActive:    endpoint.IsActive,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}
+}
+
+// NewWebhookDeliveryResponseFromEntity creates WebhookDeliveryResponse from WebhookDelivery entity
+func NewWebhookDeliveryResponseFromEntity(delivery *entities.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:                 delivery.ID,
+		EndpointID:         delivery.EndpointID,
+		Event:              string(delivery.Event),
+		EventID:            delivery.EventID,
+		Status:             string(delivery.Status),
+		Attempts:           delivery.Attempts,
+		LastResponseStatus: delivery.LastResponseStatus,
+		LastError:          delivery.LastError,
+		DeliveredAt:        delivery.DeliveredAt,
+		CreatedAt:          delivery.CreatedAt,
+	}
+	if delivery.Status == entities.WebhookDeliveryStatusPending {
+		response.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	return response
+}
+
+// NewWebhookDeliveryDetailResponseFromEntity creates WebhookDeliveryDetailResponse with the payload and attempt log
+func NewWebhookDeliveryDetailResponseFromEntity(delivery *entities.WebhookDelivery) WebhookDeliveryDetailResponse {
+	attempts := make([]WebhookDeliveryAttemptResponse, len(delivery.AttemptLog))
+	for i, attempt := range delivery.AttemptLog {
+		attempts[i] = WebhookDeliveryAttemptResponse{
+			ID:             attempt.ID,
+			ResponseStatus: attempt.ResponseStatus,
+			ResponseBody:   attempt.ResponseBody,
+			Error:          attempt.Error,
+			DurationMs:     attempt.DurationMs,
+			CreatedAt:      attempt.CreatedAt,
+		}
+	}
+
+	return WebhookDeliveryDetailResponse{
+		WebhookDeliveryResponse: NewWebhookDeliveryResponseFromEntity(delivery),
+		Payload:                 json.RawMessage(delivery.Payload),
+		AttemptLog:              attempts,
+	}
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BankStatementEntryStatus string
+
+const (
+	BankStatementEntryMatched   BankStatementEntryStatus = "matched"   // auto-approved exact match
+	BankStatementEntryReview    BankStatementEntryStatus = "review"    // ambiguous, waiting for staff review
+	BankStatementEntryUnmatched BankStatementEntryStatus = "unmatched" // no waiting booking found
+	BankStatementEntryResolved  BankStatementEntryStatus = "resolved"  // matched manually from review queue
+	BankStatementEntryDismissed BankStatementEntryStatus = "dismissed" // reviewed, not a booking payment
+)
+
+type BankStatementImportStatus string
+
+const (
+	BankStatementImportProcessing BankStatementImportStatus = "processing"
+	BankStatementImportCompleted  BankStatementImportStatus = "completed"
+	BankStatementImportFailed     BankStatementImportStatus = "failed" // stopped halfway, the counters cover the rows processed
+)
+
+// BankStatementImport represents one uploaded bank statement file
+type BankStatementImport struct {
+	gorm.Model
+	Bank          string                    `gorm:"size:20;not null"`
+	FileName      string                    `gorm:"size:255"`
+	ImportedByID  uint                      `gorm:"not null;index"`
+	Status        BankStatementImportStatus `gorm:"type:enum('processing','completed','failed');default:'completed'"`
+	Error         string                    `gorm:"size:500"`
+	TotalRows     int                       `gorm:"not null;default:0"`
+	MatchedRows   int                       `gorm:"not null;default:0"`
+	ReviewRows    int                       `gorm:"not null;default:0"`
+	UnmatchedRows int                       `gorm:"not null;default:0"`
+
+	// Relations
+	ImportedBy User                 `gorm:"foreignKey:ImportedByID"`
+	Entries    []BankStatementEntry `gorm:"foreignKey:ImportID"`
+}
+
+// BankStatementEntry represents a credit transaction from a bank statement and its reconciliation result
+type BankStatementEntry struct {
+	gorm.Model
+	ImportID            uint                     `gorm:"not null;index"`
+	LineNumber          int                      `gorm:"not null"`
+	TransactionDate     time.Time                `gorm:"not null"`
+	Description         string                   `gorm:"type:text"`
+	Amount              float64                  `gorm:"type:decimal(12,2);not null"`
+	Status              BankStatementEntryStatus `gorm:"type:enum('matched','review','unmatched','resolved','dismissed');default:'unmatched';index"`
+	MatchedBookingID    *uint                    `gorm:"index"`
+	CandidateBookingIDs string                   `gorm:"size:255"` // Comma separated booking IDs for review
+	Notes               string                   `gorm:"size:500"`
+	ResolvedByID        *uint
+	ResolvedAt          *time.Time
+
+	// Relations
+	Import BankStatementImport `gorm:"foreignKey:ImportID;constraint:OnDelete:CASCADE"`
+}
@@ -17,6 +17,12 @@ const (
 	BookingStatusCancelled           BookingStatus = "cancelled"
 )
 
+// OpenBookingStatuses are statuses of bookings whose payment has not been settled yet
+var OpenBookingStatuses = []BookingStatus{
+	BookingStatusPending,
+	BookingStatusWaitingVerification,
+}
+
 type Booking struct {
 	gorm.Model
 	UserID        uint `gorm:"not null;index"`
@@ -24,7 +30,15 @@ type Booking struct {
 	BookingTime   time.Time
 	Status        BookingStatus `gorm:"type:enum('pending','waiting_verification','success','rejected','expired','cancelled');default:'pending'"`
 	ExpiresAt     time.Time     `gorm:"not null"`
-	PaymentAmount float64       `gorm:"type:decimal(10,2);not null;default:0"`
+	PaymentAmount float64       `gorm:"type:decimal(10,2);not null;default:0"` // Ticket total + unique code
+	UniqueCode    int           `gorm:"not null;default:0"`                    // Added to the transfer amount to identify the payment
+	// OpenUniqueCode and OpenAmountSuffix are generated by the database and only set while the booking is
+	// open, their unique indexes keep the code and the last 3 digits of the amount unique among open bookings
+	OpenUniqueCode   *int `gorm:"->;type:int GENERATED ALWAYS AS (IF(status IN ('pending','waiting_verification') AND deleted_at IS NULL, unique_code, NULL)) STORED;uniqueIndex"`
+	OpenAmountSuffix *int `gorm:"->;type:int GENERATED ALWAYS AS (IF(status IN ('pending','waiting_verification') AND deleted_at IS NULL, MOD(ROUND(payment_amount), 1000), NULL)) STORED;uniqueIndex"`
+	// PartnerID is set for bookings made with a partner API key, the commission is fixed when booking
+	PartnerID         *uint   `gorm:"index"`
+	PartnerCommission float64 `gorm:"type:decimal(10,2);not null;default:0"`
 
 	// Relations
 	User           User            `gorm:"foreignKey:UserID"`
@@ -32,3 +46,17 @@ type Booking struct {
 	BookingDetails []BookingDetail `gorm:"foreignKey:BookingID"`
 	Payment        *Payment        `gorm:"foreignKey:BookingID"`
 }
+
+// ChargedUniqueCode returns the unique code included in the amount the customer paid.
+// Counter (cash/EDC) payments are charged the ticket total without it.
+func (b *Booking) ChargedUniqueCode() int {
+	if b.Payment != nil && b.Payment.IsCounterPayment() {
+		return 0
+	}
+	return b.UniqueCode
+}
+
+// ChargedAmount returns the amount the customer paid (or has to pay) for the booking
+func (b *Booking) ChargedAmount() float64 {
+	return b.PaymentAmount - float64(b.UniqueCode-b.ChargedUniqueCode())
+}
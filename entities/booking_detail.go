@@ -1,16 +1,52 @@
 package entities
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
+// BoardingStatus is where a passenger of a paid booking is in the boarding process.
+// It is derived from the boarding timestamps of the booking detail.
+type BoardingStatus string
+
+const (
+	BoardingStatusNotBoarded BoardingStatus = "not_boarded"
+	BoardingStatusCheckedIn  BoardingStatus = "checked_in"
+	BoardingStatusBoarded    BoardingStatus = "boarded"
+	BoardingStatusNoShow     BoardingStatus = "no_show"
+)
+
 type BookingDetail struct {
 	gorm.Model
 	BookingID     uint    `gorm:"not null;index"`
 	SeatID        uint    `gorm:"not null;index"` // Changed from uniqueIndex to regular index
 	PassengerName string  `gorm:"size:100;not null"`
 	Price         float64 `gorm:"type:decimal(10,2);not null"`
+	// Identity is stored in passenger_* columns
+	Identity PassengerIdentity `gorm:"embedded;embeddedPrefix:passenger_"`
+	// CheckedInAt is set when the passenger reports at the pool, BoardedAt when the boarding pass is
+	// scanned at the vehicle. BoardedByID is the staff member or driver who scanned it.
+	CheckedInAt *time.Time
+	BoardedAt   *time.Time
+	BoardedByID *uint
+	// NoShowAt is set when the schedule departed without the passenger
+	NoShowAt *time.Time `gorm:"index"`
 	// Relations
 	Booking Booking `gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE"`
 	Seat    Seat    `gorm:"foreignKey:SeatID;constraint:OnDelete:CASCADE"`
 }
+
+// BoardingStatus returns the boarding state of the passenger
+func (d *BookingDetail) BoardingStatus() BoardingStatus {
+	switch {
+	case d.BoardedAt != nil:
+		return BoardingStatusBoarded
+	case d.NoShowAt != nil:
+		return BoardingStatusNoShow
+	case d.CheckedInAt != nil:
+		return BoardingStatusCheckedIn
+	default:
+		return BoardingStatusNotBoarded
+	}
+}
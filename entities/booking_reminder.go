@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+const (
+	BookingReminderPaymentExpiry   = "payment_expiry"
+	BookingReminderDeparturePrefix = "departure_"
+)
+
+// BookingReminder records that a reminder was queued for a booking. The unique index makes sure
+// every kind of reminder is sent only once per booking.
+type BookingReminder struct {
+	ID        uint   `gorm:"primarykey"`
+	BookingID uint   `gorm:"not null;uniqueIndex:idx_booking_reminder_kind"`
+	Kind      string `gorm:"size:40;not null;uniqueIndex:idx_booking_reminder_kind"`
+	CreatedAt time.Time
+}
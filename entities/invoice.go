@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Invoice is a formal tax invoice issued for a successful booking.
+// Company and billing details are snapshotted at issue time.
+type Invoice struct {
+	gorm.Model
+	BookingID     uint      `gorm:"not null;uniqueIndex"` // One booking = one invoice
+	InvoiceNumber string    `gorm:"size:30;not null;uniqueIndex"`
+	IssuedAt      time.Time `gorm:"not null"`
+	IssuedByID    *uint
+
+	// Seller details
+	CompanyName    string `gorm:"size:150"`
+	CompanyAddress string `gorm:"type:text"`
+	CompanyPhone   string `gorm:"size:30"`
+	CompanyEmail   string `gorm:"size:100"`
+	CompanyNPWP    string `gorm:"size:30"`
+
+	// Customer billing details
+	BillingName    string `gorm:"size:150;not null"`
+	BillingCompany string `gorm:"size:150"`
+	BillingAddress string `gorm:"type:text"`
+	BillingNPWP    string `gorm:"size:30"`
+	BillingEmail   string `gorm:"size:100"`
+	BillingPhone   string `gorm:"size:30"`
+
+	// Amounts (line item prices include PPN)
+	Subtotal       float64 `gorm:"type:decimal(12,2);not null"`
+	DiscountAmount float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	DiscountNote   string  `gorm:"size:255"`
+	TaxableAmount  float64 `gorm:"type:decimal(12,2);not null"` // DPP
+	TaxRate        float64 `gorm:"type:decimal(5,4);not null"`
+	TaxAmount      float64 `gorm:"type:decimal(12,2);not null"`
+	TotalAmount    float64 `gorm:"type:decimal(12,2);not null"`
+
+	// Relations
+	Booking Booking       `gorm:"foreignKey:BookingID"`
+	Items   []InvoiceItem `gorm:"foreignKey:InvoiceID"`
+}
+
+// InvoiceItem is a line of an invoice (one per passenger/leg)
+type InvoiceItem struct {
+	gorm.Model
+	InvoiceID   uint    `gorm:"not null;index"`
+	Description string  `gorm:"size:255;not null"`
+	Quantity    int     `gorm:"not null;default:1"`
+	UnitPrice   float64 `gorm:"type:decimal(12,2);not null"`
+	Amount      float64 `gorm:"type:decimal(12,2);not null"`
+
+	// Relations
+	Invoice Invoice `gorm:"foreignKey:InvoiceID;constraint:OnDelete:CASCADE"`
+}
+
+// InvoiceSequence keeps the last issued invoice number per month
+type InvoiceSequence struct {
+	ID         uint   `gorm:"primaryKey"`
+	Period     string `gorm:"size:6;not null;uniqueIndex"` // YYYYMM
+	LastNumber int    `gorm:"not null;default:0"`
+}
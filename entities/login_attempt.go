@@ -0,0 +1,35 @@
+package entities
+
+import "time"
+
+// LoginThrottle tracks recent failed logins for one key ("account:<email>" or "ip:<address>").
+// It is stored in the database so every replica sees the same counters.
+type LoginThrottle struct {
+	ID            uint   `gorm:"primarykey"`
+	ThrottleKey   string `gorm:"size:150;uniqueIndex;not null"`
+	FailedCount   int    `gorm:"not null;default:0"`
+	FirstFailedAt *time.Time
+	LastFailedAt  *time.Time
+	LockedUntil   *time.Time
+	UpdatedAt     time.Time
+}
+
+const (
+	LoginFailureUnknownEmail  = "unknown_email"
+	LoginFailureWrongPassword = "wrong_password"
+	LoginFailureAccountLocked = "account_locked"
+	LoginFailureIPLocked      = "ip_locked"
+	LoginFailureThrottled     = "throttled"
+	LoginFailureTwoFactorCode = "wrong_two_factor_code"
+)
+
+// LoginAuditLog records a failed login attempt
+type LoginAuditLog struct {
+	ID        uint      `gorm:"primarykey"`
+	CreatedAt time.Time `gorm:"index"`
+	Email     string    `gorm:"size:100;index"`
+	UserID    *uint     `gorm:"index"`
+	IPAddress string    `gorm:"size:45;index"`
+	UserAgent string    `gorm:"size:255"`
+	Reason    string    `gorm:"size:30"`
+}
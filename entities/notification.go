@@ -0,0 +1,70 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationEvent string
+
+const (
+	NotificationEventBookingCreated    NotificationEvent = "booking.created"
+	NotificationEventBookingApproved   NotificationEvent = "booking.approved"
+	NotificationEventBookingRejected   NotificationEvent = "booking.rejected"
+	NotificationEventBookingExpired    NotificationEvent = "booking.expired"
+	NotificationEventBookingCancelled  NotificationEvent = "booking.cancelled"
+	NotificationEventPaymentReminder   NotificationEvent = "booking.payment_reminder"
+	NotificationEventDepartureReminder NotificationEvent = "booking.departure_reminder"
+	// NotificationEventPaymentProofUploaded alerts staff that a payment proof awaits verification
+	NotificationEventPaymentProofUploaded NotificationEvent = "payment.proof_uploaded"
+)
+
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail    NotificationChannel = "email"
+	NotificationChannelSMS      NotificationChannel = "sms"
+	NotificationChannelWhatsApp NotificationChannel = "whatsapp"
+	// NotificationChannelInApp is the inbox in the app, it is always enabled
+	NotificationChannelInApp NotificationChannel = "in_app"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "pending"
+	OutboxStatusSent    OutboxStatus = "sent"
+	OutboxStatusFailed  OutboxStatus = "failed"
+)
+
+// OutboxMessage is a rendered notification waiting to be delivered. It is written in the same
+// transaction as the state change it announces and delivered later by the notification dispatcher.
+type OutboxMessage struct {
+	gorm.Model
+	Event     NotificationEvent   `gorm:"size:50;not null;index"`
+	Channel   NotificationChannel `gorm:"size:20;not null"`
+	UserID    *uint               `gorm:"index"`
+	BookingID *uint               `gorm:"index"`
+	Recipient string              `gorm:"size:100;not null"`
+	Subject   string              `gorm:"size:200"`
+	Body      string              `gorm:"type:text;not null"`
+	Status    OutboxStatus        `gorm:"type:enum('pending','sent','failed');default:'pending';index:idx_outbox_due,priority:1"`
+	Attempts  int                 `gorm:"not null;default:0"`
+	// NextAttemptAt is when the message is due, the dispatcher also pushes it forward while sending
+	NextAttemptAt time.Time `gorm:"not null;index:idx_outbox_due,priority:2"`
+	LastError     string    `gorm:"size:500"`
+	SentAt        *time.Time
+}
+
+// Notification is an entry in the in-app inbox of a user. Like outbox messages it is written in the
+// same transaction as the state change it announces.
+type Notification struct {
+	gorm.Model
+	UserID    uint              `gorm:"not null;index:idx_notification_inbox,priority:1"`
+	Event     NotificationEvent `gorm:"size:50;not null"`
+	BookingID *uint             `gorm:"index"`
+	Title     string            `gorm:"size:200;not null"`
+	Body      string            `gorm:"type:text;not null"`
+	ReadAt    *time.Time        `gorm:"index:idx_notification_inbox,priority:2"`
+}
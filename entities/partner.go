@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Partner is a travel agent or online travel agency that books through API keys.
+// Bookings are made with the partner's service user and attributed to the partner.
+type Partner struct {
+	gorm.Model
+	Name           string   `gorm:"size:100;not null"`
+	ContactEmail   string   `gorm:"size:100"`
+	CommissionRate float64  `gorm:"type:decimal(5,2);not null;default:0"` // percentage of the ticket price
+	IsActive       bool     `gorm:"not null;default:true"`
+	UserID         uint     `gorm:"not null;uniqueIndex"`
+	User           User     `gorm:"foreignKey:UserID"`
+	APIKeys        []APIKey `gorm:"foreignKey:PartnerID"`
+}
+
+// APIKey is a machine credential of a partner. Only the hash of the key is stored,
+// the prefix is kept to recognise the key in listings.
+type APIKey struct {
+	gorm.Model
+	PartnerID          uint    `gorm:"not null;index"`
+	Partner            Partner `gorm:"foreignKey:PartnerID"`
+	Name               string  `gorm:"size:100;not null"`
+	Prefix             string  `gorm:"size:20;not null"`
+	KeyHash            string  `gorm:"size:64;not null;uniqueIndex"`
+	Scopes             string  `gorm:"size:500;not null"` // comma separated permissions
+	RateLimitPerMinute int     `gorm:"not null"`
+	AllowedIPs         string  `gorm:"size:1000"` // comma separated IPs or CIDRs, empty allows every IP
+	ExpiresAt          *time.Time
+	RevokedAt          *time.Time
+	RotatedToID        *uint // the key that replaced this one
+	LastUsedAt         *time.Time
+	LastUsedIP         string `gorm:"size:45"`
+}
+
+// APIKeyUsage counts the requests of an API key per day
+type APIKeyUsage struct {
+	APIKeyID     uint      `gorm:"primaryKey"`
+	Date         time.Time `gorm:"primaryKey;type:date"`
+	RequestCount int64     `gorm:"not null;default:0"`
+	ErrorCount   int64     `gorm:"not null;default:0"`
+}
+
+// ScopeList returns the scopes of the key
+func (k *APIKey) ScopeList() []string {
+	return splitList(k.Scopes)
+}
+
+// AllowedIPList returns the IPs and CIDRs the key can be used from
+func (k *APIKey) AllowedIPList() []string {
+	return splitList(k.AllowedIPs)
+}
+
+// IsUsable reports whether the key is not revoked and not expired
+func (k *APIKey) IsUsable(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,24 @@
+package entities
+
+type PassengerIDType string
+
+const (
+	PassengerIDTypeNIK      PassengerIDType = "nik"
+	PassengerIDTypePassport PassengerIDType = "passport"
+)
+
+type Gender string
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+)
+
+// PassengerIdentity holds the identity details operators need for manifests and insurance.
+// IDNumber and Phone are encrypted with utils.EncryptString before they are stored.
+type PassengerIdentity struct {
+	IDType   PassengerIDType `gorm:"size:10"`
+	IDNumber string          `gorm:"size:255"`
+	Phone    string          `gorm:"size:255"`
+	Gender   Gender          `gorm:"size:10"`
+}
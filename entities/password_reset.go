@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PasswordResetToken is a single-use token emailed to reset a password.
+// Only the SHA-256 hash of the token is stored.
+type PasswordResetToken struct {
+	gorm.Model
+	UserID      uint      `gorm:"not null;index"`
+	User        User      `gorm:"foreignKey:UserID"`
+	TokenHash   string    `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	UsedAt      *time.Time
+	RequestedIP string `gorm:"size:45"`
+}
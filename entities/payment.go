@@ -14,6 +14,12 @@ const (
 	PaymentStatusFailed  PaymentStatus = "failed"
 )
 
+// Payment methods for payments taken at the pool counter
+const (
+	PaymentMethodCash = "cash"
+	PaymentMethodEDC  = "edc"
+)
+
 type Payment struct {
 	gorm.Model
 	BookingID     uint          `gorm:"not null;uniqueIndex"` // One booking = one payment
@@ -21,7 +27,27 @@ type Payment struct {
 	PaymentStatus PaymentStatus `gorm:"type:enum('pending','success','failed');default:'pending'"`
 	PaymentDate   *time.Time    `gorm:"null"`
 	ProofImageURL string        `gorm:"type:text"`
+	// Content type sniffed from the uploaded file and SHA-256 of the stored (sanitized) file,
+	// used to detect the same proof being reused across bookings
+	ProofContentType string `gorm:"size:50"`
+	ProofSHA256      string `gorm:"size:64;index"`
+	// UniqueProofSHA256 is generated by the database and only set for stored proofs,
+	// its unique index keeps a proof from being used for two bookings
+	UniqueProofSHA256 *string `gorm:"->;type:varchar(64) GENERATED ALWAYS AS (IF(deleted_at IS NULL, NULLIF(proof_sha256, ''), NULL)) STORED;uniqueIndex"`
+
+	// Counter (cash/EDC) payments recorded by staff. Amount is the ticket total charged,
+	// without the unique code that is only added to bank transfers
+	Amount         float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	AmountReceived float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	ChangeAmount   float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	CashierID      *uint   `gorm:"index"`
 
 	// Relations
 	Booking Booking `gorm:"foreignKey:BookingID"`
+	Cashier *User   `gorm:"foreignKey:CashierID"`
+}
+
+// IsCounterPayment reports whether the payment was taken at the counter by staff
+func (p *Payment) IsCounterPayment() bool {
+	return p.CashierID != nil
 }
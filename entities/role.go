@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// Role groups permissions. Users reference their role by name.
+type Role struct {
+	ID          uint             `gorm:"primarykey"`
+	Name        string           `gorm:"size:30;uniqueIndex;not null"`
+	Description string           `gorm:"size:255"`
+	IsSystem    bool             `gorm:"not null;default:false"` // system roles cannot be deleted
+	Permissions []RolePermission `gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type RolePermission struct {
+	RoleID     uint   `gorm:"primaryKey"`
+	Permission string `gorm:"primaryKey;size:60"`
+}
+
+// PermissionNames returns the permissions of the role as strings
+func (r *Role) PermissionNames() []string {
+	names := make([]string, len(r.Permissions))
+	for i, permission := range r.Permissions {
+		names[i] = permission.Permission
+	}
+	return names
+}
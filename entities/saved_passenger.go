@@ -0,0 +1,12 @@
+package entities
+
+import "gorm.io/gorm"
+
+// SavedPassenger is a frequent traveller of a user that can pre-fill the passengers of a booking
+type SavedPassenger struct {
+	gorm.Model
+	UserID uint   `gorm:"not null;index"`
+	Label  string `gorm:"size:50"` // e.g. "Ibu", "Adik"
+	Name   string `gorm:"size:100;not null"`
+	PassengerIdentity
+}
@@ -6,6 +6,15 @@ import (
 	"gorm.io/gorm"
 )
 
+// ScheduleStatus is where a trip is in its run. Cancellation is tracked separately by CancelledAt.
+type ScheduleStatus string
+
+const (
+	ScheduleStatusScheduled ScheduleStatus = "scheduled"
+	ScheduleStatusDeparted  ScheduleStatus = "departed"
+	ScheduleStatusCompleted ScheduleStatus = "completed"
+)
+
 type Schedule struct {
 	gorm.Model
 	RouteID        uint      `gorm:"not null;index"`
@@ -14,8 +23,19 @@ type Schedule struct {
 	Price          float64   `gorm:"type:decimal(10,2);not null"`
 	TotalSeats     int       `gorm:"not null"`
 	AvailableSeats int       `gorm:"not null"`
+	// PickupPoint is where passengers board, e.g. the address of the pool in the origin city
+	PickupPoint string `gorm:"size:255"`
+	// CancelledAt is set when the trip is cancelled, its bookings are cancelled with it
+	CancelledAt        *time.Time `gorm:"index"`
+	CancellationReason string     `gorm:"size:255"`
+	// DriverID is the driver assigned to the trip, who starts and ends it
+	DriverID    *uint          `gorm:"index"`
+	Status      ScheduleStatus `gorm:"type:enum('scheduled','departed','completed');default:'scheduled';index"`
+	DepartedAt  *time.Time
+	CompletedAt *time.Time
 	// Relations
 	Route    Route     `gorm:"foreignKey:RouteID"`
+	Driver   *User     `gorm:"foreignKey:DriverID"`
 	Seats    []Seat    `gorm:"foreignKey:ScheduleID"`
 	Bookings []Booking `gorm:"foreignKey:ScheduleID"`
 }
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserSession groups the rotating refresh tokens issued from a single login.
+// Access tokens carry the session ID, so revoking the session invalidates them too.
+type UserSession struct {
+	gorm.Model
+	UserID        uint   `gorm:"not null;index"`
+	User          User   `gorm:"foreignKey:UserID"`
+	UserAgent     string `gorm:"size:255"`
+	IPAddress     string `gorm:"size:45"`
+	LastUsedAt    time.Time
+	RevokedAt     *time.Time `gorm:"index"`
+	RevokedReason string     `gorm:"size:50"`
+}
+
+// IsActive reports whether the session has not been revoked
+func (s *UserSession) IsActive() bool {
+	return s.RevokedAt == nil
+}
+
+// RefreshToken is a single-use refresh token. Only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	gorm.Model
+	SessionID uint        `gorm:"not null;index"`
+	Session   UserSession `gorm:"foreignKey:SessionID"`
+	TokenHash string      `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time   `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+const (
+	SessionRevokedLogout   = "logout"
+	SessionRevokedReuse    = "refresh_token_reuse"
+	SessionRevokedByAdmin  = "revoked_by_admin"
+	SessionRevokedPassword = "password_reset"
+)
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TripIncidentCategory is the kind of incident a driver reports
+type TripIncidentCategory string
+
+const (
+	TripIncidentBreakdown TripIncidentCategory = "breakdown"
+	TripIncidentAccident  TripIncidentCategory = "accident"
+	TripIncidentDelay     TripIncidentCategory = "delay"
+	TripIncidentPassenger TripIncidentCategory = "passenger"
+	TripIncidentOther     TripIncidentCategory = "other"
+)
+
+// TripIncident is something that went wrong on a trip, reported by its driver
+type TripIncident struct {
+	gorm.Model
+	ScheduleID  uint                 `gorm:"not null;index"`
+	DriverID    uint                 `gorm:"not null;index"`
+	Category    TripIncidentCategory `gorm:"type:enum('breakdown','accident','delay','passenger','other');not null"`
+	Description string               `gorm:"type:text;not null"`
+	// OccurredAt is when the incident happened, which may be before it was reported
+	OccurredAt time.Time `gorm:"not null"`
+	// Relations
+	Schedule Schedule `gorm:"foreignKey:ScheduleID"`
+	Driver   User     `gorm:"foreignKey:DriverID"`
+}
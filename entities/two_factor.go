@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// TwoFactorRecoveryCode is a single-use code to log in without the authenticator app.
+// Only the SHA-256 hash of the code is stored.
+type TwoFactorRecoveryCode struct {
+	ID        uint   `gorm:"primarykey"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"size:64;not null;index"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// TwoFactorPolicy decides whether users of a role must enable two-factor authentication
+type TwoFactorPolicy struct {
+	Role      string `gorm:"primarykey;size:20"`
+	Required  bool   `gorm:"not null;default:false"`
+	UpdatedAt time.Time
+}
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"time"
+
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -9,10 +11,22 @@ type User struct {
 	gorm.Model
 	Email       string `gorm:"size:100;uniqueIndex"`
 	Password    string
-	Role        string `gorm:"type:enum('user','admin', 'staff');default:'user'"`
+	Role        string `gorm:"size:30;default:'user';index"`
 	FirstName   string `gorm:"size:50"`
 	LastName    string `gorm:"size:50"`
 	PhoneNumber string `gorm:"size:20"`
+	// Locale is the preferred language ("id" or "en"), empty to follow the request
+	Locale string `gorm:"size:5"`
+	// TokenVersion is embedded in access tokens; bumping it invalidates tokens issued before the change
+	TokenVersion    uint `gorm:"not null;default:1"`
+	EmailVerifiedAt *time.Time
+	// PendingEmail is a requested new email address, it replaces Email once it is verified
+	PendingEmail    string `gorm:"size:100"`
+	PhoneVerifiedAt *time.Time
+	// TwoFactorSecret is the encrypted TOTP secret; it is pending until TwoFactorEnabledAt is set
+	TwoFactorSecret    string `gorm:"size:255"`
+	TwoFactorEnabledAt *time.Time
+	TwoFactorLastStep  int64 `gorm:"not null;default:0"`
 }
 
 // HashPassword hashes the user's password using bcrypt
@@ -29,3 +43,25 @@ func (u *User) HashPassword() error {
 func (u *User) CheckPassword(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
 }
+
+// BumpTokenVersion invalidates every access token issued with the current version
+func (u *User) BumpTokenVersion() {
+	u.TokenVersion++
+}
+
+// IsVerified reports whether both the email and the phone number have been verified
+func (u *User) IsVerified() bool {
+	return u.EmailVerifiedAt != nil && u.PhoneVerifiedAt != nil
+}
+
+// IsTwoFactorEnabled reports whether the user has to enter a TOTP code at login
+func (u *User) IsTwoFactorEnabled() bool {
+	return u.TwoFactorEnabledAt != nil
+}
+
+// MarkVerified marks the email and phone number as verified, e.g. for accounts created by an admin
+func (u *User) MarkVerified() {
+	now := time.Now()
+	u.EmailVerifiedAt = &now
+	u.PhoneVerifiedAt = &now
+}
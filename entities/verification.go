@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type VerificationChannel string
+
+const (
+	VerificationChannelEmail VerificationChannel = "email"
+	VerificationChannelPhone VerificationChannel = "phone"
+)
+
+// VerificationCode is a one-time code sent to verify an email address or phone number.
+// Emails get a long link token, phones get a short numeric code. Only the hash is stored.
+type VerificationCode struct {
+	gorm.Model
+	UserID    uint                `gorm:"not null;index"`
+	User      User                `gorm:"foreignKey:UserID"`
+	Channel   VerificationChannel `gorm:"type:enum('email','phone');not null"`
+	Target    string              `gorm:"size:100;not null"` // email or phone number the code was sent to
+	CodeHash  string              `gorm:"size:64;not null;index"`
+	ExpiresAt time.Time           `gorm:"not null"`
+	UsedAt    *time.Time
+	Attempts  int `gorm:"not null;default:0"`
+}
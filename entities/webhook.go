@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventBookingCreated    WebhookEvent = "booking.created"
+	WebhookEventBookingPaid       WebhookEvent = "booking.paid"
+	WebhookEventBookingVerified   WebhookEvent = "booking.verified"
+	WebhookEventBookingExpired    WebhookEvent = "booking.expired"
+	WebhookEventScheduleCancelled WebhookEvent = "schedule.cancelled"
+)
+
+// WebhookEvents lists the events endpoints can subscribe to
+var WebhookEvents = []WebhookEvent{
+	WebhookEventBookingCreated,
+	WebhookEventBookingPaid,
+	WebhookEventBookingVerified,
+	WebhookEventBookingExpired,
+	WebhookEventScheduleCancelled,
+}
+
+// WebhookEndpoint is a URL that receives signed event payloads
+type WebhookEndpoint struct {
+	gorm.Model
+	URL         string `gorm:"size:500;not null"`
+	Description string `gorm:"size:255"`
+	// Secret is the encrypted HMAC signing secret
+	Secret string `gorm:"size:255;not null"`
+	// Events is a comma separated list of subscribed events
+	Events string `gorm:"size:500;not null"`
+	// PartnerID limits the endpoint to events about the bookings of one partner
+	PartnerID *uint    `gorm:"index"`
+	Partner   *Partner `gorm:"foreignKey:PartnerID"`
+	IsActive  bool     `gorm:"not null;default:true"`
+}
+
+// EventList returns the subscribed events
+func (e *WebhookEndpoint) EventList() []WebhookEvent {
+	var events []WebhookEvent
+	for _, event := range strings.Split(e.Events, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, WebhookEvent(event))
+		}
+	}
+	return events
+}
+
+// Subscribes reports whether the endpoint receives the event
+func (e *WebhookEndpoint) Subscribes(event WebhookEvent) bool {
+	for _, subscribed := range e.EventList() {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event payload to deliver to one endpoint. It is written in the same
+// transaction as the change it announces and delivered by the webhook dispatcher.
+type WebhookDelivery struct {
+	gorm.Model
+	EndpointID uint            `gorm:"not null;index"`
+	Endpoint   WebhookEndpoint `gorm:"foreignKey:EndpointID"`
+	Event      WebhookEvent    `gorm:"size:50;not null;index"`
+	// EventID identifies the event, it is the same for every endpoint and every redelivery
+	EventID string                `gorm:"size:40;not null;index"`
+	Payload string                `gorm:"type:text;not null"`
+	Status  WebhookDeliveryStatus `gorm:"type:enum('pending','succeeded','failed');default:'pending';index:idx_webhook_delivery_due,priority:1"`
+	// Attempts counts the attempts since the delivery was queued or manually redelivered
+	Attempts           int       `gorm:"not null;default:0"`
+	NextAttemptAt      time.Time `gorm:"not null;index:idx_webhook_delivery_due,priority:2"`
+	LastResponseStatus int
+	LastError          string `gorm:"size:500"`
+	DeliveredAt        *time.Time
+	AttemptLog         []WebhookDeliveryAttempt `gorm:"foreignKey:DeliveryID"`
+}
+
+// WebhookDeliveryAttempt logs one HTTP request of a delivery
+type WebhookDeliveryAttempt struct {
+	ID             uint      `gorm:"primarykey"`
+	DeliveryID     uint      `gorm:"not null;index"`
+	CreatedAt      time.Time `gorm:"index"`
+	ResponseStatus int
+	ResponseBody   string `gorm:"size:1000"`
+	Error          string `gorm:"size:500"`
+	DurationMs     int64
+}
@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var monthNames = map[Locale][12]string{
+	EN: {"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	ID: {"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+}
+
+// FormatDate formats a date with the month written out, e.g. "16 Oktober 2026" or "16 October 2026"
+func FormatDate(locale Locale, t time.Time) string {
+	months, ok := monthNames[locale]
+	if !ok {
+		months = monthNames[EN]
+	}
+	return fmt.Sprintf("%d %s %d", t.Day(), months[t.Month()-1], t.Year())
+}
+
+// FormatDateTime formats a date and the time of day, e.g. "16 Oktober 2026 08:30".
+// The time is shown as is, callers convert it to the time zone they mention.
+func FormatDateTime(locale Locale, t time.Time) string {
+	return FormatDate(locale, t) + " " + t.Format("15:04")
+}
+
+// FormatCurrency formats a rupiah amount without decimals, e.g. "Rp 150.000" or "IDR 150,000"
+func FormatCurrency(locale Locale, amount float64) string {
+	prefix, separator := "IDR ", ","
+	if locale == ID {
+		prefix, separator = "Rp ", "."
+	}
+
+	sign := ""
+	rounded := math.Round(amount)
+	if rounded < 0 {
+		sign = "-"
+		rounded = -rounded
+	}
+	return sign + prefix + groupThousands(strconv.FormatFloat(rounded, 'f', 0, 64), separator)
+}
+
+// groupThousands inserts the separator between every group of three digits
+func groupThousands(digits, separator string) string {
+	n := len(digits)
+	if n <= 3 {
+		return digits
+	}
+
+	var result strings.Builder
+	for i, char := range digits {
+		if i > 0 && (n-i)%3 == 0 {
+			result.WriteString(separator)
+		}
+		result.WriteRune(char)
+	}
+	return result.String()
+}
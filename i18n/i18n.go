@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"malakashuttle/config"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale is a supported language of responses, notifications and documents
+type Locale string
+
+const (
+	EN Locale = "en"
+	ID Locale = "id"
+)
+
+// catalogs maps a message in English to its translation. English messages are their own key,
+// so a message without a translation is shown in English.
+var catalogs = map[Locale]map[string]string{
+	ID: indonesian,
+}
+
+// Parse returns the supported locale of a language tag such as "id", "id-ID" or "en-US"
+func Parse(tag string) (Locale, bool) {
+	language := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	switch language {
+	case "en":
+		return EN, true
+	case "id", "in": // "in" is the legacy code for Indonesian
+		return ID, true
+	default:
+		return "", false
+	}
+}
+
+// Default returns the configured default locale, English if it is not supported
+func Default() Locale {
+	if locale, ok := Parse(config.GetDefaultLocale()); ok {
+		return locale
+	}
+	return EN
+}
+
+// Resolve returns the locale of a stored preference, or the default locale
+func Resolve(preference string) Locale {
+	if locale, ok := Parse(preference); ok {
+		return locale
+	}
+	return Default()
+}
+
+// FromAcceptLanguage returns the supported locale the client prefers most in an Accept-Language header
+func FromAcceptLanguage(header string) (Locale, bool) {
+	type candidate struct {
+		locale  Locale
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		locale, ok := Parse(fields[0])
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{locale: locale, quality: quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].locale, true
+}
+
+// T translates an English message to the locale. Messages without a translation are returned as is.
+func T(locale Locale, message string) string {
+	if translated, ok := catalogs[locale][message]; ok {
+		return translated
+	}
+	return message
+}
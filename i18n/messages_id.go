@@ -0,0 +1,506 @@
+package i18n
+
+// indonesian holds the Indonesian translations of the API messages, notification texts and receipts
+var indonesian = map[string]string{
+	// General
+	"Validation failed":                         "Validasi gagal",
+	"Invalid request data":                      "Data permintaan tidak valid",
+	"Invalid request body":                      "Isi permintaan tidak valid",
+	"Invalid input data":                        "Data masukan tidak valid",
+	"Invalid query parameters":                  "Parameter query tidak valid",
+	"An unexpected error occurred":              "Terjadi kesalahan yang tidak terduga",
+	"Invalid from format, use YYYY-MM-DD":       "Format from tidak valid, gunakan YYYY-MM-DD",
+	"Invalid to format, use YYYY-MM-DD":         "Format to tidak valid, gunakan YYYY-MM-DD",
+	"invalid from format, use YYYY-MM-DD HH:mm": "format from tidak valid, gunakan YYYY-MM-DD HH:mm",
+	"invalid to format, use YYYY-MM-DD HH:mm":   "format to tidak valid, gunakan YYYY-MM-DD HH:mm",
+	"to must be after from":                     "to harus setelah from",
+	"Customer":                                  "Pelanggan",
+
+	// Authentication and sessions
+	"Authentication is not configured": "Autentikasi belum dikonfigurasi",
+	"Authorization header is required": "Header Authorization wajib diisi",
+	"User not authenticated":           "Pengguna belum terautentikasi",
+	"Invalid email or password":        "Email atau kata sandi salah",
+	"Login successful":                 "Login berhasil",
+	"Logout successful":                "Logout berhasil",
+	"Failed to logout":                 "Gagal logout",
+	"Failed to create session":         "Gagal membuat sesi",
+	"Failed to generate token":         "Gagal membuat token",
+	"Failed to refresh token":          "Gagal memperbarui token",
+	"Token refreshed successfully":     "Token berhasil diperbarui",
+	"Invalid refresh token":            "Refresh token tidak valid",
+	"Invalid or expired refresh token": "Refresh token tidak valid atau sudah kedaluwarsa",
+	"Refresh token has already been used, all tokens of this session have been revoked": "Refresh token sudah pernah digunakan, semua token sesi ini telah dicabut",
+	"session has been revoked":                                        "sesi telah dicabut",
+	"token has been invalidated":                                      "token sudah tidak berlaku",
+	"token is not bound to a session":                                 "token tidak terikat pada sesi",
+	"user no longer exists":                                           "pengguna sudah tidak ada",
+	"User registered successfully":                                    "Pengguna berhasil didaftarkan",
+	"User with this email already exists":                             "Pengguna dengan email ini sudah ada",
+	"user with this email already exists":                             "pengguna dengan email ini sudah ada",
+	"Failed to hash password":                                         "Gagal mengenkripsi kata sandi",
+	"failed to hash password":                                         "gagal mengenkripsi kata sandi",
+	"Failed to load permissions":                                      "Gagal memuat hak akses",
+	"If the email is registered, a password reset link has been sent": "Jika email terdaftar, tautan reset kata sandi telah dikirim",
+	"Failed to process password reset request":                        "Gagal memproses permintaan reset kata sandi",
+	"Failed to create reset token":                                    "Gagal membuat token reset",
+	"Failed to generate reset token":                                  "Gagal membuat token reset",
+	"Password reset token is invalid or expired":                      "Token reset kata sandi tidak valid atau sudah kedaluwarsa",
+	"Failed to reset password":                                        "Gagal mereset kata sandi",
+	"Password has been reset, please login again":                     "Kata sandi telah direset, silakan login kembali",
+	"Failed to check login attempts":                                  "Gagal memeriksa percobaan login",
+
+	// API keys and partners
+	"API key authentication is not configured":    "Autentikasi API key belum dikonfigurasi",
+	"API keys are not accepted for this endpoint": "API key tidak dapat digunakan untuk endpoint ini",
+	"Invalid API key":                             "API key tidak valid",
+	"Invalid API key ID":                          "ID API key tidak valid",
+	"API key has expired or been revoked":         "API key sudah kedaluwarsa atau dicabut",
+	"API key cannot be used from this IP address": "API key tidak dapat digunakan dari alamat IP ini",
+	"API key rate limit exceeded":                 "Batas penggunaan API key terlampaui",
+	"API key not found":                           "API key tidak ditemukan",
+	"API key created, store the key now because it is not shown again":     "API key dibuat, simpan key sekarang karena tidak akan ditampilkan lagi",
+	"API key rotated, store the new key now because it is not shown again": "API key diganti, simpan key baru sekarang karena tidak akan ditampilkan lagi",
+	"API key has already been rotated":                                     "API key sudah pernah diganti",
+	"Only active API keys can be rotated":                                  "Hanya API key aktif yang dapat diganti",
+	"API key revoked successfully":                                         "API key berhasil dicabut",
+	"API keys retrieved successfully":                                      "API key berhasil diambil",
+	"API key usage retrieved successfully":                                 "Penggunaan API key berhasil diambil",
+	"Failed to create API key":                                             "Gagal membuat API key",
+	"Failed to generate API key":                                           "Gagal membuat API key",
+	"Failed to get API key":                                                "Gagal mengambil API key",
+	"Failed to get API keys":                                               "Gagal mengambil daftar API key",
+	"Failed to get API key usage":                                          "Gagal mengambil penggunaan API key",
+	"Failed to revoke API key":                                             "Gagal mencabut API key",
+	"Failed to rotate API key":                                             "Gagal mengganti API key",
+	"Partner account is disabled":                                          "Akun partner dinonaktifkan",
+	"Invalid partner ID":                                                   "ID partner tidak valid",
+	"Partner not found":                                                    "Partner tidak ditemukan",
+	"Partner created successfully":                                         "Partner berhasil dibuat",
+	"Partner retrieved successfully":                                       "Partner berhasil diambil",
+	"Partner updated successfully":                                         "Partner berhasil diperbarui",
+	"Partners retrieved successfully":                                      "Daftar partner berhasil diambil",
+	"Commission report retrieved successfully":                             "Laporan komisi berhasil diambil",
+	"Failed to create partner":                                             "Gagal membuat partner",
+	"Failed to get partner":                                                "Gagal mengambil partner",
+	"Failed to get partners":                                               "Gagal mengambil daftar partner",
+	"Failed to update partner":                                             "Gagal memperbarui partner",
+	"Failed to get commission report":                                      "Gagal mengambil laporan komisi",
+
+	// Verification
+	"Email verified successfully":                        "Email berhasil diverifikasi",
+	"Phone number verified successfully":                 "Nomor telepon berhasil diverifikasi",
+	"Verification code sent successfully":                "Kode verifikasi berhasil dikirim",
+	"Verification status retrieved successfully":         "Status verifikasi berhasil diambil",
+	"Verification code is invalid or expired":            "Kode verifikasi tidak valid atau sudah kedaluwarsa",
+	"Verification link is invalid or expired":            "Tautan verifikasi tidak valid atau sudah kedaluwarsa",
+	"Please wait before requesting a new code":           "Harap tunggu sebelum meminta kode baru",
+	"Too many wrong attempts, please request a new code": "Terlalu banyak percobaan yang salah, silakan minta kode baru",
+	"Failed to verify email":                             "Gagal memverifikasi email",
+	"Failed to verify phone number":                      "Gagal memverifikasi nomor telepon",
+	"Failed to check verification codes":                 "Gagal memeriksa kode verifikasi",
+	"Failed to create verification code":                 "Gagal membuat kode verifikasi",
+	"Failed to generate verification code":               "Gagal membuat kode verifikasi",
+	"Failed to generate verification token":              "Gagal membuat token verifikasi",
+
+	// Two-factor authentication
+	"Invalid or expired challenge token, please login again":           "Token tantangan tidak valid atau sudah kedaluwarsa, silakan login kembali",
+	"Failed to generate challenge token":                               "Gagal membuat token tantangan",
+	"Invalid two-factor code":                                          "Kode dua faktor tidak valid",
+	"Invalid recovery code":                                            "Kode pemulihan tidak valid",
+	"Provide either code or recovery_code":                             "Isi salah satu dari code atau recovery_code",
+	"Two-factor code has already been used, wait for the next code":    "Kode dua faktor sudah digunakan, tunggu kode berikutnya",
+	"Two-factor authentication is already enabled":                     "Autentikasi dua faktor sudah aktif",
+	"Two-factor authentication is not enabled":                         "Autentikasi dua faktor belum aktif",
+	"Two-factor authentication is not available for customer accounts": "Autentikasi dua faktor tidak tersedia untuk akun pelanggan",
+	"Two-factor authentication is required for your role":              "Autentikasi dua faktor wajib untuk peran Anda",
+	"Two-factor authentication must be enabled for your role":          "Autentikasi dua faktor harus diaktifkan untuk peran Anda",
+	"Two-factor setup has not been started":                            "Pengaturan dua faktor belum dimulai",
+	"Scan the provisioning URI with your authenticator app and confirm a code to enable two-factor authentication": "Pindai URI provisioning dengan aplikasi autentikator Anda lalu konfirmasi kode untuk mengaktifkan autentikasi dua faktor",
+	"Two-factor authentication enabled, store the recovery codes in a safe place":                                  "Autentikasi dua faktor diaktifkan, simpan kode pemulihan di tempat yang aman",
+	"Two-factor authentication disabled":           "Autentikasi dua faktor dinonaktifkan",
+	"Two-factor authentication reset successfully": "Autentikasi dua faktor berhasil direset",
+	"Two-factor status retrieved successfully":     "Status dua faktor berhasil diambil",
+	"Two-factor policies retrieved successfully":   "Kebijakan dua faktor berhasil diambil",
+	"Two-factor policy updated successfully":       "Kebijakan dua faktor berhasil diperbarui",
+	"Recovery codes regenerated successfully":      "Kode pemulihan berhasil dibuat ulang",
+	"Failed to enable two-factor authentication":   "Gagal mengaktifkan autentikasi dua faktor",
+	"Failed to disable two-factor authentication":  "Gagal menonaktifkan autentikasi dua faktor",
+	"Failed to reset two-factor authentication":    "Gagal mereset autentikasi dua faktor",
+	"Failed to verify two-factor code":             "Gagal memverifikasi kode dua faktor",
+	"Failed to verify recovery code":               "Gagal memverifikasi kode pemulihan",
+	"Failed to generate recovery codes":            "Gagal membuat kode pemulihan",
+	"Failed to save recovery codes":                "Gagal menyimpan kode pemulihan",
+	"Failed to count recovery codes":               "Gagal menghitung kode pemulihan",
+	"Failed to generate secret":                    "Gagal membuat secret",
+	"Failed to encrypt secret":                     "Gagal mengenkripsi secret",
+	"Failed to read secret":                        "Gagal membaca secret",
+	"Failed to save secret":                        "Gagal menyimpan secret",
+	"Failed to get two-factor policies":            "Gagal mengambil kebijakan dua faktor",
+	"Failed to get two-factor policy":              "Gagal mengambil kebijakan dua faktor",
+	"Failed to save two-factor policy":             "Gagal menyimpan kebijakan dua faktor",
+
+	// Login protection
+	"Login audit logs retrieved successfully": "Log audit login berhasil diambil",
+	"Failed to get login audit logs":          "Gagal mengambil log audit login",
+	"User login unlocked successfully":        "Login pengguna berhasil dibuka",
+	"Failed to unlock user":                   "Gagal membuka kunci pengguna",
+
+	// Users, roles and profile
+	"Invalid user ID":                                 "ID pengguna tidak valid",
+	"User not found":                                  "Pengguna tidak ditemukan",
+	"user not found":                                  "pengguna tidak ditemukan",
+	"User created successfully":                       "Pengguna berhasil dibuat",
+	"User retrieved successfully":                     "Pengguna berhasil diambil",
+	"User updated successfully":                       "Pengguna berhasil diperbarui",
+	"User deleted successfully":                       "Pengguna berhasil dihapus",
+	"Users retrieved successfully":                    "Daftar pengguna berhasil diambil",
+	"User sessions revoked successfully":              "Sesi pengguna berhasil dicabut",
+	"Failed to create user":                           "Gagal membuat pengguna",
+	"Failed to get user":                              "Gagal mengambil pengguna",
+	"Failed to get users":                             "Gagal mengambil daftar pengguna",
+	"Failed to revoke sessions":                       "Gagal mencabut sesi",
+	"Failed to revoke other sessions":                 "Gagal mencabut sesi lainnya",
+	"Failed to check email":                           "Gagal memeriksa email",
+	"cannot delete admin user":                        "pengguna admin tidak dapat dihapus",
+	"cannot update admin user":                        "pengguna admin tidak dapat diubah",
+	"invalid role: role does not exist":               "peran tidak valid: peran tidak ada",
+	"invalid role: the admin role cannot be assigned": "peran tidak valid: peran admin tidak dapat diberikan",
+	"invalid role: partner accounts are created through /admin/partners": "peran tidak valid: akun partner dibuat melalui /admin/partners",
+	"Role not found":                                                       "Peran tidak ditemukan",
+	"Role created successfully":                                            "Peran berhasil dibuat",
+	"Role retrieved successfully":                                          "Peran berhasil diambil",
+	"Role updated successfully":                                            "Peran berhasil diperbarui",
+	"Role deleted successfully":                                            "Peran berhasil dihapus",
+	"Roles retrieved successfully":                                         "Daftar peran berhasil diambil",
+	"Permissions retrieved successfully":                                   "Daftar hak akses berhasil diambil",
+	"Role with this name already exists":                                   "Peran dengan nama ini sudah ada",
+	"Role is still assigned to users":                                      "Peran masih digunakan oleh pengguna",
+	"System roles cannot be deleted":                                       "Peran sistem tidak dapat dihapus",
+	"The admin role always has every permission and cannot be changed":     "Peran admin selalu memiliki semua hak akses dan tidak dapat diubah",
+	"Failed to create role":                                                "Gagal membuat peran",
+	"Failed to get role":                                                   "Gagal mengambil peran",
+	"Failed to get roles":                                                  "Gagal mengambil daftar peran",
+	"Failed to update role":                                                "Gagal memperbarui peran",
+	"Failed to delete role":                                                "Gagal menghapus peran",
+	"Failed to check role":                                                 "Gagal memeriksa peran",
+	"Failed to check role usage":                                           "Gagal memeriksa penggunaan peran",
+	"Profile retrieved successfully":                                       "Profil berhasil diambil",
+	"Profile updated successfully":                                         "Profil berhasil diperbarui",
+	"Failed to update profile":                                             "Gagal memperbarui profil",
+	"Password is incorrect":                                                "Kata sandi salah",
+	"Invalid password":                                                     "Kata sandi tidak valid",
+	"Current password is incorrect":                                        "Kata sandi saat ini salah",
+	"New password must be different from the current password":             "Kata sandi baru harus berbeda dari kata sandi saat ini",
+	"Password changed successfully, refresh your access token to continue": "Kata sandi berhasil diubah, perbarui access token Anda untuk melanjutkan",
+	"Failed to change password":                                            "Gagal mengubah kata sandi",
+	"Email is already used by another account":                             "Email sudah digunakan oleh akun lain",
+	"New email is the same as the current email":                           "Email baru sama dengan email saat ini",
+	"Verification link sent, the email changes once the new address is verified": "Tautan verifikasi telah dikirim, email akan berubah setelah alamat baru diverifikasi",
+	"Failed to change email": "Gagal mengubah email",
+
+	// Saved passengers and passenger identities
+	"Invalid passenger ID":                                                              "ID penumpang tidak valid",
+	"Saved passenger not found":                                                         "Penumpang tersimpan tidak ditemukan",
+	"Passenger saved successfully":                                                      "Penumpang berhasil disimpan",
+	"Saved passenger retrieved successfully":                                            "Penumpang tersimpan berhasil diambil",
+	"Saved passengers retrieved successfully":                                           "Daftar penumpang tersimpan berhasil diambil",
+	"Saved passenger updated successfully":                                              "Penumpang tersimpan berhasil diperbarui",
+	"Saved passenger deleted successfully":                                              "Penumpang tersimpan berhasil dihapus",
+	"Failed to save passenger":                                                          "Gagal menyimpan penumpang",
+	"Failed to get saved passenger":                                                     "Gagal mengambil penumpang tersimpan",
+	"Failed to get saved passengers":                                                    "Gagal mengambil daftar penumpang tersimpan",
+	"Failed to update saved passenger":                                                  "Gagal memperbarui penumpang tersimpan",
+	"Failed to delete saved passenger":                                                  "Gagal menghapus penumpang tersimpan",
+	"Failed to encrypt passenger identity":                                              "Gagal mengenkripsi identitas penumpang",
+	"Failed to read passenger identity":                                                 "Gagal membaca identitas penumpang",
+	"invalid passenger: passenger_name or saved_passenger_id is required":               "penumpang tidak valid: passenger_name atau saved_passenger_id wajib diisi",
+	"invalid passenger identity: NIK is not valid":                                      "identitas penumpang tidak valid: NIK tidak valid",
+	"invalid passenger identity: NIK must be 16 digits":                                 "identitas penumpang tidak valid: NIK harus 16 digit",
+	"invalid passenger identity: gender does not match the NIK":                         "identitas penumpang tidak valid: jenis kelamin tidak sesuai dengan NIK",
+	"invalid passenger identity: id_type and id_number must be sent together":           "identitas penumpang tidak valid: id_type dan id_number harus dikirim bersamaan",
+	"invalid passenger identity: passport number must be 6 to 9 letters or digits":      "identitas penumpang tidak valid: nomor paspor harus 6 sampai 9 huruf atau angka",
+	"invalid passenger identity: phone must be a valid phone number, e.g. 081234567890": "identitas penumpang tidak valid: nomor telepon harus valid, contoh 081234567890",
+
+	// Routes and schedules
+	"Invalid route ID":                                    "ID rute tidak valid",
+	"route not found":                                     "rute tidak ditemukan",
+	"Route created successfully":                          "Rute berhasil dibuat",
+	"Route retrieved successfully":                        "Rute berhasil diambil",
+	"Route updated successfully":                          "Rute berhasil diperbarui",
+	"Route deleted successfully":                          "Rute berhasil dihapus",
+	"Routes retrieved successfully":                       "Daftar rute berhasil diambil",
+	"Failed to create route":                              "Gagal membuat rute",
+	"Failed to get routes":                                "Gagal mengambil daftar rute",
+	"Failed to update route":                              "Gagal memperbarui rute",
+	"Failed to delete route":                              "Gagal menghapus rute",
+	"Failed to check duplicate route":                     "Gagal memeriksa rute ganda",
+	"Invalid schedule ID":                                 "ID jadwal tidak valid",
+	"Schedule not found":                                  "Jadwal tidak ditemukan",
+	"schedule not found":                                  "jadwal tidak ditemukan",
+	"Schedule created successfully":                       "Jadwal berhasil dibuat",
+	"Schedule retrieved successfully":                     "Jadwal berhasil diambil",
+	"Schedule updated successfully":                       "Jadwal berhasil diperbarui",
+	"Schedule deleted successfully":                       "Jadwal berhasil dihapus",
+	"Schedule cancelled successfully":                     "Jadwal berhasil dibatalkan",
+	"Schedules retrieved successfully":                    "Daftar jadwal berhasil diambil",
+	"No schedules found for the given criteria":           "Tidak ada jadwal yang sesuai dengan kriteria",
+	"Failed to create schedule":                           "Gagal membuat jadwal",
+	"Failed to get schedule":                              "Gagal mengambil jadwal",
+	"Failed to get schedules":                             "Gagal mengambil daftar jadwal",
+	"Failed to update schedule":                           "Gagal memperbarui jadwal",
+	"Failed to delete schedule":                           "Gagal menghapus jadwal",
+	"Failed to cancel schedule":                           "Gagal membatalkan jadwal",
+	"Failed to search schedules":                          "Gagal mencari jadwal",
+	"Failed to stream seats":                              "Gagal mengalirkan data kursi",
+	"schedule has been cancelled":                         "jadwal telah dibatalkan",
+	"schedule is already cancelled":                       "jadwal sudah dibatalkan",
+	"cannot cancel a schedule that has already departed":  "jadwal yang sudah berangkat tidak dapat dibatalkan",
+	"cannot delete schedule with active bookings":         "jadwal yang memiliki pemesanan aktif tidak dapat dihapus",
+	"cannot book past schedule":                           "tidak dapat memesan jadwal yang sudah lewat",
+	"arrival_time must be after departure_time":           "arrival_time harus setelah departure_time",
+	"departure_date cannot be in the past":                "departure_date tidak boleh di masa lalu",
+	"departure_time cannot be in the past":                "departure_time tidak boleh di masa lalu",
+	"invalid arrival_time format, use YYYY-MM-DD HH:mm":   "format arrival_time tidak valid, gunakan YYYY-MM-DD HH:mm",
+	"invalid departure_time format, use YYYY-MM-DD HH:mm": "format departure_time tidak valid, gunakan YYYY-MM-DD HH:mm",
+	"invalid departure_date format, use YYYY-MM-DD":       "format departure_date tidak valid, gunakan YYYY-MM-DD",
+	"price must be greater than 0":                        "harga harus lebih dari 0",
+	"total_seats must be greater than 0":                  "total_seats harus lebih dari 0",
+	"total_seats cannot exceed 50":                        "total_seats tidak boleh lebih dari 50",
+
+	// Bookings and payments
+	"Invalid booking ID":                            "ID pemesanan tidak valid",
+	"booking not found":                             "pemesanan tidak ditemukan",
+	"booking not found or not eligible for payment": "pemesanan tidak ditemukan atau tidak dapat dibayar",
+	"booking has expired":                           "pemesanan sudah kedaluwarsa",
+	"booking is not pending payment":                "pemesanan tidak sedang menunggu pembayaran",
+	"booking is not in waiting verification status": "pemesanan tidak dalam status menunggu verifikasi",
+	"account is not verified: verify your email and phone number before booking":               "akun belum terverifikasi: verifikasi email dan nomor telepon Anda sebelum memesan",
+	"duplicate seat assignment: seat is already assigned to another passenger in this booking": "kursi ganda: kursi sudah diberikan kepada penumpang lain dalam pemesanan ini",
+	"invalid status":                                        "status tidak valid",
+	"Booking created successfully":                          "Pemesanan berhasil dibuat",
+	"Booking retrieved successfully":                        "Pemesanan berhasil diambil",
+	"Bookings retrieved successfully":                       "Daftar pemesanan berhasil diambil",
+	"Booking status updated successfully":                   "Status pemesanan berhasil diperbarui",
+	"Available seats retrieved successfully":                "Kursi tersedia berhasil diambil",
+	"Failed to create booking":                              "Gagal membuat pemesanan",
+	"Failed to get booking":                                 "Gagal mengambil pemesanan",
+	"Failed to get bookings":                                "Gagal mengambil daftar pemesanan",
+	"Failed to update booking status":                       "Gagal memperbarui status pemesanan",
+	"Failed to get available seats":                         "Gagal mengambil kursi tersedia",
+	"failed to retrieve booking":                            "gagal mengambil pemesanan",
+	"Payment proof file is required":                        "File bukti pembayaran wajib diunggah",
+	"File size too large (max 5MB)":                         "Ukuran file terlalu besar (maks 5MB)",
+	"Payment proof uploaded successfully":                   "Bukti pembayaran berhasil diunggah",
+	"Failed to upload payment proof":                        "Gagal mengunggah bukti pembayaran",
+	"payment proof already uploaded":                        "bukti pembayaran sudah diunggah",
+	"Payment proof not found":                               "Bukti pembayaran tidak ditemukan",
+	"Payment proof file not found":                          "File bukti pembayaran tidak ditemukan",
+	"Payment proof file not found on disk":                  "File bukti pembayaran tidak ditemukan di penyimpanan",
+	"failed to check payment proof":                         "gagal memeriksa bukti pembayaran",
+	"failed to check existing payment":                      "gagal memeriksa pembayaran yang ada",
+	"payment not found":                                     "pembayaran tidak ditemukan",
+	"Failed to get payment":                                 "Gagal mengambil pembayaran",
+	"Payment method is required":                            "Metode pembayaran wajib diisi",
+	"invalid payment method":                                "metode pembayaran tidak valid",
+	"Payment recorded successfully":                         "Pembayaran berhasil dicatat",
+	"Failed to record payment":                              "Gagal mencatat pembayaran",
+	"Cash summary retrieved successfully":                   "Rekap kas berhasil diambil",
+	"Failed to get cash summary":                            "Gagal mengambil rekap kas",
+	"Receipt file not found":                                "File struk tidak ditemukan",
+	"Failed to generate receipt":                            "Gagal membuat struk",
+	"receipt can only be generated for successful bookings": "struk hanya dapat dibuat untuk pemesanan yang berhasil",
+
+	// Invoices
+	"Invoice issued successfully":                                    "Faktur berhasil diterbitkan",
+	"Invoice retrieved successfully":                                 "Faktur berhasil diambil",
+	"Invoice file not found":                                         "File faktur tidak ditemukan",
+	"invoice can only be issued for successful bookings":             "faktur hanya dapat diterbitkan untuk pemesanan yang berhasil",
+	"discount cannot exceed the invoice subtotal":                    "diskon tidak boleh melebihi subtotal faktur",
+	"discounts can only be given by staff":                           "diskon hanya dapat diberikan oleh staf",
+	"failed to check existing invoice":                               "gagal memeriksa faktur yang ada",
+	"invoice not found: it has not been issued for this booking yet": "faktur tidak ditemukan: faktur untuk pemesanan ini belum diterbitkan",
+	"failed to retrieve invoice":                                     "gagal mengambil faktur",
+	"invoice has already been issued for this booking":               "faktur untuk pemesanan ini sudah diterbitkan",
+
+	// Reconciliation
+	"Bank statement file is required":             "File mutasi rekening wajib diunggah",
+	"Bank statement imported successfully":        "Mutasi rekening berhasil diimpor",
+	"Failed to import bank statement":             "Gagal mengimpor mutasi rekening",
+	"Invalid import ID":                           "ID impor tidak valid",
+	"import not found":                            "impor tidak ditemukan",
+	"Import retrieved successfully":               "Impor berhasil diambil",
+	"Imports retrieved successfully":              "Daftar impor berhasil diambil",
+	"Failed to get import":                        "Gagal mengambil impor",
+	"Failed to get imports":                       "Gagal mengambil daftar impor",
+	"Invalid entry ID":                            "ID entri tidak valid",
+	"statement entry not found":                   "entri mutasi tidak ditemukan",
+	"statement entry has already been reconciled": "entri mutasi sudah direkonsiliasi",
+	"Statement entries retrieved successfully":    "Daftar entri mutasi berhasil diambil",
+	"Statement entry resolved successfully":       "Entri mutasi berhasil diselesaikan",
+	"Statement entry dismissed successfully":      "Entri mutasi berhasil diabaikan",
+	"Failed to get statement entries":             "Gagal mengambil entri mutasi",
+	"Failed to resolve statement entry":           "Gagal menyelesaikan entri mutasi",
+	"Failed to dismiss statement entry":           "Gagal mengabaikan entri mutasi",
+	"notes are required when the transfer amount does not match the booking amount": "catatan wajib diisi jika jumlah transfer tidak sesuai dengan jumlah pemesanan",
+	"expires_at must be in the future":                                              "expires_at harus di masa depan",
+
+	// Webhooks
+	"Invalid webhook endpoint ID":                                                  "ID endpoint webhook tidak valid",
+	"Invalid delivery ID":                                                          "ID pengiriman tidak valid",
+	"Invalid delivery status":                                                      "Status pengiriman tidak valid",
+	"At least one webhook event is required":                                       "Minimal satu event webhook wajib dipilih",
+	"Webhook URL must be an absolute http or https URL":                            "URL webhook harus berupa URL http atau https yang lengkap",
+	"Webhook URL host cannot be resolved":                                          "host URL webhook tidak dapat ditemukan",
+	"Webhook URL must not point to a private, loopback or link-local address":      "URL webhook tidak boleh mengarah ke alamat privat, loopback, atau link-local",
+	"Webhook endpoint not found":                                                   "Endpoint webhook tidak ditemukan",
+	"Webhook delivery not found":                                                   "Pengiriman webhook tidak ditemukan",
+	"Webhook endpoint created, store the secret now because it is not shown again": "Endpoint webhook dibuat, simpan secret sekarang karena tidak akan ditampilkan lagi",
+	"Webhook secret rotated, store the secret now because it is not shown again":   "Secret webhook diganti, simpan secret sekarang karena tidak akan ditampilkan lagi",
+	"Webhook endpoint retrieved successfully":                                      "Endpoint webhook berhasil diambil",
+	"Webhook endpoints retrieved successfully":                                     "Daftar endpoint webhook berhasil diambil",
+	"Webhook endpoint updated successfully":                                        "Endpoint webhook berhasil diperbarui",
+	"Webhook endpoint deleted successfully":                                        "Endpoint webhook berhasil dihapus",
+	"Webhook events retrieved successfully":                                        "Daftar event webhook berhasil diambil",
+	"Webhook deliveries retrieved successfully":                                    "Daftar pengiriman webhook berhasil diambil",
+	"Webhook delivery retrieved successfully":                                      "Pengiriman webhook berhasil diambil",
+	"Webhook delivery queued for redelivery":                                       "Pengiriman webhook dijadwalkan ulang",
+	"Webhook endpoint is disabled, enable it before redelivering":                  "Endpoint webhook nonaktif, aktifkan sebelum mengirim ulang",
+	"Delivery is already queued":                                                   "Pengiriman sudah dalam antrean",
+	"Failed to create webhook endpoint":                                            "Gagal membuat endpoint webhook",
+	"Failed to create webhook secret":                                              "Gagal membuat secret webhook",
+	"Failed to get webhook endpoint":                                               "Gagal mengambil endpoint webhook",
+	"Failed to get webhook endpoints":                                              "Gagal mengambil daftar endpoint webhook",
+	"Failed to update webhook endpoint":                                            "Gagal memperbarui endpoint webhook",
+	"Failed to delete webhook endpoint":                                            "Gagal menghapus endpoint webhook",
+	"Failed to get webhook deliveries":                                             "Gagal mengambil daftar pengiriman webhook",
+	"Failed to get webhook delivery":                                               "Gagal mengambil pengiriman webhook",
+	"Failed to queue webhook delivery":                                             "Gagal menjadwalkan pengiriman webhook",
+
+	// Notifications
+	"Invalid notification ID":                          "ID notifikasi tidak valid",
+	"Invalid notification status, use read or unread":  "Status notifikasi tidak valid, gunakan read atau unread",
+	"Notification not found":                           "Notifikasi tidak ditemukan",
+	"Notifications retrieved successfully":             "Daftar notifikasi berhasil diambil",
+	"Unread notification count retrieved successfully": "Jumlah notifikasi belum dibaca berhasil diambil",
+	"Notification marked as read":                      "Notifikasi ditandai sudah dibaca",
+	"All notifications marked as read":                 "Semua notifikasi ditandai sudah dibaca",
+	"Failed to get notifications":                      "Gagal mengambil daftar notifikasi",
+	"Failed to count unread notifications":             "Gagal menghitung notifikasi belum dibaca",
+	"Failed to mark notification as read":              "Gagal menandai notifikasi sudah dibaca",
+	"Failed to mark notifications as read":             "Gagal menandai notifikasi sudah dibaca",
+
+	// Boarding
+	"Boarding passes are only available for paid bookings": "Boarding pass hanya tersedia untuk pemesanan yang sudah dibayar",
+	"Failed to generate boarding pass":                     "Gagal membuat boarding pass",
+	"Passenger not found":                                  "Penumpang tidak ditemukan",
+	"Failed to get passenger":                              "Gagal mengambil data penumpang",
+	"Invalid boarding pass":                                "Boarding pass tidak valid",
+	"Failed to verify boarding pass":                       "Gagal memverifikasi boarding pass",
+	"Boarding pass is for another schedule":                "Boarding pass untuk jadwal lain",
+	"Booking is not valid for boarding":                    "Pemesanan tidak berlaku untuk naik kendaraan",
+	"Schedule is cancelled":                                "Jadwal dibatalkan",
+	"Passenger has already boarded":                        "Penumpang sudah naik",
+	"Failed to board passenger":                            "Gagal mencatat penumpang naik",
+	"Passenger boarded successfully":                       "Penumpang berhasil naik",
+	"Passenger has already checked in":                     "Penumpang sudah check-in",
+	"Failed to check in passenger":                         "Gagal mencatat check-in penumpang",
+	"Passenger checked in successfully":                    "Penumpang berhasil check-in",
+	"Failed to update boarding status":                     "Gagal memperbarui status naik",
+	"Boarding status updated successfully":                 "Status naik berhasil diperbarui",
+
+	// No-show
+	"Failed to get no-show statistics":                                                    "Gagal mengambil statistik ketidakhadiran",
+	"No-show statistics retrieved successfully":                                           "Statistik ketidakhadiran berhasil diambil",
+	"booking blocked: too many missed trips, please contact customer service":             "pemesanan diblokir: terlalu banyak perjalanan yang terlewat, silakan hubungi layanan pelanggan",
+	"too many passengers: accounts with recent missed trips have a lower passenger limit": "terlalu banyak penumpang: akun dengan perjalanan terlewat baru-baru ini memiliki batas penumpang lebih rendah",
+
+	// Manifest
+	"Manifest retrieved successfully": "Manifes berhasil diambil",
+	"Failed to get seats":             "Gagal mengambil data kursi",
+	"Failed to get passengers":        "Gagal mengambil daftar penumpang",
+	"Failed to generate manifest":     "Gagal membuat manifes",
+	"PASSENGER MANIFEST":              "MANIFES PENUMPANG",
+	"Schedule ID:":                    "ID Jadwal:",
+	"Pickup Point:":                   "Titik Penjemputan:",
+	"Pickup Point":                    "Titik Penjemputan",
+	"Booking ID":                      "ID Pemesanan",
+	"Payment":                         "Pembayaran",
+	"Payment Status":                  "Status Pembayaran",
+	"Payment Method":                  "Metode Pembayaran",
+	"Boarding":                        "Naik",
+	"Boarding Status":                 "Status Naik",
+	"Boarded At":                      "Waktu Naik",
+	"Boarded":                         "Sudah naik",
+	"Not boarded":                     "Belum naik",
+	"Checked in":                      "Sudah check-in",
+	"No-show":                         "Tidak hadir",
+	"Checked In:":                     "Sudah Check-in:",
+	"No-show:":                        "Tidak Hadir:",
+	"Paid":                            "Lunas",
+	"Failed":                          "Gagal",
+	"No passengers yet":               "Belum ada penumpang",
+	"SEAT SUMMARY":                    "RINGKASAN KURSI",
+	"Total Seats:":                    "Total Kursi:",
+	"Occupied Seats:":                 "Kursi Terisi:",
+	"Boarded:":                        "Sudah Naik:",
+	"Empty Seats:":                    "Kursi Kosong:",
+
+	// Driver trips
+	"Driver not found":                      "Pengemudi tidak ditemukan",
+	"Failed to get driver":                  "Gagal mengambil data pengemudi",
+	"User is not a driver":                  "Pengguna bukan pengemudi",
+	"Failed to assign driver":               "Gagal menugaskan pengemudi",
+	"Driver assigned successfully":          "Pengemudi berhasil ditugaskan",
+	"Invalid trip ID":                       "ID perjalanan tidak valid",
+	"Trip not found":                        "Perjalanan tidak ditemukan",
+	"Failed to get trip":                    "Gagal mengambil data perjalanan",
+	"Failed to get trips":                   "Gagal mengambil daftar perjalanan",
+	"Trip retrieved successfully":           "Perjalanan berhasil diambil",
+	"Trips retrieved successfully":          "Daftar perjalanan berhasil diambil",
+	"Trip has already started":              "Perjalanan sudah dimulai",
+	"Trip has not started yet":              "Perjalanan belum dimulai",
+	"Trip has already ended":                "Perjalanan sudah selesai",
+	"Failed to update trip status":          "Gagal memperbarui status perjalanan",
+	"Trip started successfully":             "Perjalanan berhasil dimulai",
+	"Trip ended successfully":               "Perjalanan berhasil diselesaikan",
+	"Incident time cannot be in the future": "Waktu insiden tidak boleh di masa depan",
+	"Failed to report incident":             "Gagal melaporkan insiden",
+	"Incident reported successfully":        "Insiden berhasil dilaporkan",
+	"Failed to get incidents":               "Gagal mengambil daftar insiden",
+	"Incidents retrieved successfully":      "Daftar insiden berhasil diambil",
+
+	// Booking receipt
+	"Trusted Transportation Service":         "Jasa Transportasi Terpercaya",
+	"BOOKING RECEIPT":                        "BUKTI PEMESANAN",
+	"Booking ID:":                            "ID Pemesanan:",
+	"Booking Date:":                          "Tanggal Pemesanan:",
+	"Status:":                                "Status:",
+	"SCHEDULE DETAILS":                       "DETAIL JADWAL",
+	"Route:":                                 "Rute:",
+	"Departure:":                             "Keberangkatan:",
+	"Arrival:":                               "Kedatangan:",
+	"Duration:":                              "Durasi:",
+	"PASSENGER DETAILS":                      "DETAIL PENUMPANG",
+	"No":                                     "No",
+	"Passenger Name":                         "Nama Penumpang",
+	"Seat":                                   "Kursi",
+	"Price":                                  "Harga",
+	"PAYMENT SUMMARY":                        "RINGKASAN PEMBAYARAN",
+	"Subtotal:":                              "Subtotal:",
+	"Unique Code:":                           "Kode Unik:",
+	"Total Amount:":                          "Total Pembayaran:",
+	"Payment Status:":                        "Status Pembayaran:",
+	"PAID":                                   "LUNAS",
+	"PENDING":                                "BELUM LUNAS",
+	"Thank you for choosing Malaka Shuttle!": "Terima kasih telah memilih Malaka Shuttle!",
+	"Generated on":                           "Dibuat pada",
+	"Success":                                "Berhasil",
+	"Pending":                                "Menunggu Pembayaran",
+	"Waiting Verification":                   "Menunggu Verifikasi",
+	"Rejected":                               "Ditolak",
+	"Expired":                                "Kedaluwarsa",
+	"Cancelled":                              "Dibatalkan",
+	"BOARDING PASS":                          "BOARDING PASS",
+	"Show this code to the driver when boarding.": "Tunjukkan kode ini kepada pengemudi saat naik kendaraan.",
+}
@@ -0,0 +1,34 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9@._-]`)
+
+// FileSender writes messages as .eml files instead of sending them. It is the
+// stand-in for SMTP during local development.
+type FileSender struct {
+	dir  string
+	from string
+}
+
+func NewFileSender(dir, from string) *FileSender {
+	return &FileSender{dir: dir, from: from}
+}
+
+func (s *FileSender) Send(msg Message) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("%s_%s.eml",
+		time.Now().Format("20060102_150405.000000000"),
+		unsafeFileChars.ReplaceAllString(msg.To, "_"),
+	)
+	return os.WriteFile(filepath.Join(s.dir, filename), buildMessage(s.from, msg), 0644)
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"fmt"
+	"malakashuttle/config"
+	"time"
+)
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers email messages
+type Sender interface {
+	Send(msg Message) error
+}
+
+// NewSenderFromConfig creates the sender selected by MAIL_DRIVER
+func NewSenderFromConfig() (Sender, error) {
+	cfg := config.GetMailConfig()
+	switch cfg.Driver {
+	case "smtp":
+		if cfg.Host == "" {
+			return nil, fmt.Errorf("SMTP_HOST is required for the smtp mail driver")
+		}
+		return NewSMTPSender(cfg), nil
+	case "file":
+		return NewFileSender(cfg.FileDir, cfg.From), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", cfg.Driver)
+	}
+}
+
+// buildMessage renders the RFC 5322 representation of a message
+func buildMessage(from string, msg Message) []byte {
+	return []byte(fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		from,
+		msg.To,
+		msg.Subject,
+		time.Now().Format(time.RFC1123Z),
+		msg.Body,
+	))
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"fmt"
+	"malakashuttle/config"
+	"net/mail"
+	"net/smtp"
+)
+
+// SMTPSender sends messages through an SMTP server
+type SMTPSender struct {
+	cfg config.MailConfig
+}
+
+func NewSMTPSender(cfg config.MailConfig) *SMTPSender {
+	return &SMTPSender{cfg: cfg}
+}
+
+func (s *SMTPSender) Send(msg Message) error {
+	from, err := mail.ParseAddress(s.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid MAIL_FROM address: %w", err)
+	}
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
+	return smtp.SendMail(addr, auth, from.Address, []string{msg.To}, buildMessage(s.cfg.From, msg))
+}
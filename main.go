@@ -30,6 +30,10 @@ func main() {
 	}
 
 	router := gin.New()
+	// c.ClientIP() only reads X-Forwarded-For from these proxies
+	if err := router.SetTrustedProxies(config.GetTrustedProxies()); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES: ", err)
+	}
 
 	// Add global middleware
 	router.Use(middleware.RequestIDMiddleware()) // Add request ID to all requests
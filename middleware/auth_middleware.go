@@ -1,15 +1,78 @@
 package middleware
 
 import (
+	"errors"
 	"malakashuttle/utils"
+	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// APIKeyHeader carries partner API keys
+const APIKeyHeader = "X-API-Key"
+
+// PrincipalResolver loads the user behind validated token claims and rejects tokens
+// that were revoked or issued before the user's role, email or password changed
+type PrincipalResolver interface {
+	ResolvePrincipal(claims *utils.JWTClaims) (*utils.Principal, error)
+}
+
+var principalResolver PrincipalResolver
+
+// SetPrincipalResolver registers the resolver AuthMiddleware uses to load the principal
+func SetPrincipalResolver(resolver PrincipalResolver) {
+	principalResolver = resolver
+}
+
+// APIKeyResolver authenticates partner API keys and records their usage
+type APIKeyResolver interface {
+	ResolveAPIKey(key, clientIP string) (*utils.Principal, error)
+	RecordAPIKeyUsage(keyID uint, clientIP string, statusCode int)
+}
+
+var apiKeyResolver APIKeyResolver
+
+// SetAPIKeyResolver registers the resolver AuthMiddleware uses for API keys
+func SetAPIKeyResolver(resolver APIKeyResolver) {
+	apiKeyResolver = resolver
+}
+
+type authOptions struct {
+	acceptAPIKeys       bool
+	allowTwoFactorSetup bool
+}
+
+type AuthOption func(*authOptions)
+
+// AcceptAPIKeys lets partner API keys authenticate next to user tokens
+func AcceptAPIKeys(o *authOptions) {
+	o.acceptAPIKeys = true
+}
+
+// AllowTwoFactorSetup lets users whose role requires two-factor authentication in before they enabled it,
+// for the endpoints that set it up
+func AllowTwoFactorSetup(o *authOptions) {
+	o.allowTwoFactorSetup = true
+}
+
+func AuthMiddleware(options ...AuthOption) gin.HandlerFunc {
+	var opts authOptions
+	for _, option := range options {
+		option(&opts)
+	}
 
 	return func(c *gin.Context) {
+		if apiKey := c.GetHeader(APIKeyHeader); apiKey != "" {
+			if !opts.acceptAPIKeys {
+				utils.Response.BuildErrorResponse(c, utils.NewUnauthorizedError("API keys are not accepted for this endpoint", nil))
+				c.Abort()
+				return
+			}
+			authenticateAPIKey(c, apiKey)
+			return
+		}
 
 		// Membaca header Authorization dari request
 		authHeader := c.GetHeader("Authorization")
@@ -55,8 +118,67 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
+		if principalResolver == nil {
+			utils.Response.BuildErrorResponse(c, utils.NewInternalServerError("Authentication is not configured", nil))
+			c.Abort()
+			return
+		}
+
+		// Muat user dari database sekali per request, token dari sesi yang dicabut
+		// atau versi token yang sudah usang ditolak
+		principal, err := principalResolver.ResolvePrincipal(claims)
+		if err != nil {
+			sessionErr := utils.NewUnauthorizedErrorWithDetails(
+				"Token is no longer valid, please login again",
+				err,
+				map[string]string{
+					"token_error": err.Error(),
+				},
+			)
+			utils.Response.BuildErrorResponse(c, sessionErr)
+			c.Abort()
+			return
+		}
+
+		// Roles that require two-factor authentication can only set it up until it is enabled
+		if principal.TwoFactorSetupRequired && !opts.allowTwoFactorSetup {
+			utils.Response.BuildErrorResponse(c, utils.NewForbiddenError("Two-factor authentication must be enabled for your role", nil))
+			c.Abort()
+			return
+		}
+
+		utils.SetPrincipal(c, principal)
 		c.Next()
 	}
 }
+
+// authenticateAPIKey sets the principal of a partner API key and counts the request in its usage
+func authenticateAPIKey(c *gin.Context, apiKey string) {
+	if apiKeyResolver == nil {
+		utils.Response.BuildErrorResponse(c, utils.NewInternalServerError("API key authentication is not configured", nil))
+		c.Abort()
+		return
+	}
+
+	// ClientIP only honours X-Forwarded-For from TRUSTED_PROXIES, so the allowlist cannot be bypassed with a forged header
+	clientIP := c.ClientIP()
+	principal, err := apiKeyResolver.ResolveAPIKey(apiKey, clientIP)
+	if err != nil {
+		var customErr *utils.CustomError
+		if errors.As(err, &customErr) && customErr.StatusCode == http.StatusTooManyRequests {
+			if details, ok := customErr.Details.(map[string]interface{}); ok {
+				if seconds, ok := details["retry_after_seconds"].(int); ok {
+					c.Header("Retry-After", strconv.Itoa(seconds))
+				}
+			}
+		}
+		utils.Response.BuildErrorResponse(c, err)
+		c.Abort()
+		return
+	}
+
+	utils.SetPrincipal(c, principal)
+	c.Next()
+
+	go apiKeyResolver.RecordAPIKeyUsage(principal.APIKeyID, clientIP, c.Writer.Status())
+}
@@ -8,26 +8,51 @@ import (
 
 // RequireRole creates a middleware that checks if the user has the required role
 func RequireRole(requiredRole string) gin.HandlerFunc {
+	return RequireAnyRole(requiredRole)
+}
+
+// RequireAnyRole creates a middleware that checks if the user has one of the given roles
+func RequireAnyRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user_role from context (set by AuthMiddleware)
-		userRole, exists := c.Get("user_role")
-		if !exists {
-			err := utils.NewUnauthorizedError("User not authenticated", nil)
-			utils.Response.BuildErrorResponse(c, err)
+		principal, ok := authorizedPrincipal(c)
+		if !ok {
+			return
+		}
+
+		// Check if user has one of the required roles
+		if !principal.HasRole(roles...) {
+			forbiddenErr := utils.NewForbiddenErrorWithDetails(
+				"Insufficient permissions",
+				nil,
+				map[string]interface{}{
+					"required_roles": roles,
+					"user_role":      principal.Role,
+				},
+			)
+			utils.Response.BuildErrorResponse(c, forbiddenErr)
 			c.Abort()
 			return
 		}
 
-		// Check if user has the required role
-		if userRole.(string) != requiredRole {
-			userEmail, _ := c.Get("user_email")
+		c.Next()
+	}
+}
+
+// RequirePermission creates a middleware that checks if the user's role grants one of the given permissions
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := authorizedPrincipal(c)
+		if !ok {
+			return
+		}
+
+		if !principal.HasPermission(permissions...) {
 			forbiddenErr := utils.NewForbiddenErrorWithDetails(
 				"Insufficient permissions",
 				nil,
 				map[string]interface{}{
-					"required_role": requiredRole,
-					"user_role":     userRole,
-					"user_email":    userEmail,
+					"required_permissions": permissions,
+					"user_role":            principal.Role,
 				},
 			)
 			utils.Response.BuildErrorResponse(c, forbiddenErr)
@@ -38,3 +63,26 @@ func RequireRole(requiredRole string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// authorizedPrincipal returns the principal set by AuthMiddleware, or aborts the request
+func authorizedPrincipal(c *gin.Context) (*utils.Principal, bool) {
+	// Get principal from context (set by AuthMiddleware)
+	principal, exists := utils.GetPrincipal(c)
+	if !exists {
+		err := utils.NewUnauthorizedError("User not authenticated", nil)
+		utils.Response.BuildErrorResponse(c, err)
+		c.Abort()
+		return nil, false
+	}
+
+	// AuthMiddleware already stops these unless the route sets up two-factor authentication,
+	// which never needs a role or permission
+	if principal.TwoFactorSetupRequired {
+		err := utils.NewForbiddenError("Two-factor authentication must be enabled for your role", nil)
+		utils.Response.BuildErrorResponse(c, err)
+		c.Abort()
+		return nil, false
+	}
+
+	return principal, true
+}
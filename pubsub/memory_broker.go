@@ -0,0 +1,79 @@
+package pubsub
+
+import (
+	"sync"
+)
+
+// MemoryBroker delivers messages to subscribers within the same process. It is the
+// default broker and is only sufficient when a single instance serves the streams.
+type MemoryBroker struct {
+	mu          sync.RWMutex
+	buffer      int
+	subscribers map[string]map[*memorySubscription]struct{}
+}
+
+func NewMemoryBroker(buffer int) *MemoryBroker {
+	return &MemoryBroker{
+		buffer:      buffer,
+		subscribers: make(map[string]map[*memorySubscription]struct{}),
+	}
+}
+
+// Publish never blocks: a subscriber whose buffer is full misses the message
+func (b *MemoryBroker) Publish(topic string, payload []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers[topic] {
+		select {
+		case sub.messages <- payload:
+		default:
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(topic string) (Subscription, error) {
+	sub := &memorySubscription{
+		broker:   b,
+		topic:    topic,
+		messages: make(chan []byte, b.buffer),
+	}
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[*memorySubscription]struct{})
+	}
+	b.subscribers[topic][sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub, nil
+}
+
+func (b *MemoryBroker) unsubscribe(sub *memorySubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subscribers[sub.topic], sub)
+	if len(b.subscribers[sub.topic]) == 0 {
+		delete(b.subscribers, sub.topic)
+	}
+	close(sub.messages)
+}
+
+type memorySubscription struct {
+	broker   *MemoryBroker
+	topic    string
+	messages chan []byte
+	once     sync.Once
+}
+
+func (s *memorySubscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func (s *memorySubscription) Close() {
+	s.once.Do(func() {
+		s.broker.unsubscribe(s)
+	})
+}
@@ -0,0 +1,30 @@
+package pubsub
+
+import (
+	"fmt"
+	"malakashuttle/config"
+)
+
+// Subscription receives the messages published to a topic until it is closed
+type Subscription interface {
+	Messages() <-chan []byte
+	Close()
+}
+
+// Broker fans out messages to every subscriber of a topic, including subscribers
+// connected to other instances when the broker is shared between them
+type Broker interface {
+	Publish(topic string, payload []byte) error
+	Subscribe(topic string) (Subscription, error)
+}
+
+// NewBrokerFromConfig creates the broker selected by PUBSUB_DRIVER
+func NewBrokerFromConfig() (Broker, error) {
+	cfg := config.GetRealtimeConfig()
+	switch cfg.PubSubDriver {
+	case "memory":
+		return NewMemoryBroker(cfg.SubscriberBuffer), nil
+	default:
+		return nil, fmt.Errorf("unsupported pubsub driver: %s", cfg.PubSubDriver)
+	}
+}
@@ -0,0 +1,491 @@
+// Package qrcode encodes short texts like signed tickets as QR codes. It only implements
+// what the tickets need: byte mode, error correction level M and versions 1 to 10.
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// ErrTooLong is returned when the content does not fit in the largest supported version
+var ErrTooLong = errors.New("qrcode: content too long")
+
+// quietZone is the light border around the symbol scanners need, in modules
+const quietZone = 4
+
+// Block structure of error correction level M per version, index 0 is version 1
+var (
+	totalCodewords   = [...]int{26, 44, 70, 100, 134, 172, 196, 242, 292, 346}
+	eccPerBlock      = [...]int{10, 16, 26, 18, 24, 16, 18, 22, 22, 26}
+	numBlocks        = [...]int{1, 1, 1, 2, 2, 4, 4, 4, 5, 5}
+	alignmentCenters = [...][]int{
+		{},
+		{6, 18},
+		{6, 22},
+		{6, 26},
+		{6, 30},
+		{6, 34},
+		{6, 22, 38},
+		{6, 24, 42},
+		{6, 26, 46},
+		{6, 28, 50},
+	}
+)
+
+// Code is an encoded QR symbol
+type Code struct {
+	version  int
+	size     int
+	modules  [][]bool
+	function [][]bool
+}
+
+// Encode encodes the content in the smallest version it fits in
+func Encode(content string) (*Code, error) {
+	data := []byte(content)
+
+	version := 0
+	for v := 1; v <= len(totalCodewords); v++ {
+		if 4+countBits(v)+len(data)*8 <= dataCodewords(v)*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	c := &Code{version: version, size: version*4 + 17}
+	c.modules = newGrid(c.size)
+	c.function = newGrid(c.size)
+
+	c.drawFunctionPatterns()
+	c.drawCodewords(addErrorCorrection(version, encodeData(version, data)))
+
+	// Use the mask that leaves the fewest patterns that confuse scanners
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask) // masking twice undoes it
+	}
+	c.applyMask(best)
+	c.drawFormatBits(best)
+
+	return c, nil
+}
+
+// Size returns the number of modules per side, without the quiet zone
+func (c *Code) Size() int {
+	return c.size
+}
+
+// Dark reports whether the module in column x and row y is dark
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y][x]
+}
+
+// PNG renders the symbol with its quiet zone, every module is scale pixels wide
+func (c *Code) PNG(scale int) ([]byte, error) {
+	if scale < 1 {
+		scale = 1
+	}
+	width := (c.size + 2*quietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, width, width))
+	for y := 0; y < width; y++ {
+		for x := 0; x < width; x++ {
+			mx, my := x/scale-quietZone, y/scale-quietZone
+			if mx >= 0 && my >= 0 && mx < c.size && my < c.size && c.modules[my][mx] {
+				img.SetGray(x, y, color.Gray{Y: 0})
+			} else {
+				img.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+// countBits is the length of the character count in byte mode
+func countBits(version int) int {
+	if version < 10 {
+		return 8
+	}
+	return 16
+}
+
+func dataCodewords(version int) int {
+	return totalCodewords[version-1] - eccPerBlock[version-1]*numBlocks[version-1]
+}
+
+// encodeData builds the data codewords: mode, length, content, terminator and padding
+func encodeData(version int, data []byte) []byte {
+	capacity := dataCodewords(version) * 8
+	var bits bitBuffer
+	bits.append(0x4, 4) // byte mode
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	terminator := capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	return bits.bytes()
+}
+
+// addErrorCorrection splits the data in blocks, adds the error correction codewords of
+// every block and interleaves the result
+func addErrorCorrection(version int, data []byte) []byte {
+	blocks := numBlocks[version-1]
+	eccLen := eccPerBlock[version-1]
+	shortLen := totalCodewords[version-1]/blocks - eccLen
+	numShort := blocks - totalCodewords[version-1]%blocks
+	divisor := rsDivisor(eccLen)
+
+	dataBlocks := make([][]byte, blocks)
+	eccBlocks := make([][]byte, blocks)
+	offset := 0
+	for i := 0; i < blocks; i++ {
+		length := shortLen
+		if i >= numShort {
+			length++
+		}
+		dataBlocks[i] = data[offset : offset+length]
+		eccBlocks[i] = rsRemainder(dataBlocks[i], divisor)
+		offset += length
+	}
+
+	result := make([]byte, 0, totalCodewords[version-1])
+	for i := 0; i <= shortLen; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < eccLen; i++ {
+		for _, block := range eccBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.function[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := 0; i < c.size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(c.size-4, 3)
+	c.drawFinder(3, c.size-4)
+
+	centers := alignmentCenters[c.version-1]
+	last := len(centers) - 1
+	for i, x := range centers {
+		for j, y := range centers {
+			// Skip the corners taken by the finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas, the bits are drawn once the mask is known
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+// drawFinder draws a finder pattern with its separator around the center
+func (c *Code) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= c.size || y >= c.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the error correction level (M) and mask
+func (c *Code) drawFormatBits(mask int) {
+	const levelM = 0
+	data := levelM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		c.setFunction(8, i, bit(bits, i))
+	}
+	c.setFunction(8, 7, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(c.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.size-15+i, bit(bits, i))
+	}
+	c.setFunction(8, c.size-8, true) // always dark
+}
+
+// drawVersion draws both copies of the version from version 7 on
+func (c *Code) drawVersion() {
+	if c.version < 7 {
+		return
+	}
+	rem := c.version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		a, b := c.size-11+i%3, i/3
+		c.setFunction(a, b, bit(bits, i))
+		c.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// drawCodewords places the codewords in the zigzag order, two columns at a time from the right
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := 0; vert < c.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = c.size - 1 - vert
+				}
+				if !c.function[y][x] && i < len(codewords)*8 {
+					c.modules[y][x] = bit(int(codewords[i>>3]), 7-i&7)
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if c.function[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol with the four rules of the specification, lower is better
+func (c *Code) penalty() int {
+	score := 0
+	finderLike := [][]bool{
+		{true, false, true, true, true, false, true, false, false, false, false},
+		{false, false, false, false, true, false, true, true, true, false, true},
+	}
+
+	for _, vertical := range []bool{false, true} {
+		at := func(i, j int) bool {
+			if vertical {
+				return c.modules[j][i]
+			}
+			return c.modules[i][j]
+		}
+		for i := 0; i < c.size; i++ {
+			// Runs of five or more modules of the same color
+			run := 1
+			for j := 1; j < c.size; j++ {
+				if at(i, j) == at(i, j-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					score += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				score += run - 2
+			}
+
+			// Patterns that look like a finder pattern
+			for j := 0; j+11 <= c.size; j++ {
+				for _, pattern := range finderLike {
+					matches := true
+					for k, dark := range pattern {
+						if at(i, j+k) != dark {
+							matches = false
+							break
+						}
+					}
+					if matches {
+						score += 40
+					}
+				}
+			}
+		}
+	}
+
+	// 2x2 blocks of the same color
+	dark := 0
+	for y := 0; y < c.size; y++ {
+		for x := 0; x < c.size; x++ {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < c.size && y+1 < c.size {
+				same := c.modules[y][x]
+				if c.modules[y][x+1] == same && c.modules[y+1][x] == same && c.modules[y+1][x+1] == same {
+					score += 3
+				}
+			}
+		}
+	}
+
+	// Balance of dark and light modules
+	percent := dark * 100 / (c.size * c.size)
+	score += abs(percent-50) / 5 * 10
+	return score
+}
+
+// rsDivisor returns the generator polynomial of the given degree, without its leading term
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < degree {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder returns the Reed-Solomon error correction codewords of the data
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= gfMultiply(coef, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, set := range b {
+		if set {
+			result[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return result
+}
+
+func bit(value, i int) bool {
+	return (value>>i)&1 != 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
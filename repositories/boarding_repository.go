@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BoardingRepository reads and records the boarding of passengers
+type BoardingRepository interface {
+	FindPassenger(id uint) (*entities.BookingDetail, error)
+	FindPaidPassengers(scheduleID uint) ([]entities.BookingDetail, error)
+	MarkCheckedIn(id uint, checkedInAt time.Time) (bool, error)
+	MarkBoarded(id, boardedByID uint, boardedAt time.Time) (bool, error)
+	SetBoardingStatus(id uint, status entities.BoardingStatus, changedByID uint, at time.Time) error
+	MarkNoShows(departedFrom, departedBefore, at time.Time) (int64, error)
+	GetNoShowStats(userID uint) (*NoShowStats, error)
+	FindNoShowTimes(userID uint, since time.Time) ([]time.Time, error)
+}
+
+// NoShowStats counts the passengers of a user's paid bookings by boarding state
+type NoShowStats struct {
+	Passengers   int64
+	CheckedIn    int64
+	Boarded      int64
+	NoShows      int64
+	LastNoShowAt *time.Time
+}
+
+type boardingRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardingRepository(db *gorm.DB) BoardingRepository {
+	return &boardingRepository{db: db}
+}
+
+// FindPassenger returns a booking detail with its booking, schedule and seat
+func (r *boardingRepository) FindPassenger(id uint) (*entities.BookingDetail, error) {
+	var detail entities.BookingDetail
+	err := r.db.Preload("Booking").
+		Preload("Booking.Schedule").
+		Preload("Seat").
+		First(&detail, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+// FindPaidPassengers returns the passengers of the successful bookings of a schedule in seat order,
+// with their booking, payment and seat
+func (r *boardingRepository) FindPaidPassengers(scheduleID uint) ([]entities.BookingDetail, error) {
+	var details []entities.BookingDetail
+	err := r.db.Joins("JOIN bookings ON bookings.id = booking_details.booking_id AND bookings.deleted_at IS NULL").
+		Joins("JOIN seats ON seats.id = booking_details.seat_id").
+		Where("bookings.schedule_id = ? AND bookings.status = ?", scheduleID, entities.BookingStatusSuccess).
+		Preload("Booking").
+		Preload("Booking.Payment").
+		Preload("Seat").
+		// Seats are created in seat order, sorting the numbers as text would put seat 10 before seat 2
+		Order("seats.id ASC").
+		Find(&details).Error
+	return details, err
+}
+
+// MarkCheckedIn checks a passenger in. It returns false when the passenger had already checked in or boarded.
+func (r *boardingRepository) MarkCheckedIn(id uint, checkedInAt time.Time) (bool, error) {
+	result := r.db.Model(&entities.BookingDetail{}).
+		Where("id = ? AND checked_in_at IS NULL AND boarded_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"checked_in_at": checkedInAt,
+			"no_show_at":    nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// MarkBoarded boards a passenger. It returns false when the passenger had already boarded,
+// so two scans of the same code at the same time cannot both succeed.
+func (r *boardingRepository) MarkBoarded(id, boardedByID uint, boardedAt time.Time) (bool, error) {
+	result := r.db.Model(&entities.BookingDetail{}).
+		Where("id = ? AND boarded_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"checked_in_at": gorm.Expr("COALESCE(checked_in_at, ?)", boardedAt),
+			"boarded_at":    boardedAt,
+			"boarded_by_id": boardedByID,
+			"no_show_at":    nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// SetBoardingStatus overrides the boarding state of a passenger, clearing the timestamps of later states
+func (r *boardingRepository) SetBoardingStatus(id uint, status entities.BoardingStatus, changedByID uint, at time.Time) error {
+	updates := map[string]interface{}{
+		"checked_in_at": nil,
+		"boarded_at":    nil,
+		"boarded_by_id": nil,
+		"no_show_at":    nil,
+	}
+	switch status {
+	case entities.BoardingStatusCheckedIn:
+		updates["checked_in_at"] = gorm.Expr("COALESCE(checked_in_at, ?)", at)
+	case entities.BoardingStatusBoarded:
+		updates["checked_in_at"] = gorm.Expr("COALESCE(checked_in_at, ?)", at)
+		updates["boarded_at"] = gorm.Expr("COALESCE(boarded_at, ?)", at)
+		updates["boarded_by_id"] = changedByID
+	case entities.BoardingStatusNoShow:
+		updates["no_show_at"] = at
+	}
+	return r.db.Model(&entities.BookingDetail{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// MarkNoShows marks the passengers who did not board as no-show on the paid bookings of schedules that
+// departed in the given range. Schedules where nobody checked in or boarded are skipped, since boarding
+// was evidently not recorded for them.
+func (r *boardingRepository) MarkNoShows(departedFrom, departedBefore, at time.Time) (int64, error) {
+	// The schedules are looked up first as MySQL cannot read booking_details in a subquery of its own update
+	var scheduleIDs []uint
+	err := r.db.Model(&entities.Schedule{}).
+		Where("departure_time >= ? AND departure_time < ? AND cancelled_at IS NULL", departedFrom, departedBefore).
+		Where("EXISTS (?)", r.db.Table("booking_details").
+			Select("1").
+			Joins("JOIN bookings ON bookings.id = booking_details.booking_id").
+			Where("bookings.schedule_id = schedules.id AND booking_details.deleted_at IS NULL").
+			Where("booking_details.boarded_at IS NOT NULL OR booking_details.checked_in_at IS NOT NULL")).
+		Pluck("id", &scheduleIDs).Error
+	if err != nil || len(scheduleIDs) == 0 {
+		return 0, err
+	}
+
+	result := r.db.Model(&entities.BookingDetail{}).
+		Where("boarded_at IS NULL AND no_show_at IS NULL").
+		Where("booking_id IN (?)", r.db.Model(&entities.Booking{}).
+			Select("id").
+			Where("schedule_id IN ? AND status = ?", scheduleIDs, entities.BookingStatusSuccess)).
+		Update("no_show_at", at)
+	return result.RowsAffected, result.Error
+}
+
+// GetNoShowStats counts the passengers of the paid bookings of a user by boarding state
+func (r *boardingRepository) GetNoShowStats(userID uint) (*NoShowStats, error) {
+	var stats NoShowStats
+	err := r.db.Model(&entities.BookingDetail{}).
+		Select("COUNT(*) AS passengers, "+
+			"COUNT(booking_details.checked_in_at) AS checked_in, "+
+			"COUNT(booking_details.boarded_at) AS boarded, "+
+			"COUNT(booking_details.no_show_at) AS no_shows, "+
+			"MAX(booking_details.no_show_at) AS last_no_show_at").
+		Joins("JOIN bookings ON bookings.id = booking_details.booking_id AND bookings.deleted_at IS NULL").
+		Where("bookings.user_id = ? AND bookings.status = ?", userID, entities.BookingStatusSuccess).
+		Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// FindNoShowTimes returns when each of the user's bookings since the given time was missed, newest first.
+// A booking missed by several passengers counts once.
+func (r *boardingRepository) FindNoShowTimes(userID uint, since time.Time) ([]time.Time, error) {
+	var times []time.Time
+	err := r.db.Model(&entities.BookingDetail{}).
+		Joins("JOIN bookings ON bookings.id = booking_details.booking_id AND bookings.deleted_at IS NULL").
+		Where("bookings.user_id = ? AND booking_details.no_show_at >= ?", userID, since).
+		Group("booking_details.booking_id").
+		Order("MAX(booking_details.no_show_at) DESC").
+		Pluck("MAX(booking_details.no_show_at)", &times).Error
+	return times, err
+}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"errors"
+	"math"
+	"math/rand"
 	"time"
 
 	"malakashuttle/entities"
@@ -17,8 +19,9 @@ func NewBookingRepository(db *gorm.DB) *BookingRepository {
 	return &BookingRepository{db: db}
 }
 
-// CreateBooking creates a new booking with booking details in a transaction
-func (r *BookingRepository) CreateBooking(booking *entities.Booking, bookingDetails []entities.BookingDetail) error {
+// CreateBooking creates a new booking with booking details in a transaction.
+// A unique code (1..maxUniqueCode) is added to booking.PaymentAmount so the transfer can be identified.
+func (r *BookingRepository) CreateBooking(booking *entities.Booking, bookingDetails []entities.BookingDetail, maxUniqueCode int, outbox OutboxBuilder) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Check if any seats are already booked for this schedule
 		var existingDetails []entities.BookingDetail
@@ -68,8 +71,8 @@ func (r *BookingRepository) CreateBooking(booking *entities.Booking, bookingDeta
 			return errors.New("one or more seats are already booked")
 		}
 
-		// Create booking
-		if err := tx.Create(booking).Error; err != nil {
+		// Create booking with a unique payment code among open bookings
+		if err := createWithUniqueCode(tx, booking, maxUniqueCode); err != nil {
 			return err
 		}
 
@@ -86,10 +89,72 @@ func (r *BookingRepository) CreateBooking(booking *entities.Booking, bookingDeta
 			return err
 		}
 
-		return nil
+		return writeBookingOutbox(tx, outbox, booking.ID)
 	})
 }
 
+// uniqueCodeAttempts is how often a booking is retried when a concurrent booking took the same code
+const uniqueCodeAttempts = 5
+
+// createWithUniqueCode creates the booking with a random code that is not used by any open booking and
+// whose resulting transfer amount doesn't share its last 3 digits with another open booking. The codes in
+// use are read without locking, the unique indexes on the open code and amount suffix reject a code that a
+// concurrent booking took in the meantime, in which case another code is tried.
+func createWithUniqueCode(tx *gorm.DB, booking *entities.Booking, maxUniqueCode int) error {
+	var openBookings []entities.Booking
+	err := tx.Select("open_unique_code", "open_amount_suffix").
+		Where("open_unique_code IS NOT NULL").
+		Find(&openBookings).Error
+	if err != nil {
+		return err
+	}
+
+	usedCodes := make(map[int]bool)
+	usedSuffixes := make(map[int64]bool)
+	for _, open := range openBookings {
+		if open.OpenUniqueCode != nil {
+			usedCodes[*open.OpenUniqueCode] = true
+		}
+		if open.OpenAmountSuffix != nil {
+			usedSuffixes[int64(*open.OpenAmountSuffix)] = true
+		}
+	}
+
+	baseAmount := booking.PaymentAmount
+	attempts := 0
+	for _, code := range rand.Perm(maxUniqueCode) {
+		code++ // codes start at 1
+		if usedCodes[code] || usedSuffixes[amountSuffix(baseAmount+float64(code))] {
+			continue
+		}
+		booking.UniqueCode = code
+		booking.PaymentAmount = baseAmount + float64(code)
+
+		err := tx.Create(booking).Error
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return err
+		}
+		// A concurrent booking took the code or amount, only this statement was rolled back
+		booking.ID = 0
+		attempts++
+		if attempts == uniqueCodeAttempts {
+			break
+		}
+	}
+
+	booking.PaymentAmount = baseAmount
+	booking.UniqueCode = 0
+	return errors.New("no unique payment code available, please try again later")
+}
+
+// amountSuffix returns the last 3 digits of a rupiah amount
+func amountSuffix(amount float64) int64 {
+	return int64(math.Round(amount)) % 1000
+}
+
 // GetBookingByID retrieves a booking by ID with all relations
 func (r *BookingRepository) GetBookingByID(id uint, userID *uint) (*entities.Booking, error) {
 	var booking entities.Booking
@@ -180,12 +245,95 @@ func (r *BookingRepository) UpdateBookingStatus(id uint, status entities.Booking
 	return r.db.Model(&entities.Booking{}).Where("id = ?", id).Update("status", status).Error
 }
 
-// ExpireBookings updates status of bookings that have expired
-func (r *BookingRepository) ExpireBookings() error {
+// TxFunc writes records that belong to a booking change within the transaction of the change
+type TxFunc func(tx *gorm.DB) error
+
+// VerifyBooking applies a verification decision to a booking in a single transaction.
+// Approving marks the payment as successful, rejecting marks it failed and frees the seats.
+// record (optional) stores what led to the decision, e.g. the matching bank statement entry.
+func (r *BookingRepository) VerifyBooking(id uint, status entities.BookingStatus, outbox OutboxBuilder, record TxFunc) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Get expired bookings with their seat IDs
+		// Only bookings still waiting for verification can be approved or rejected
+		result := tx.Model(&entities.Booking{}).
+			Where("id = ? AND status = ?", id, entities.BookingStatusWaitingVerification).
+			Update("status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("booking is not in waiting verification status")
+		}
+
+		paymentUpdates := map[string]interface{}{"payment_status": entities.PaymentStatusFailed}
+		if status == entities.BookingStatusSuccess {
+			paymentUpdates = map[string]interface{}{
+				"payment_status": entities.PaymentStatusSuccess,
+				"payment_date":   time.Now(),
+			}
+		}
+		if err := tx.Model(&entities.Payment{}).Where("booking_id = ?", id).Updates(paymentUpdates).Error; err != nil {
+			return err
+		}
+
+		// Queue the notifications while the seats are still attached to the booking
+		if err := writeBookingOutbox(tx, outbox, id); err != nil {
+			return err
+		}
+
+		// The payment is handled, so the staff alerts about its proof are done
+		if err := tx.Model(&entities.Notification{}).
+			Where("booking_id = ? AND event = ? AND read_at IS NULL", id, entities.NotificationEventPaymentProofUploaded).
+			Update("read_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		if record != nil {
+			if err := record(tx); err != nil {
+				return err
+			}
+		}
+
+		if status == entities.BookingStatusRejected {
+			return freeSeatsByBookingID(tx, id)
+		}
+		return nil
+	})
+}
+
+// FindWaitingVerificationByAmount finds bookings waiting for verification with the exact payment amount
+// that were made within the given time range
+func (r *BookingRepository) FindWaitingVerificationByAmount(amount float64, from, to time.Time) ([]entities.Booking, error) {
+	var bookings []entities.Booking
+	err := r.db.Preload("Payment").
+		Where("status = ? AND payment_amount = ? AND booking_time BETWEEN ? AND ?",
+			entities.BookingStatusWaitingVerification, amount, from, to).
+		Order("booking_time ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// FindWaitingVerificationBySuffix finds bookings waiting for verification whose payment amount ends
+// with the given 3 digit suffix (the unique code part of the transfer) within the given time range
+func (r *BookingRepository) FindWaitingVerificationBySuffix(suffix int64, from, to time.Time) ([]entities.Booking, error) {
+	var bookings []entities.Booking
+	err := r.db.Preload("Payment").
+		Where("status = ? AND MOD(ROUND(payment_amount), 1000) = ? AND booking_time BETWEEN ? AND ?",
+			entities.BookingStatusWaitingVerification, suffix, from, to).
+		Order("booking_time ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// ExpireBookings updates status of bookings that have expired.
+// Returns the IDs of the schedules whose seats were freed.
+func (r *BookingRepository) ExpireBookings(outbox OutboxBuilder) ([]uint, error) {
+	var scheduleIDs []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Get expired bookings with their seats and what the notifications need
 		var expiredBookings []entities.Booking
-		err := tx.Preload("BookingDetails").
+		err := tx.Preload("BookingDetails.Seat").
+			Preload("User").
+			Preload("Schedule.Route").
 			Where("expires_at <= ? AND status = ?", time.Now(), entities.BookingStatusPending).
 			Find(&expiredBookings).Error
 		if err != nil {
@@ -201,6 +349,7 @@ func (r *BookingRepository) ExpireBookings() error {
 		var bookingIDs []uint
 		for _, booking := range expiredBookings {
 			bookingIDs = append(bookingIDs, booking.ID)
+			scheduleIDs = append(scheduleIDs, booking.ScheduleID)
 			for _, detail := range booking.BookingDetails {
 				seatIDs = append(seatIDs, detail.SeatID)
 			}
@@ -211,6 +360,13 @@ func (r *BookingRepository) ExpireBookings() error {
 			return err
 		}
 
+		for i := range expiredBookings {
+			expiredBookings[i].Status = entities.BookingStatusExpired
+			if err := writeOutbox(tx, outbox, &expiredBookings[i]); err != nil {
+				return err
+			}
+		}
+
 		// Soft delete booking details to free up unique constraint
 		if err := tx.Where("booking_id IN ?", bookingIDs).Delete(&entities.BookingDetail{}).Error; err != nil {
 			return err
@@ -226,6 +382,10 @@ func (r *BookingRepository) ExpireBookings() error {
 
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	return scheduleIDs, nil
 }
 
 // GetBookingForPayment gets booking for payment (only pending bookings)
@@ -240,7 +400,7 @@ func (r *BookingRepository) GetBookingForPayment(id uint, userID uint) (*entitie
 }
 
 // CreatePayment creates payment record
-func (r *BookingRepository) CreatePayment(payment *entities.Payment) error {
+func (r *BookingRepository) CreatePayment(payment *entities.Payment, outbox OutboxBuilder) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Create payment
 		if err := tx.Create(payment).Error; err != nil {
@@ -253,10 +413,47 @@ func (r *BookingRepository) CreatePayment(payment *entities.Payment) error {
 			return err
 		}
 
-		return nil
+		return writeBookingOutbox(tx, outbox, payment.BookingID)
 	})
 }
 
+// CreateCounterPayment records a payment taken at the counter and confirms the pending booking
+func (r *BookingRepository) CreateCounterPayment(payment *entities.Payment, outbox OutboxBuilder) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Confirm the booking only if it is still pending
+		result := tx.Model(&entities.Booking{}).
+			Where("id = ? AND status = ?", payment.BookingID, entities.BookingStatusPending).
+			Update("status", entities.BookingStatusSuccess)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("booking is not pending payment")
+		}
+
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+
+		return writeBookingOutbox(tx, outbox, payment.BookingID)
+	})
+}
+
+// GetCounterPayments gets payments recorded at the counter within a time range, optionally for one cashier
+func (r *BookingRepository) GetCounterPayments(cashierID *uint, from, to time.Time) ([]entities.Payment, error) {
+	var payments []entities.Payment
+	query := r.db.Preload("Cashier").
+		Preload("Booking").
+		Where("cashier_id IS NOT NULL AND payment_date BETWEEN ? AND ?", from, to)
+
+	if cashierID != nil {
+		query = query.Where("cashier_id = ?", *cashierID)
+	}
+
+	err := query.Order("cashier_id ASC, payment_date ASC").Find(&payments).Error
+	return payments, err
+}
+
 // GetPaymentByBookingID gets payment by booking ID
 func (r *BookingRepository) GetPaymentByBookingID(bookingID uint) (*entities.Payment, error) {
 	var payment entities.Payment
@@ -267,6 +464,16 @@ func (r *BookingRepository) GetPaymentByBookingID(bookingID uint) (*entities.Pay
 	return &payment, nil
 }
 
+// FindPaymentByProofHash finds a payment whose proof file has the given SHA-256 hash
+func (r *BookingRepository) FindPaymentByProofHash(hash string) (*entities.Payment, error) {
+	var payment entities.Payment
+	err := r.db.Where("proof_sha256 = ?", hash).First(&payment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
 // GetAvailableSeats gets all seats for a schedule with their booking status
 func (r *BookingRepository) GetAvailableSeats(scheduleID uint) ([]entities.Seat, error) {
 	var seats []entities.Seat
@@ -277,34 +484,39 @@ func (r *BookingRepository) GetAvailableSeats(scheduleID uint) ([]entities.Seat,
 // FreeSeatsByBookingID frees seats when booking is rejected/cancelled
 func (r *BookingRepository) FreeSeatsByBookingID(bookingID uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
-		// Get seat IDs from booking details
-		var bookingDetails []entities.BookingDetail
-		err := tx.Where("booking_id = ?", bookingID).Find(&bookingDetails).Error
-		if err != nil {
-			return err
-		}
+		return freeSeatsByBookingID(tx, bookingID)
+	})
+}
 
-		// Extract seat IDs
-		var seatIDs []uint
-		for _, detail := range bookingDetails {
-			seatIDs = append(seatIDs, detail.SeatID)
-		}
+// freeSeatsByBookingID soft deletes the booking details and frees their seats within a transaction
+func freeSeatsByBookingID(tx *gorm.DB, bookingID uint) error {
+	// Get seat IDs from booking details
+	var bookingDetails []entities.BookingDetail
+	err := tx.Where("booking_id = ?", bookingID).Find(&bookingDetails).Error
+	if err != nil {
+		return err
+	}
 
-		// Soft delete booking details (this will free up the unique constraint)
-		if err := tx.Where("booking_id = ?", bookingID).Delete(&entities.BookingDetail{}).Error; err != nil {
-			return err
-		}
+	// Extract seat IDs
+	var seatIDs []uint
+	for _, detail := range bookingDetails {
+		seatIDs = append(seatIDs, detail.SeatID)
+	}
 
-		// Free the seats
-		if len(seatIDs) > 0 {
-			err = tx.Model(&entities.Seat{}).Where("id IN ?", seatIDs).Update("is_booked", false).Error
-			if err != nil {
-				return err
-			}
+	// Soft delete booking details (this will free up the unique constraint)
+	if err := tx.Where("booking_id = ?", bookingID).Delete(&entities.BookingDetail{}).Error; err != nil {
+		return err
+	}
+
+	// Free the seats
+	if len(seatIDs) > 0 {
+		err = tx.Model(&entities.Seat{}).Where("id IN ?", seatIDs).Update("is_booked", false).Error
+		if err != nil {
+			return err
 		}
+	}
 
-		return nil
-	})
+	return nil
 }
 
 // ValidateSeatsForSchedule validates that all seat IDs belong to the specified schedule
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InboxRepository reads and updates the in-app notifications of a user. The notifications
+// themselves are written through the outbox of the state change they announce.
+type InboxRepository interface {
+	GetNotifications(userID uint, page, limit int, unread *bool) ([]entities.Notification, int64, error)
+	CountUnread(userID uint) (int64, error)
+	MarkRead(id, userID uint, readAt time.Time) (*entities.Notification, error)
+	MarkAllRead(userID uint, readAt time.Time) (int64, error)
+}
+
+type inboxRepository struct {
+	db *gorm.DB
+}
+
+func NewInboxRepository(db *gorm.DB) InboxRepository {
+	return &inboxRepository{db: db}
+}
+
+// GetNotifications lists the notifications of a user, newest first. unread filters on the read state.
+func (r *inboxRepository) GetNotifications(userID uint, page, limit int, unread *bool) ([]entities.Notification, int64, error) {
+	query := r.db.Model(&entities.Notification{}).Where("user_id = ?", userID)
+	if unread != nil {
+		if *unread {
+			query = query.Where("read_at IS NULL")
+		} else {
+			query = query.Where("read_at IS NOT NULL")
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []entities.Notification
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (r *inboxRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks a notification of the user as read. Notifications that are already read keep
+// their original read time.
+func (r *inboxRepository) MarkRead(id, userID uint, readAt time.Time) (*entities.Notification, error) {
+	err := r.db.Model(&entities.Notification{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", id, userID).
+		Update("read_at", readAt).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var notification entities.Notification
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// MarkAllRead marks every unread notification of the user as read and returns how many were updated
+func (r *inboxRepository) MarkAllRead(userID uint, readAt time.Time) (int64, error) {
+	result := r.db.Model(&entities.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
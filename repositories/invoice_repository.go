@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"fmt"
+
+	"malakashuttle/entities"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvoiceRepository struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) *InvoiceRepository {
+	return &InvoiceRepository{db: db}
+}
+
+// GetInvoiceByBookingID retrieves the invoice of a booking with its line items
+func (r *InvoiceRepository) GetInvoiceByBookingID(bookingID uint) (*entities.Invoice, error) {
+	var invoice entities.Invoice
+	err := r.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Where("booking_id = ?", bookingID).First(&invoice).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// CreateInvoice assigns the next sequential invoice number of the issue month and creates
+// the invoice with its items in a transaction
+func (r *InvoiceRepository) CreateInvoice(invoice *entities.Invoice) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		period := invoice.IssuedAt.Format("200601")
+
+		// Make sure the sequence row exists, then lock it to get the next number
+		sequence := entities.InvoiceSequence{Period: period}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&sequence).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("period = ?", period).
+			First(&sequence).Error; err != nil {
+			return err
+		}
+
+		sequence.LastNumber++
+		if err := tx.Model(&sequence).Update("last_number", sequence.LastNumber).Error; err != nil {
+			return err
+		}
+
+		invoice.InvoiceNumber = fmt.Sprintf("INV/%s/%04d", period, sequence.LastNumber)
+		return tx.Create(invoice).Error
+	})
+}
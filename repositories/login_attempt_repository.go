@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"errors"
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginAttemptStore keeps login throttling state shared by all replicas
+type LoginAttemptStore interface {
+	GetThrottle(key string) (*entities.LoginThrottle, error)
+	RecordFailure(key string, window time.Duration, maxFailures int, lockout time.Duration) (*entities.LoginThrottle, error)
+	ResetThrottle(key string) error
+	CreateAuditLog(log *entities.LoginAuditLog) error
+	GetAuditLogs(page, limit int, email, ipAddress string) ([]entities.LoginAuditLog, int64, error)
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptStore {
+	return &loginAttemptRepository{db: db}
+}
+
+// GetThrottle returns the throttle state of a key, or nil when there are no recorded failures
+func (r *loginAttemptRepository) GetThrottle(key string) (*entities.LoginThrottle, error) {
+	var throttle entities.LoginThrottle
+	err := r.db.Where("throttle_key = ?", key).First(&throttle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// RecordFailure counts a failed login for the key and locks it once maxFailures is reached.
+// The counter starts over when the window has passed or a previous lock has expired.
+func (r *loginAttemptRepository) RecordFailure(key string, window time.Duration, maxFailures int, lockout time.Duration) (*entities.LoginThrottle, error) {
+	var throttle entities.LoginThrottle
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entities.LoginThrottle{ThrottleKey: key}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("throttle_key = ?", key).
+			First(&throttle).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		windowExpired := throttle.FirstFailedAt == nil || now.Sub(*throttle.FirstFailedAt) > window
+		lockExpired := throttle.LockedUntil != nil && !now.Before(*throttle.LockedUntil)
+		if windowExpired || lockExpired {
+			throttle.FailedCount = 0
+			throttle.FirstFailedAt = &now
+			throttle.LockedUntil = nil
+		}
+
+		throttle.FailedCount++
+		throttle.LastFailedAt = &now
+		if throttle.FailedCount >= maxFailures && throttle.LockedUntil == nil {
+			lockedUntil := now.Add(lockout)
+			throttle.LockedUntil = &lockedUntil
+		}
+
+		return tx.Save(&throttle).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &throttle, nil
+}
+
+func (r *loginAttemptRepository) ResetThrottle(key string) error {
+	return r.db.Where("throttle_key = ?", key).Delete(&entities.LoginThrottle{}).Error
+}
+
+func (r *loginAttemptRepository) CreateAuditLog(log *entities.LoginAuditLog) error {
+	return r.db.Create(log).Error
+}
+
+func (r *loginAttemptRepository) GetAuditLogs(page, limit int, email, ipAddress string) ([]entities.LoginAuditLog, int64, error) {
+	var logs []entities.LoginAuditLog
+	var total int64
+
+	query := r.db.Model(&entities.LoginAuditLog{})
+	if email != "" {
+		query = query.Where("email = ?", email)
+	}
+	if ipAddress != "" {
+		query = query.Where("ip_address = ?", ipAddress)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
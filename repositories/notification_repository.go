@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	ClaimDueMessages(now time.Time, limit int, lease time.Duration) ([]entities.OutboxMessage, error)
+	MarkSent(id uint, attempts int, sentAt time.Time) error
+	MarkRetry(id uint, attempts int, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(id uint, attempts int, lastError string) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// ClaimDueMessages returns pending messages that are due and pushes their next attempt forward by
+// the lease, so another dispatcher doesn't pick them up while they are being sent. A message whose
+// dispatcher dies mid-send becomes due again when the lease runs out.
+func (r *notificationRepository) ClaimDueMessages(now time.Time, limit int, lease time.Duration) ([]entities.OutboxMessage, error) {
+	var due []entities.OutboxMessage
+	err := r.db.Where("status = ? AND next_attempt_at <= ?", entities.OutboxStatusPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]entities.OutboxMessage, 0, len(due))
+	for _, message := range due {
+		// Only claim the message if nobody moved it since it was read
+		result := r.db.Model(&entities.OutboxMessage{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", message.ID, entities.OutboxStatusPending, message.NextAttemptAt).
+			Update("next_attempt_at", now.Add(lease))
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 1 {
+			claimed = append(claimed, message)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *notificationRepository) MarkSent(id uint, attempts int, sentAt time.Time) error {
+	return r.db.Model(&entities.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     entities.OutboxStatusSent,
+		"attempts":   attempts,
+		"sent_at":    sentAt,
+		"last_error": "",
+	}).Error
+}
+
+func (r *notificationRepository) MarkRetry(id uint, attempts int, nextAttemptAt time.Time, lastError string) error {
+	return r.db.Model(&entities.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+	}).Error
+}
+
+func (r *notificationRepository) MarkFailed(id uint, attempts int, lastError string) error {
+	return r.db.Model(&entities.OutboxMessage{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     entities.OutboxStatusFailed,
+		"attempts":   attempts,
+		"last_error": lastError,
+	}).Error
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+
+	"gorm.io/gorm"
+)
+
+// Outbox holds the records announcing a state change: notifications for the customer, in-app
+// notifications for the customer and staff, and webhook deliveries for subscribed endpoints
+type Outbox struct {
+	Messages      []entities.OutboxMessage
+	Notifications []entities.Notification
+	Deliveries    []entities.WebhookDelivery
+}
+
+// OutboxBuilder renders the outbox records of a booking state change. Booking repository methods call
+// it inside the transaction of the change, so the records are stored exactly when the change is
+// committed. The booking is passed with its user, schedule route and seats loaded.
+type OutboxBuilder func(booking *entities.Booking) (*Outbox, error)
+
+// CombineOutbox merges the records of several builders, nil builders are skipped
+func CombineOutbox(builders ...OutboxBuilder) OutboxBuilder {
+	return func(booking *entities.Booking) (*Outbox, error) {
+		combined := &Outbox{}
+		for _, build := range builders {
+			if build == nil {
+				continue
+			}
+			outbox, err := build(booking)
+			if err != nil {
+				return nil, err
+			}
+			if outbox != nil {
+				combined.Messages = append(combined.Messages, outbox.Messages...)
+				combined.Notifications = append(combined.Notifications, outbox.Notifications...)
+				combined.Deliveries = append(combined.Deliveries, outbox.Deliveries...)
+			}
+		}
+		return combined, nil
+	}
+}
+
+// storeOutbox creates the records within the given transaction
+func storeOutbox(tx *gorm.DB, outbox *Outbox) error {
+	if outbox == nil {
+		return nil
+	}
+	if len(outbox.Messages) > 0 {
+		if err := tx.Create(&outbox.Messages).Error; err != nil {
+			return err
+		}
+	}
+	if len(outbox.Notifications) > 0 {
+		if err := tx.Create(&outbox.Notifications).Error; err != nil {
+			return err
+		}
+	}
+	if len(outbox.Deliveries) > 0 {
+		if err := tx.Create(&outbox.Deliveries).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeOutbox stores the records built for a booking within the given transaction
+func writeOutbox(tx *gorm.DB, build OutboxBuilder, booking *entities.Booking) error {
+	if build == nil {
+		return nil
+	}
+	outbox, err := build(booking)
+	if err != nil {
+		return err
+	}
+	return storeOutbox(tx, outbox)
+}
+
+// writeBookingOutbox loads a booking for the outbox builder and stores the records built for it
+// within the given transaction
+func writeBookingOutbox(tx *gorm.DB, build OutboxBuilder, bookingID uint) error {
+	if build == nil {
+		return nil
+	}
+	var booking entities.Booking
+	if err := preloadBookingForOutbox(tx).First(&booking, bookingID).Error; err != nil {
+		return err
+	}
+	return writeOutbox(tx, build, &booking)
+}
+
+// preloadBookingForOutbox preloads the relations outbox builders use
+func preloadBookingForOutbox(db *gorm.DB) *gorm.DB {
+	return db.Preload("User").
+		Preload("Schedule.Route").
+		Preload("BookingDetails.Seat").
+		Preload("Payment")
+}
@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PartnerCommissionSummary totals the successful bookings of a partner
+type PartnerCommissionSummary struct {
+	BookingCount int64
+	TicketAmount float64
+	Commission   float64
+}
+
+type PartnerRepository interface {
+	CreatePartner(partner *entities.Partner, user *entities.User) error
+	GetPartners() ([]entities.Partner, error)
+	GetPartnerByID(id uint) (*entities.Partner, error)
+	UpdatePartner(partner *entities.Partner) error
+	CreateAPIKey(key *entities.APIKey) error
+	GetAPIKeys(partnerID uint) ([]entities.APIKey, error)
+	GetAPIKey(id, partnerID uint) (*entities.APIKey, error)
+	FindAPIKeyByHash(keyHash string) (*entities.APIKey, error)
+	RotateAPIKey(old *entities.APIKey, next *entities.APIKey, oldExpiresAt time.Time) error
+	RevokeAPIKey(id uint) error
+	RecordUsage(keyID uint, at time.Time, ip string, failed bool) error
+	GetUsage(partnerID uint, from, to time.Time) ([]entities.APIKeyUsage, error)
+	GetCommissionSummary(partnerID uint, from, to time.Time) (*PartnerCommissionSummary, error)
+}
+
+type partnerRepository struct {
+	db *gorm.DB
+}
+
+func NewPartnerRepository(db *gorm.DB) PartnerRepository {
+	return &partnerRepository{db: db}
+}
+
+// CreatePartner creates the partner together with its service user
+func (r *partnerRepository) CreatePartner(partner *entities.Partner, user *entities.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		partner.UserID = user.ID
+		return tx.Omit("User", "APIKeys").Create(partner).Error
+	})
+}
+
+func (r *partnerRepository) GetPartners() ([]entities.Partner, error) {
+	var partners []entities.Partner
+	err := r.db.Order("name ASC").Find(&partners).Error
+	return partners, err
+}
+
+func (r *partnerRepository) GetPartnerByID(id uint) (*entities.Partner, error) {
+	var partner entities.Partner
+	err := r.db.First(&partner, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &partner, nil
+}
+
+func (r *partnerRepository) UpdatePartner(partner *entities.Partner) error {
+	return r.db.Omit("User", "APIKeys").Save(partner).Error
+}
+
+func (r *partnerRepository) CreateAPIKey(key *entities.APIKey) error {
+	return r.db.Omit("Partner").Create(key).Error
+}
+
+func (r *partnerRepository) GetAPIKeys(partnerID uint) ([]entities.APIKey, error) {
+	var keys []entities.APIKey
+	err := r.db.Where("partner_id = ?", partnerID).Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *partnerRepository) GetAPIKey(id, partnerID uint) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := r.db.Where("id = ? AND partner_id = ?", id, partnerID).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindAPIKeyByHash loads the key with its partner and the partner's service user
+func (r *partnerRepository) FindAPIKeyByHash(keyHash string) (*entities.APIKey, error) {
+	var key entities.APIKey
+	err := r.db.Preload("Partner.User").Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RotateAPIKey stores the replacement key and lets the old key expire after the grace period
+func (r *partnerRepository) RotateAPIKey(old *entities.APIKey, next *entities.APIKey, oldExpiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Partner").Create(next).Error; err != nil {
+			return err
+		}
+		if old.ExpiresAt != nil && old.ExpiresAt.Before(oldExpiresAt) {
+			oldExpiresAt = *old.ExpiresAt
+		}
+		return tx.Model(&entities.APIKey{}).
+			Where("id = ?", old.ID).
+			Updates(map[string]interface{}{"expires_at": oldExpiresAt, "rotated_to_id": next.ID}).Error
+	})
+}
+
+func (r *partnerRepository) RevokeAPIKey(id uint) error {
+	return r.db.Model(&entities.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RecordUsage counts a request in the daily usage of the key and remembers when it was last used
+func (r *partnerRepository) RecordUsage(keyID uint, at time.Time, ip string, failed bool) error {
+	errorCount := 0
+	if failed {
+		errorCount = 1
+	}
+
+	usage := entities.APIKeyUsage{
+		APIKeyID:     keyID,
+		Date:         dateOnly(at),
+		RequestCount: 1,
+		ErrorCount:   int64(errorCount),
+	}
+	err := r.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"request_count": gorm.Expr("request_count + 1"),
+			"error_count":   gorm.Expr("error_count + ?", errorCount),
+		}),
+	}).Create(&usage).Error
+	if err != nil {
+		return err
+	}
+
+	return r.db.Model(&entities.APIKey{}).
+		Where("id = ?", keyID).
+		Updates(map[string]interface{}{"last_used_at": at, "last_used_ip": ip}).Error
+}
+
+func (r *partnerRepository) GetUsage(partnerID uint, from, to time.Time) ([]entities.APIKeyUsage, error) {
+	var usage []entities.APIKeyUsage
+	err := r.db.Joins("JOIN api_keys ON api_keys.id = api_key_usages.api_key_id").
+		Where("api_keys.partner_id = ? AND api_key_usages.date BETWEEN ? AND ?", partnerID, dateOnly(from), dateOnly(to)).
+		Order("api_key_usages.date ASC, api_key_usages.api_key_id ASC").
+		Find(&usage).Error
+	return usage, err
+}
+
+// GetCommissionSummary totals the successful bookings of a partner made in the period
+func (r *partnerRepository) GetCommissionSummary(partnerID uint, from, to time.Time) (*PartnerCommissionSummary, error) {
+	var summary PartnerCommissionSummary
+	err := r.db.Model(&entities.Booking{}).
+		Select("COUNT(*) AS booking_count, COALESCE(SUM(payment_amount - unique_code), 0) AS ticket_amount, COALESCE(SUM(partner_commission), 0) AS commission").
+		Where("partner_id = ? AND status = ? AND created_at BETWEEN ? AND ?", partnerID, entities.BookingStatusSuccess, from, to).
+		Scan(&summary).Error
+	if err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// dateOnly keeps the calendar date of t as midnight in the database connection's time zone,
+// so the date column stores the same day t has in its own location
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"errors"
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrPasswordResetTokenInvalid = errors.New("password reset token is invalid or expired")
+
+type PasswordResetRepository interface {
+	CreateToken(token *entities.PasswordResetToken) error
+	CountRecentTokens(userID uint, since time.Time) (int64, error)
+	ResetPassword(tokenHash string, hashedPassword string) (*entities.User, error)
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+func (r *passwordResetRepository) CreateToken(token *entities.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *passwordResetRepository) CountRecentTokens(userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.PasswordResetToken{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	return count, err
+}
+
+// ResetPassword consumes a reset token and sets the new password. All outstanding reset
+// tokens of the user are invalidated, the token version is bumped and every session is revoked.
+func (r *passwordResetRepository) ResetPassword(tokenHash string, hashedPassword string) (*entities.User, error) {
+	var user entities.User
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var token entities.PasswordResetToken
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", tokenHash).
+			First(&token).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPasswordResetTokenInvalid
+			}
+			return err
+		}
+
+		now := time.Now()
+		if token.UsedAt != nil || now.After(token.ExpiresAt) {
+			return ErrPasswordResetTokenInvalid
+		}
+
+		if err := tx.First(&user, token.UserID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPasswordResetTokenInvalid
+			}
+			return err
+		}
+
+		if err := tx.Model(&user).Updates(map[string]interface{}{
+			"password":      hashedPassword,
+			"token_version": gorm.Expr("token_version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entities.PasswordResetToken{}).
+			Where("user_id = ? AND used_at IS NULL", user.ID).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.UserSession{}).
+			Where("user_id = ? AND revoked_at IS NULL", user.ID).
+			Updates(map[string]interface{}{"revoked_at": now, "revoked_reason": entities.SessionRevokedPassword}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+
+	"gorm.io/gorm"
+)
+
+type ReconciliationRepository struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepository(db *gorm.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+// CreateImport creates a bank statement import record
+func (r *ReconciliationRepository) CreateImport(statementImport *entities.BankStatementImport) error {
+	return r.db.Create(statementImport).Error
+}
+
+// UpdateImport saves the import status. The row counters are only changed by AddEntry.
+func (r *ReconciliationRepository) UpdateImport(statementImport *entities.BankStatementImport) error {
+	return r.db.Model(statementImport).Updates(map[string]interface{}{
+		"status":     statementImport.Status,
+		"error":      statementImport.Error,
+		"total_rows": statementImport.TotalRows,
+	}).Error
+}
+
+// AddEntry saves a reconciled statement row and counts it on its import in one transaction,
+// so the counters always match the stored entries even when an import stops halfway
+func (r *ReconciliationRepository) AddEntry(entry *entities.BankStatementEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return r.AddEntryTx(tx, entry)
+	})
+}
+
+// AddEntryTx saves and counts a statement row within the given transaction,
+// e.g. the one approving the booking it matched
+func (r *ReconciliationRepository) AddEntryTx(tx *gorm.DB, entry *entities.BankStatementEntry) error {
+	column := "unmatched_rows"
+	switch entry.Status {
+	case entities.BankStatementEntryMatched:
+		column = "matched_rows"
+	case entities.BankStatementEntryReview:
+		column = "review_rows"
+	}
+
+	if err := tx.Create(entry).Error; err != nil {
+		return err
+	}
+	return tx.Model(&entities.BankStatementImport{}).
+		Where("id = ?", entry.ImportID).
+		Update(column, gorm.Expr(column+" + 1")).Error
+}
+
+// GetImports retrieves bank statement imports with pagination
+func (r *ReconciliationRepository) GetImports(page, limit int) ([]entities.BankStatementImport, int64, error) {
+	var imports []entities.BankStatementImport
+	var total int64
+
+	if err := r.db.Model(&entities.BankStatementImport{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := r.db.Preload("ImportedBy").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&imports).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return imports, total, nil
+}
+
+// GetImportByID retrieves an import with its entries
+func (r *ReconciliationRepository) GetImportByID(id uint) (*entities.BankStatementImport, error) {
+	var statementImport entities.BankStatementImport
+	err := r.db.Preload("ImportedBy").
+		Preload("Entries", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_number ASC")
+		}).
+		First(&statementImport, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &statementImport, nil
+}
+
+// UpdateEntry saves a bank statement entry
+func (r *ReconciliationRepository) UpdateEntry(entry *entities.BankStatementEntry) error {
+	return r.UpdateEntryTx(r.db, entry)
+}
+
+// UpdateEntryTx saves a bank statement entry within the given transaction
+func (r *ReconciliationRepository) UpdateEntryTx(tx *gorm.DB, entry *entities.BankStatementEntry) error {
+	return tx.Save(entry).Error
+}
+
+// GetEntryByID retrieves a bank statement entry by ID
+func (r *ReconciliationRepository) GetEntryByID(id uint) (*entities.BankStatementEntry, error) {
+	var entry entities.BankStatementEntry
+	if err := r.db.First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetEntries retrieves bank statement entries filtered by status with pagination
+func (r *ReconciliationRepository) GetEntries(page, limit int, status []entities.BankStatementEntryStatus) ([]entities.BankStatementEntry, int64, error) {
+	var entries []entities.BankStatementEntry
+	var total int64
+
+	query := r.db.Model(&entities.BankStatementEntry{})
+	if len(status) > 0 {
+		query = query.Where("status IN ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("transaction_date DESC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
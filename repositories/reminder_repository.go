@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReminderRepository interface {
+	FindPaymentReminderDue(now time.Time, lead time.Duration) ([]entities.Booking, error)
+	FindDepartureReminderDue(kind string, from, to time.Time) ([]entities.Booking, error)
+	RecordReminder(booking *entities.Booking, kind string, outbox OutboxBuilder) (bool, error)
+}
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &reminderRepository{db: db}
+}
+
+// FindPaymentReminderDue finds pending bookings that expire within the lead time and haven't been reminded
+func (r *reminderRepository) FindPaymentReminderDue(now time.Time, lead time.Duration) ([]entities.Booking, error) {
+	var bookings []entities.Booking
+	err := preloadBookingForOutbox(r.db).
+		Where("bookings.status = ? AND bookings.expires_at > ? AND bookings.expires_at <= ?",
+			entities.BookingStatusPending, now, now.Add(lead)).
+		Where(notRemindedCondition(entities.BookingReminderPaymentExpiry)).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// FindDepartureReminderDue finds confirmed bookings departing after from and no later than to that
+// haven't received the reminder of the given kind
+func (r *reminderRepository) FindDepartureReminderDue(kind string, from, to time.Time) ([]entities.Booking, error) {
+	var bookings []entities.Booking
+	err := preloadBookingForOutbox(r.db).
+		Joins("JOIN schedules ON schedules.id = bookings.schedule_id").
+		Where("bookings.status = ? AND schedules.departure_time > ? AND schedules.departure_time <= ?",
+			entities.BookingStatusSuccess, from, to).
+		Where(notRemindedCondition(kind)).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// RecordReminder marks the reminder as sent and queues its notifications in one transaction.
+// It returns false without queueing anything if the reminder was already recorded.
+func (r *reminderRepository) RecordReminder(booking *entities.Booking, kind string, outbox OutboxBuilder) (bool, error) {
+	recorded := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entities.BookingReminder{BookingID: booking.ID, Kind: kind})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		recorded = true
+		return writeOutbox(tx, outbox, booking)
+	})
+	return recorded, err
+}
+
+func notRemindedCondition(kind string) clause.Expr {
+	return gorm.Expr("NOT EXISTS (SELECT 1 FROM booking_reminders WHERE booking_reminders.booking_id = bookings.id AND booking_reminders.kind = ?)", kind)
+}
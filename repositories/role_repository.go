@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	GetRoles() ([]entities.Role, error)
+	GetRoleByName(name string) (*entities.Role, error)
+	CreateRole(role *entities.Role) error
+	UpdateRole(role *entities.Role, permissions []string) error
+	DeleteRole(role *entities.Role) error
+	CountUsersWithRole(name string) (int64, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) GetRoles() ([]entities.Role, error) {
+	var roles []entities.Role
+	err := r.db.Preload("Permissions").Order("name ASC").Find(&roles).Error
+	return roles, err
+}
+
+func (r *roleRepository) GetRoleByName(name string) (*entities.Role, error) {
+	var role entities.Role
+	err := r.db.Preload("Permissions").Where("name = ?", name).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (r *roleRepository) CreateRole(role *entities.Role) error {
+	return r.db.Create(role).Error
+}
+
+// UpdateRole saves the role and replaces its permissions
+func (r *roleRepository) UpdateRole(role *entities.Role, permissions []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Permissions").Save(role).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", role.ID).Delete(&entities.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		role.Permissions = make([]entities.RolePermission, len(permissions))
+		for i, permission := range permissions {
+			role.Permissions[i] = entities.RolePermission{RoleID: role.ID, Permission: permission}
+		}
+		if len(role.Permissions) == 0 {
+			return nil
+		}
+		return tx.Create(&role.Permissions).Error
+	})
+}
+
+func (r *roleRepository) DeleteRole(role *entities.Role) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", role.ID).Delete(&entities.RolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(role).Error
+	})
+}
+
+func (r *roleRepository) CountUsersWithRole(name string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.User{}).Where("role = ?", name).Count(&count).Error
+	return count, err
+}
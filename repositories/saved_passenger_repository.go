@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+
+	"gorm.io/gorm"
+)
+
+type SavedPassengerRepository interface {
+	FindByUser(userID uint) ([]entities.SavedPassenger, error)
+	FindByID(id, userID uint) (*entities.SavedPassenger, error)
+	FindByIDs(ids []uint, userID uint) ([]entities.SavedPassenger, error)
+	Create(passenger *entities.SavedPassenger) error
+	Update(passenger *entities.SavedPassenger) error
+	Delete(id, userID uint) (int64, error)
+}
+
+type savedPassengerRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedPassengerRepository(db *gorm.DB) SavedPassengerRepository {
+	return &savedPassengerRepository{db: db}
+}
+
+func (r *savedPassengerRepository) FindByUser(userID uint) ([]entities.SavedPassenger, error) {
+	var passengers []entities.SavedPassenger
+	err := r.db.Where("user_id = ?", userID).Order("name ASC").Find(&passengers).Error
+	return passengers, err
+}
+
+func (r *savedPassengerRepository) FindByID(id, userID uint) (*entities.SavedPassenger, error) {
+	var passenger entities.SavedPassenger
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&passenger).Error
+	if err != nil {
+		return nil, err
+	}
+	return &passenger, nil
+}
+
+// FindByIDs returns the saved passengers with the given IDs that belong to the user
+func (r *savedPassengerRepository) FindByIDs(ids []uint, userID uint) ([]entities.SavedPassenger, error) {
+	var passengers []entities.SavedPassenger
+	if len(ids) == 0 {
+		return passengers, nil
+	}
+	err := r.db.Where("id IN ? AND user_id = ?", ids, userID).Find(&passengers).Error
+	return passengers, err
+}
+
+func (r *savedPassengerRepository) Create(passenger *entities.SavedPassenger) error {
+	return r.db.Create(passenger).Error
+}
+
+func (r *savedPassengerRepository) Update(passenger *entities.SavedPassenger) error {
+	return r.db.Save(passenger).Error
+}
+
+func (r *savedPassengerRepository) Delete(id, userID uint) (int64, error) {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&entities.SavedPassenger{})
+	return result.RowsAffected, result.Error
+}
@@ -1,13 +1,19 @@
 package repositories
 
 import (
+	"errors"
 	"fmt"
 	"malakashuttle/entities"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ScheduleOutboxBuilder renders the outbox records of a cancelled schedule and the bookings cancelled with it.
+// The bookings are passed with their user, schedule route and seats loaded.
+type ScheduleOutboxBuilder func(schedule *entities.Schedule, bookings []entities.Booking) (*Outbox, error)
+
 type ScheduleRepository struct {
 	db *gorm.DB
 }
@@ -112,6 +118,72 @@ func (r *ScheduleRepository) DeleteSchedule(id uint) error {
 	})
 }
 
+// CancelSchedule - Cancel schedule beserta booking yang masih aktif dan bebaskan kursinya (menggunakan transaction).
+// Mengembalikan ID booking yang ikut dibatalkan.
+func (r *ScheduleRepository) CancelSchedule(id uint, reason string, outbox ScheduleOutboxBuilder) ([]uint, error) {
+	var bookingIDs []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock schedule supaya tidak dibatalkan dua kali bersamaan
+		var schedule entities.Schedule
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Route").First(&schedule, id).Error; err != nil {
+			return err
+		}
+		if schedule.CancelledAt != nil {
+			return errors.New("schedule is already cancelled")
+		}
+
+		now := time.Now()
+		if err := tx.Model(&entities.Schedule{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"cancelled_at":        now,
+			"cancellation_reason": reason,
+		}).Error; err != nil {
+			return err
+		}
+		schedule.CancelledAt = &now
+		schedule.CancellationReason = reason
+
+		// Booking yang belum bayar, menunggu verifikasi, atau sudah lunas ikut dibatalkan
+		var bookings []entities.Booking
+		err := preloadBookingForOutbox(tx).
+			Where("schedule_id = ? AND status IN ?", id, []entities.BookingStatus{
+				entities.BookingStatusPending,
+				entities.BookingStatusWaitingVerification,
+				entities.BookingStatusSuccess,
+			}).
+			Find(&bookings).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range bookings {
+			bookingIDs = append(bookingIDs, bookings[i].ID)
+			bookings[i].Status = entities.BookingStatusCancelled
+			bookings[i].Schedule = schedule
+		}
+		if len(bookingIDs) > 0 {
+			if err := tx.Model(&entities.Booking{}).Where("id IN ?", bookingIDs).
+				Update("status", entities.BookingStatusCancelled).Error; err != nil {
+				return err
+			}
+			for _, bookingID := range bookingIDs {
+				if err := freeSeatsByBookingID(tx, bookingID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if outbox == nil {
+			return nil
+		}
+		records, err := outbox(&schedule, bookings)
+		if err != nil {
+			return err
+		}
+		return storeOutbox(tx, records)
+	})
+	return bookingIDs, err
+}
+
 // GetAllSchedules - Get all schedules with pagination (Admin)
 func (r *ScheduleRepository) GetAllSchedules(page, limit int) ([]entities.Schedule, int64, error) {
 	var schedules []entities.Schedule
@@ -158,7 +230,8 @@ func (r *ScheduleRepository) SearchSchedules(origin, destination string, departu
 		Where("LOWER(routes.origin_city) = LOWER(?)", origin).
 		Where("LOWER(routes.destination_city) = LOWER(?)", destination).
 		Where("schedules.departure_time >= ? AND schedules.departure_time < ?", startOfDay, endOfDay).
-		Where("schedules.available_seats > 0") // Hanya tampilkan yang masih ada seat tersedia
+		Where("schedules.available_seats > 0"). // Hanya tampilkan yang masih ada seat tersedia
+		Where("schedules.cancelled_at IS NULL")
 
 	// Count total records
 	if err := query.Count(&totalCount).Error; err != nil {
@@ -192,10 +265,10 @@ func (r *ScheduleRepository) CheckRouteExists(routeID uint) (bool, error) {
 	return count > 0, err
 }
 
-// GetSeatsByScheduleID - Get all seats for a schedule
+// GetSeatsByScheduleID - Get all seats for a schedule in seat order (seats are created in seat order)
 func (r *ScheduleRepository) GetSeatsByScheduleID(scheduleID uint) ([]entities.Seat, error) {
 	var seats []entities.Seat
-	err := r.db.Where("schedule_id = ?", scheduleID).Order("seat_number").Find(&seats).Error
+	err := r.db.Where("schedule_id = ?", scheduleID).Order("id").Find(&seats).Error
 	if err != nil {
 		return nil, err
 	}
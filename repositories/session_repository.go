@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"errors"
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenReused   = errors.New("refresh token has already been used")
+	ErrSessionRevoked       = errors.New("session has been revoked")
+)
+
+type SessionRepository interface {
+	CreateSession(session *entities.UserSession, token *entities.RefreshToken) error
+	RotateRefreshToken(tokenHash string, next *entities.RefreshToken) (*entities.UserSession, error)
+	FindRefreshToken(tokenHash string) (*entities.RefreshToken, error)
+	FindActiveSession(sessionID uint) (*entities.UserSession, error)
+	RevokeSession(sessionID uint, reason string) error
+	RevokeAllForUser(userID uint, reason string) (int64, error)
+	RevokeOtherSessions(userID, keepSessionID uint, reason string) (int64, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) CreateSession(session *entities.UserSession, token *entities.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		token.SessionID = session.ID
+		return tx.Create(token).Error
+	})
+}
+
+// RotateRefreshToken marks the presented refresh token as used and stores its replacement.
+// Presenting a token that was already used revokes the whole session, since either the
+// client or an attacker is holding a stolen copy.
+func (r *sessionRepository) RotateRefreshToken(tokenHash string, next *entities.RefreshToken) (*entities.UserSession, error) {
+	var session entities.UserSession
+	var reused bool
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var current entities.RefreshToken
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", tokenHash).
+			First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRefreshTokenNotFound
+			}
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&session, current.SessionID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrSessionRevoked
+			}
+			return err
+		}
+		if !session.IsActive() {
+			return ErrSessionRevoked
+		}
+
+		now := time.Now()
+		if current.UsedAt != nil {
+			reused = true
+			return revokeSession(tx, session.ID, entities.SessionRevokedReuse, now)
+		}
+		if now.After(current.ExpiresAt) {
+			return ErrRefreshTokenNotFound
+		}
+
+		if err := tx.Model(&current).Update("used_at", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&session).Update("last_used_at", now).Error; err != nil {
+			return err
+		}
+
+		next.SessionID = session.ID
+		return tx.Create(next).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The revocation has to be committed, so the reuse error is returned outside the transaction
+	if reused {
+		return nil, ErrRefreshTokenReused
+	}
+
+	return &session, nil
+}
+
+func (r *sessionRepository) FindRefreshToken(tokenHash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+	err := r.db.Preload("Session").Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindActiveSession returns the session if it is not revoked and its user still exists
+func (r *sessionRepository) FindActiveSession(sessionID uint) (*entities.UserSession, error) {
+	var session entities.UserSession
+	err := r.db.Joins("JOIN users ON users.id = user_sessions.user_id AND users.deleted_at IS NULL").
+		Where("user_sessions.id = ? AND user_sessions.revoked_at IS NULL", sessionID).
+		First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) RevokeSession(sessionID uint, reason string) error {
+	return revokeSession(r.db, sessionID, reason, time.Now())
+}
+
+func (r *sessionRepository) RevokeAllForUser(userID uint, reason string) (int64, error) {
+	result := r.db.Model(&entities.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	return result.RowsAffected, result.Error
+}
+
+// RevokeOtherSessions revokes every session of the user except the one that is still in use
+func (r *sessionRepository) RevokeOtherSessions(userID, keepSessionID uint, reason string) (int64, error) {
+	result := r.db.Model(&entities.UserSession{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepSessionID).
+		Updates(map[string]interface{}{"revoked_at": time.Now(), "revoked_reason": reason})
+	return result.RowsAffected, result.Error
+}
+
+func revokeSession(tx *gorm.DB, sessionID uint, reason string, at time.Time) error {
+	return tx.Model(&entities.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Updates(map[string]interface{}{"revoked_at": at, "revoked_reason": reason}).Error
+}
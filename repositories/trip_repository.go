@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TripRepository reads and updates the schedules assigned to drivers and their incidents
+type TripRepository interface {
+	FindDriverSchedule(scheduleID, driverID uint) (*entities.Schedule, error)
+	FindUpcoming(driverID uint, page, limit int) ([]entities.Schedule, int64, error)
+	AssignDriver(scheduleID uint, driverID *uint) error
+	UpdateStatus(scheduleID, driverID uint, from, to entities.ScheduleStatus, at time.Time) (bool, error)
+	CreateIncident(incident *entities.TripIncident) error
+	FindIncidents(scheduleID uint) ([]entities.TripIncident, error)
+}
+
+type tripRepository struct {
+	db *gorm.DB
+}
+
+func NewTripRepository(db *gorm.DB) TripRepository {
+	return &tripRepository{db: db}
+}
+
+// FindDriverSchedule returns a schedule with its route, as long as it is assigned to the driver
+func (r *tripRepository) FindDriverSchedule(scheduleID, driverID uint) (*entities.Schedule, error) {
+	var schedule entities.Schedule
+	err := r.db.Preload("Route").
+		Where("driver_id = ?", driverID).
+		First(&schedule, scheduleID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// FindUpcoming returns the schedules of a driver that have not been completed or cancelled, by departure
+func (r *tripRepository) FindUpcoming(driverID uint, page, limit int) ([]entities.Schedule, int64, error) {
+	query := r.db.Model(&entities.Schedule{}).
+		Where("driver_id = ? AND cancelled_at IS NULL AND status IN ?", driverID,
+			[]entities.ScheduleStatus{entities.ScheduleStatusScheduled, entities.ScheduleStatusDeparted})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var schedules []entities.Schedule
+	err := query.Preload("Route").
+		Order("departure_time ASC").
+		Limit(limit).
+		Offset((page - 1) * limit).
+		Find(&schedules).Error
+	return schedules, total, err
+}
+
+// AssignDriver sets the driver of a schedule, nil removes the assignment
+func (r *tripRepository) AssignDriver(scheduleID uint, driverID *uint) error {
+	return r.db.Model(&entities.Schedule{}).
+		Where("id = ?", scheduleID).
+		Update("driver_id", driverID).Error
+}
+
+// UpdateStatus moves a trip of the driver from one status to the next and records when it happened.
+// It returns false when the trip was not in the expected status, so starting or ending it twice
+// cannot both succeed.
+func (r *tripRepository) UpdateStatus(scheduleID, driverID uint, from, to entities.ScheduleStatus, at time.Time) (bool, error) {
+	updates := map[string]interface{}{"status": to}
+	switch to {
+	case entities.ScheduleStatusDeparted:
+		updates["departed_at"] = at
+	case entities.ScheduleStatusCompleted:
+		updates["completed_at"] = at
+	}
+
+	result := r.db.Model(&entities.Schedule{}).
+		Where("id = ? AND driver_id = ? AND status = ? AND cancelled_at IS NULL", scheduleID, driverID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *tripRepository) CreateIncident(incident *entities.TripIncident) error {
+	return r.db.Create(incident).Error
+}
+
+// FindIncidents returns the incidents reported on a schedule, newest first
+func (r *tripRepository) FindIncidents(scheduleID uint) ([]entities.TripIncident, error) {
+	var incidents []entities.TripIncident
+	err := r.db.Where("schedule_id = ?", scheduleID).
+		Order("occurred_at DESC").
+		Find(&incidents).Error
+	return incidents, err
+}
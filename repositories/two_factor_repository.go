@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"errors"
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TwoFactorRepository interface {
+	SavePendingSecret(userID uint, encryptedSecret string) error
+	Enable(userID uint, step int64, recoveryCodes []entities.TwoFactorRecoveryCode) error
+	Disable(userID uint) error
+	ReplaceRecoveryCodes(userID uint, recoveryCodes []entities.TwoFactorRecoveryCode) error
+	UseRecoveryCode(userID uint, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(userID uint) (int64, error)
+	MarkStepUsed(userID uint, step int64) (bool, error)
+	GetPolicies() ([]entities.TwoFactorPolicy, error)
+	GetPolicy(role string) (*entities.TwoFactorPolicy, error)
+	SavePolicy(policy *entities.TwoFactorPolicy) error
+}
+
+type twoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) TwoFactorRepository {
+	return &twoFactorRepository{db: db}
+}
+
+// SavePendingSecret stores a new secret that becomes active once the user confirms a code
+func (r *twoFactorRepository) SavePendingSecret(userID uint, encryptedSecret string) error {
+	return r.db.Model(&entities.User{}).
+		Where("id = ? AND two_factor_enabled_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"two_factor_secret":    encryptedSecret,
+			"two_factor_last_step": 0,
+		}).Error
+}
+
+func (r *twoFactorRepository) Enable(userID uint, step int64, recoveryCodes []entities.TwoFactorRecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.User{}).
+			Where("id = ? AND two_factor_enabled_at IS NULL", userID).
+			Updates(map[string]interface{}{
+				"two_factor_enabled_at": time.Now(),
+				"two_factor_last_step":  step,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("two-factor authentication is already enabled")
+		}
+		return replaceRecoveryCodes(tx, userID, recoveryCodes)
+	})
+}
+
+func (r *twoFactorRepository) Disable(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entities.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"two_factor_secret":     "",
+				"two_factor_enabled_at": nil,
+				"two_factor_last_step":  0,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entities.TwoFactorRecoveryCode{}).Error
+	})
+}
+
+func (r *twoFactorRepository) ReplaceRecoveryCodes(userID uint, recoveryCodes []entities.TwoFactorRecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, recoveryCodes)
+	})
+}
+
+// UseRecoveryCode consumes an unused recovery code, it reports false when the code is not valid
+func (r *twoFactorRepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&entities.TwoFactorRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *twoFactorRepository) CountUnusedRecoveryCodes(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.TwoFactorRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkStepUsed records the TOTP time step of an accepted code. It reports false when a code
+// of the same or a later step was already accepted, so a code cannot be replayed.
+func (r *twoFactorRepository) MarkStepUsed(userID uint, step int64) (bool, error) {
+	result := r.db.Model(&entities.User{}).
+		Where("id = ? AND two_factor_last_step < ?", userID, step).
+		Update("two_factor_last_step", step)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *twoFactorRepository) GetPolicies() ([]entities.TwoFactorPolicy, error) {
+	var policies []entities.TwoFactorPolicy
+	err := r.db.Order("role ASC").Find(&policies).Error
+	return policies, err
+}
+
+// GetPolicy returns the policy of a role, or nil when none has been set
+func (r *twoFactorRepository) GetPolicy(role string) (*entities.TwoFactorPolicy, error) {
+	var policy entities.TwoFactorPolicy
+	err := r.db.Where("role = ?", role).First(&policy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *twoFactorRepository) SavePolicy(policy *entities.TwoFactorPolicy) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "role"}},
+		DoUpdates: clause.AssignmentColumns([]string{"required", "updated_at"}),
+	}).Create(policy).Error
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, recoveryCodes []entities.TwoFactorRecoveryCode) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&entities.TwoFactorRecoveryCode{}).Error; err != nil {
+		return err
+	}
+	for i := range recoveryCodes {
+		recoveryCodes[i].UserID = userID
+	}
+	return tx.Create(&recoveryCodes).Error
+}
@@ -11,6 +11,7 @@ type UserRepository interface {
 	FindByEmail(email string) (*entities.User, error)
 	FindByID(id uint) (*entities.User, error)
 	FindAll() ([]entities.User, error)
+	FindByRoles(roles []string) ([]entities.User, error)
 	GetAllWithPagination(page, limit int) ([]entities.User, int64, error)
 	Update(user *entities.User) error
 	Delete(id uint) error
@@ -52,6 +53,13 @@ func (r *userRepository) FindAll() ([]entities.User, error) {
 	return users, err
 }
 
+// FindByRoles only loads the ID and language preference of the users
+func (r *userRepository) FindByRoles(roles []string) ([]entities.User, error) {
+	var users []entities.User
+	err := r.db.Select("id", "locale").Where("role IN ?", roles).Order("id ASC").Find(&users).Error
+	return users, err
+}
+
 func (r *userRepository) Update(user *entities.User) error {
 	return r.db.Save(user).Error
 }
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type VerificationRepository interface {
+	CreateCode(code *entities.VerificationCode) error
+	FindLatestCode(userID uint, channel entities.VerificationChannel) (*entities.VerificationCode, error)
+	FindActiveCode(userID uint, channel entities.VerificationChannel) (*entities.VerificationCode, error)
+	FindActiveCodeByHash(channel entities.VerificationChannel, codeHash string) (*entities.VerificationCode, error)
+	IncrementAttempts(codeID uint) error
+	ConfirmCode(code *entities.VerificationCode) error
+}
+
+type verificationRepository struct {
+	db *gorm.DB
+}
+
+func NewVerificationRepository(db *gorm.DB) VerificationRepository {
+	return &verificationRepository{db: db}
+}
+
+// CreateCode stores a new code and invalidates the previous unused codes of the same channel
+func (r *verificationRepository) CreateCode(code *entities.VerificationCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entities.VerificationCode{}).
+			Where("user_id = ? AND channel = ? AND used_at IS NULL", code.UserID, code.Channel).
+			Update("expires_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return tx.Create(code).Error
+	})
+}
+
+func (r *verificationRepository) FindLatestCode(userID uint, channel entities.VerificationChannel) (*entities.VerificationCode, error) {
+	var code entities.VerificationCode
+	err := r.db.Where("user_id = ? AND channel = ?", userID, channel).
+		Order("created_at DESC").
+		First(&code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *verificationRepository) FindActiveCode(userID uint, channel entities.VerificationChannel) (*entities.VerificationCode, error) {
+	var code entities.VerificationCode
+	err := r.db.Where("user_id = ? AND channel = ? AND used_at IS NULL AND expires_at > ?", userID, channel, time.Now()).
+		Order("created_at DESC").
+		First(&code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *verificationRepository) FindActiveCodeByHash(channel entities.VerificationChannel, codeHash string) (*entities.VerificationCode, error) {
+	var code entities.VerificationCode
+	err := r.db.Preload("User").
+		Where("channel = ? AND code_hash = ? AND used_at IS NULL AND expires_at > ?", channel, codeHash, time.Now()).
+		First(&code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *verificationRepository) IncrementAttempts(codeID uint) error {
+	return r.db.Model(&entities.VerificationCode{}).
+		Where("id = ?", codeID).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// ConfirmCode marks the code as used and the matching email or phone number as verified.
+// The user is only marked verified when the code was sent to their current or pending email/phone.
+func (r *verificationRepository) ConfirmCode(code *entities.VerificationCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&entities.VerificationCode{}).
+			Where("id = ? AND used_at IS NULL", code.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// A requested email change is applied once the new address has been confirmed.
+		// The token version is bumped because access tokens carry the email.
+		if code.Channel == entities.VerificationChannelEmail {
+			result = tx.Model(&entities.User{}).
+				Where("id = ? AND pending_email = ?", code.UserID, code.Target).
+				Updates(map[string]interface{}{
+					"email":             code.Target,
+					"pending_email":     "",
+					"email_verified_at": now,
+					"token_version":     gorm.Expr("token_version + 1"),
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				return nil
+			}
+		}
+
+		column, targetColumn := "email_verified_at", "email"
+		if code.Channel == entities.VerificationChannelPhone {
+			column, targetColumn = "phone_verified_at", "phone_number"
+		}
+
+		result = tx.Model(&entities.User{}).
+			Where("id = ? AND "+targetColumn+" = ?", code.UserID, code.Target).
+			Update(column, now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
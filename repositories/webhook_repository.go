@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"malakashuttle/entities"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	CreateEndpoint(endpoint *entities.WebhookEndpoint) error
+	GetEndpoints() ([]entities.WebhookEndpoint, error)
+	GetEndpointByID(id uint) (*entities.WebhookEndpoint, error)
+	GetActiveEndpoints() ([]entities.WebhookEndpoint, error)
+	UpdateEndpoint(endpoint *entities.WebhookEndpoint) error
+	DeleteEndpoint(id uint) error
+
+	ClaimDueDeliveries(now time.Time, limit int, lease time.Duration) ([]entities.WebhookDelivery, error)
+	RecordAttempt(delivery *entities.WebhookDelivery, attempt *entities.WebhookDeliveryAttempt) error
+	GetDeliveries(endpointID uint, page, limit int, status string) ([]entities.WebhookDelivery, int64, error)
+	GetDelivery(id, endpointID uint) (*entities.WebhookDelivery, error)
+	ResetDelivery(id uint, now time.Time) error
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func (r *webhookRepository) CreateEndpoint(endpoint *entities.WebhookEndpoint) error {
+	return r.db.Create(endpoint).Error
+}
+
+func (r *webhookRepository) GetEndpoints() ([]entities.WebhookEndpoint, error) {
+	var endpoints []entities.WebhookEndpoint
+	err := r.db.Order("id ASC").Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *webhookRepository) GetEndpointByID(id uint) (*entities.WebhookEndpoint, error) {
+	var endpoint entities.WebhookEndpoint
+	if err := r.db.First(&endpoint, id).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+func (r *webhookRepository) GetActiveEndpoints() ([]entities.WebhookEndpoint, error) {
+	var endpoints []entities.WebhookEndpoint
+	err := r.db.Where("is_active = ?", true).Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *webhookRepository) UpdateEndpoint(endpoint *entities.WebhookEndpoint) error {
+	return r.db.Save(endpoint).Error
+}
+
+func (r *webhookRepository) DeleteEndpoint(id uint) error {
+	return r.db.Delete(&entities.WebhookEndpoint{}, id).Error
+}
+
+// ClaimDueDeliveries returns pending deliveries that are due and pushes their next attempt forward
+// by the lease, so another dispatcher doesn't pick them up while they are being sent
+func (r *webhookRepository) ClaimDueDeliveries(now time.Time, limit int, lease time.Duration) ([]entities.WebhookDelivery, error) {
+	var due []entities.WebhookDelivery
+	err := r.db.Preload("Endpoint").
+		Where("status = ? AND next_attempt_at <= ?", entities.WebhookDeliveryStatusPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]entities.WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		// Only claim the delivery if nobody moved it since it was read
+		result := r.db.Model(&entities.WebhookDelivery{}).
+			Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, entities.WebhookDeliveryStatusPending, delivery.NextAttemptAt).
+			Update("next_attempt_at", now.Add(lease))
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 1 {
+			claimed = append(claimed, delivery)
+		}
+	}
+	return claimed, nil
+}
+
+// RecordAttempt logs an attempt and saves the resulting state of the delivery
+func (r *webhookRepository) RecordAttempt(delivery *entities.WebhookDelivery, attempt *entities.WebhookDeliveryAttempt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		attempt.DeliveryID = delivery.ID
+		if err := tx.Create(attempt).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(map[string]interface{}{
+			"status":               delivery.Status,
+			"attempts":             delivery.Attempts,
+			"next_attempt_at":      delivery.NextAttemptAt,
+			"last_response_status": delivery.LastResponseStatus,
+			"last_error":           delivery.LastError,
+			"delivered_at":         delivery.DeliveredAt,
+		}).Error
+	})
+}
+
+func (r *webhookRepository) GetDeliveries(endpointID uint, page, limit int, status string) ([]entities.WebhookDelivery, int64, error) {
+	query := r.db.Model(&entities.WebhookDelivery{}).Where("endpoint_id = ?", endpointID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var deliveries []entities.WebhookDelivery
+	offset := (page - 1) * limit
+	err := query.Order("created_at DESC").Offset(offset).Limit(limit).Find(&deliveries).Error
+	return deliveries, total, err
+}
+
+func (r *webhookRepository) GetDelivery(id, endpointID uint) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	err := r.db.Preload("AttemptLog", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).
+		Where("id = ? AND endpoint_id = ?", id, endpointID).
+		First(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ResetDelivery queues a delivery again with a fresh attempt budget, the attempt log is kept
+func (r *webhookRepository) ResetDelivery(id uint, now time.Time) error {
+	return r.db.Model(&entities.WebhookDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          entities.WebhookDeliveryStatusPending,
+		"attempts":        0,
+		"next_attempt_at": now,
+		"last_error":      "",
+	}).Error
+}
@@ -1,12 +1,17 @@
 package router
 
 import (
+	"log"
 	"malakashuttle/controllers"
 	"malakashuttle/cron"
+	"malakashuttle/mailer"
 	"malakashuttle/middleware"
+	"malakashuttle/pubsub"
 	"malakashuttle/repositories"
 	"malakashuttle/routes"
 	"malakashuttle/services"
+	"malakashuttle/sms"
+	"malakashuttle/whatsapp"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -15,40 +20,138 @@ import (
 func InitRoutes(r *gin.Engine, db *gorm.DB) {
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
+	sessionRepo := repositories.NewSessionRepository(db)
+	passwordResetRepo := repositories.NewPasswordResetRepository(db)
+	verificationRepo := repositories.NewVerificationRepository(db)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(db)
+	twoFactorRepo := repositories.NewTwoFactorRepository(db)
+	savedPassengerRepo := repositories.NewSavedPassengerRepository(db)
+	partnerRepo := repositories.NewPartnerRepository(db)
+	notificationRepo := repositories.NewNotificationRepository(db)
+	inboxRepo := repositories.NewInboxRepository(db)
+	reminderRepo := repositories.NewReminderRepository(db)
+	webhookRepo := repositories.NewWebhookRepository(db)
+	roleRepo := repositories.NewRoleRepository(db)
 	routeRepo := repositories.NewRouteRepository(db)
 	scheduleRepo := repositories.NewScheduleRepository(db)
 	bookingRepo := repositories.NewBookingRepository(db)
+	boardingRepo := repositories.NewBoardingRepository(db)
+	tripRepo := repositories.NewTripRepository(db)
+	reconciliationRepo := repositories.NewReconciliationRepository(db)
+	invoiceRepo := repositories.NewInvoiceRepository(db)
+
+	// Initialize mail, sms and whatsapp senders
+	mailSender, err := mailer.NewSenderFromConfig()
+	if err != nil {
+		log.Fatalf("Failed to initialize mail sender: %v", err)
+	}
+	smsSender, err := sms.NewSenderFromConfig()
+	if err != nil {
+		log.Fatalf("Failed to initialize sms sender: %v", err)
+	}
+	whatsappSender, err := whatsapp.NewSenderFromConfig()
+	if err != nil {
+		log.Fatalf("Failed to initialize whatsapp sender: %v", err)
+	}
+
+	// Seat changes fan out to the streams of every instance through the broker
+	broker, err := pubsub.NewBrokerFromConfig()
+	if err != nil {
+		log.Fatalf("Failed to initialize pubsub broker: %v", err)
+	}
 
 	// Initialize services
-	authService := services.NewAuthService(userRepo)
-	userService := services.NewUserService(userRepo)
+	roleService := services.NewRoleService(roleRepo)
+	if err := roleService.EnsureDefaultRoles(); err != nil {
+		log.Fatalf("Failed to create default roles: %v", err)
+	}
+	verificationService := services.NewVerificationService(verificationRepo, userRepo, mailSender, smsSender)
+	loginProtectionService := services.NewLoginProtectionService(loginAttemptRepo, userRepo)
+	twoFactorService := services.NewTwoFactorService(twoFactorRepo, userRepo, roleService)
+	authService := services.NewAuthService(userRepo, sessionRepo, passwordResetRepo, mailSender, verificationService, loginProtectionService, twoFactorService, roleService)
+	userService := services.NewUserService(userRepo, roleService)
+	profileService := services.NewProfileService(userRepo, sessionRepo, savedPassengerRepo, verificationService)
+	partnerService := services.NewPartnerService(partnerRepo, roleService)
+	notificationService := services.NewNotificationService(notificationRepo, userRepo, roleService, mailSender, smsSender, whatsappSender)
+	inboxService := services.NewInboxService(inboxRepo)
+	reminderService := services.NewReminderService(reminderRepo, notificationService)
+	webhookService := services.NewWebhookService(webhookRepo, partnerRepo)
+	seatAvailabilityService := services.NewSeatAvailabilityService(scheduleRepo, broker)
 	routeService := services.NewRouteService(routeRepo)
-	scheduleService := services.NewScheduleService(scheduleRepo)
-	bookingService := services.NewBookingService(bookingRepo, scheduleRepo, userRepo)
+	scheduleService := services.NewScheduleService(scheduleRepo, notificationService, webhookService, seatAvailabilityService)
+	noShowService := services.NewNoShowService(boardingRepo, userRepo)
+	bookingService := services.NewBookingService(bookingRepo, scheduleRepo, userRepo, savedPassengerRepo, notificationService, webhookService, seatAvailabilityService, noShowService)
+	reconciliationService := services.NewReconciliationService(reconciliationRepo, bookingRepo, bookingService)
+	invoiceService := services.NewInvoiceService(invoiceRepo, bookingRepo)
+	counterPaymentService := services.NewCounterPaymentService(bookingRepo, notificationService, webhookService)
+	boardingService := services.NewBoardingService(boardingRepo, bookingRepo)
+	manifestService := services.NewManifestService(scheduleRepo, boardingRepo)
+	tripService := services.NewTripService(tripRepo, scheduleRepo, userRepo, manifestService, boardingService)
 
 	// Initialize controllers
 	authController := controllers.NewAuthController(authService)
+	verificationController := controllers.NewVerificationController(verificationService)
+	loginProtectionController := controllers.NewLoginProtectionController(loginProtectionService)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorService)
+	roleController := controllers.NewRoleController(roleService)
 	userController := controllers.NewUserController(userService)
+	profileController := controllers.NewProfileController(profileService)
+	partnerController := controllers.NewPartnerController(partnerService)
+	webhookController := controllers.NewWebhookController(webhookService)
+	inboxController := controllers.NewInboxController(inboxService)
 	routeController := controllers.NewRouteController(routeService)
-	scheduleController := controllers.NewScheduleController(scheduleService)
+	scheduleController := controllers.NewScheduleController(scheduleService, seatAvailabilityService)
 	bookingController := controllers.NewBookingController(bookingService)
+	reconciliationController := controllers.NewReconciliationController(reconciliationService)
+	invoiceController := controllers.NewInvoiceController(invoiceService)
+	counterPaymentController := controllers.NewCounterPaymentController(counterPaymentService)
+	boardingController := controllers.NewBoardingController(boardingService)
+	manifestController := controllers.NewManifestController(manifestService)
+	noShowController := controllers.NewNoShowController(noShowService)
+	tripController := controllers.NewTripController(tripService)
 	testController := controllers.NewTestController()
 
 	// feedback: Ini ntr ganti jadi pake cron job
 	bookingScheduler := cron.NewBookingScheduler(bookingService)
 	bookingScheduler.Start()
+	notificationDispatcher := cron.NewNotificationDispatcher(notificationService)
+	notificationDispatcher.Start()
+	reminderScheduler := cron.NewReminderScheduler(reminderService)
+	reminderScheduler.Start()
+	webhookDispatcher := cron.NewWebhookDispatcher(webhookService)
+	webhookDispatcher.Start()
+	noShowScheduler := cron.NewNoShowScheduler(noShowService)
+	noShowScheduler.Start()
 
 	// Apply logging middleware to all API routes
 	r.Use(middleware.LoggerMiddleware(), middleware.RequestIDMiddleware())
 
+	// AuthMiddleware memuat principal dan menolak token yang sudah dicabut
+	middleware.SetPrincipalResolver(authService)
+	middleware.SetAPIKeyResolver(partnerService)
+
 	// Menginisialisasi grup router untuk API
 	router := r.Group("/api")
 
 	// Inisialisasi routes
 	routes.TestRoutes(router, testController)
 	routes.AuthRoutes(router, authController)
+	routes.VerificationRoutes(router, verificationController)
+	routes.LoginProtectionRoutes(router, loginProtectionController)
+	routes.TwoFactorRoutes(router, twoFactorController)
+	routes.RoleRoutes(router, roleController)
 	routes.UserRoutes(router, userController)
-	routes.BookingRoutes(router, bookingController)
+	routes.ProfileRoutes(router, profileController)
+	routes.PartnerRoutes(router, partnerController)
+	routes.WebhookRoutes(router, webhookController)
+	routes.InboxRoutes(router, inboxController)
+	routes.BookingRoutes(router, bookingController, invoiceController, boardingController)
+	routes.BoardingRoutes(router, boardingController)
 	routes.RouteRoutes(router, routeController)
 	routes.ScheduleRoutes(router, scheduleController)
+	routes.ManifestRoutes(router, manifestController)
+	routes.NoShowRoutes(router, noShowController)
+	routes.TripRoutes(router, tripController)
+	routes.ReconciliationRoutes(router, reconciliationController)
+	routes.CounterPaymentRoutes(router, counterPaymentController)
 }
@@ -1,7 +1,9 @@
 package routes
 
 import (
+	"malakashuttle/constants"
 	"malakashuttle/controllers"
+	"malakashuttle/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,4 +13,14 @@ func AuthRoutes(r *gin.RouterGroup, h *controllers.AuthController) {
 	auth := r.Group("/auth")
 	auth.POST("/register", h.Register)
 	auth.POST("/login", h.Login)
+	auth.POST("/2fa/verify", h.VerifyTwoFactor)
+	auth.POST("/refresh", h.Refresh)
+	auth.POST("/logout", h.Logout)
+	auth.POST("/forgot-password", h.ForgotPassword)
+	auth.POST("/reset-password", h.ResetPassword)
+
+	// Admin session management
+	adminRoutes := r.Group("/admin")
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_USERS_MANAGE))
+	adminRoutes.POST("/users/:id/revoke-sessions", h.RevokeUserSessions)
 }
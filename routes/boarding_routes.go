@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BoardingRoutes serve the scanners of staff and drivers boarding passengers
+func BoardingRoutes(r *gin.RouterGroup, h *controllers.BoardingController) {
+	boarding := r.Group("/boarding")
+	boarding.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_BOARDING_SCAN))
+	boarding.POST("/check-in", h.CheckIn)
+	boarding.POST("/scan", h.ScanBoardingPass)
+	boarding.PUT("/passengers/:id/status", h.UpdateBoardingStatus)
+}
@@ -8,29 +8,35 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func BookingRoutes(r *gin.RouterGroup, h *controllers.BookingController) {
-	// User booking routes
+func BookingRoutes(r *gin.RouterGroup, h *controllers.BookingController, invoiceHandler *controllers.InvoiceController, boardingHandler *controllers.BoardingController) {
+	// Customer booking routes, handlers limit access to own bookings unless the role grants bookings.read_all.
+	// Partners book here with their API keys.
 	userRoutes := r.Group("/bookings")
-	userRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole(constants.ROLE_USER))
-	userRoutes.GET("", h.GetUserBookings)
-	userRoutes.POST("", h.CreateBooking)
-	userRoutes.GET("/:id", h.GetBookingByID)
-	userRoutes.GET("/:id/receipt", h.DownloadReceipt)
-	userRoutes.POST("/:id/payment", h.UploadPaymentProof)
+	userRoutes.Use(middleware.AuthMiddleware(middleware.AcceptAPIKeys))
+	readOwn := middleware.RequirePermission(constants.PERMISSION_BOOKINGS_READ_OWN, constants.PERMISSION_BOOKINGS_READ_ALL)
+	create := middleware.RequirePermission(constants.PERMISSION_BOOKINGS_CREATE)
+	userRoutes.GET("", readOwn, h.GetUserBookings)
+	userRoutes.POST("", create, h.CreateBooking)
+	userRoutes.GET("/:id", readOwn, h.GetBookingByID)
+	userRoutes.GET("/:id/receipt", readOwn, h.DownloadReceipt)
+	userRoutes.GET("/:id/passengers/:passengerId/boarding-pass", readOwn, boardingHandler.GetBoardingPass)
+	userRoutes.POST("/:id/payment", create, h.UploadPaymentProof)
+	userRoutes.GET("/:id/invoice", readOwn, invoiceHandler.GetInvoice)
+	userRoutes.POST("/:id/invoice", readOwn, invoiceHandler.IssueInvoice)
+	userRoutes.GET("/:id/invoice/pdf", readOwn, invoiceHandler.DownloadInvoice)
 
-	// Admin booking routes
-	adminRoutes := r.Group("/admin/bookings")
-	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole(constants.ROLE_ADMIN))
-	adminRoutes.GET("", h.GetAllBookings)
-	adminRoutes.GET("/:id", h.GetBookingByID)
-	adminRoutes.GET("/:id/payment/download", h.DownloadPaymentProof)
-	adminRoutes.PUT("/:id/status", h.UpdateBookingStatus)
-
-	// Staff booking routes
-	staffRoutes := r.Group("/staff/bookings")
-	staffRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole(constants.ROLE_STAFF))
-	staffRoutes.GET("", h.GetAllBookings)
-	staffRoutes.GET("/:id", h.GetBookingByID)
-	staffRoutes.GET("/:id/payment/download", h.DownloadPaymentProof)
-	staffRoutes.PUT("/:id/status", h.UpdateBookingStatus)
+	// Back office booking routes, /staff/bookings is kept as an alias for existing clients
+	for _, prefix := range []string{"/admin/bookings", "/staff/bookings"} {
+		backOffice := r.Group(prefix)
+		backOffice.Use(middleware.AuthMiddleware())
+		readAll := middleware.RequirePermission(constants.PERMISSION_BOOKINGS_READ_ALL)
+		backOffice.GET("", readAll, h.GetAllBookings)
+		backOffice.GET("/:id", readAll, h.GetBookingByID)
+		backOffice.GET("/:id/payment/download", readAll, h.DownloadPaymentProof)
+		backOffice.GET("/:id/passengers/:passengerId/boarding-pass", readAll, boardingHandler.GetBoardingPass)
+		backOffice.PUT("/:id/status", middleware.RequirePermission(constants.PERMISSION_BOOKINGS_VERIFY), h.UpdateBookingStatus)
+		backOffice.GET("/:id/invoice", readAll, invoiceHandler.GetInvoice)
+		backOffice.POST("/:id/invoice", middleware.RequirePermission(constants.PERMISSION_INVOICES_ISSUE), invoiceHandler.IssueInvoice)
+		backOffice.GET("/:id/invoice/pdf", readAll, invoiceHandler.DownloadInvoice)
+	}
 }
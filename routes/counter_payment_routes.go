@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CounterPaymentRoutes(r *gin.RouterGroup, h *controllers.CounterPaymentController) {
+	// /staff is kept as an alias for existing clients, the cash summary is limited to the
+	// cashier's own payments unless the role grants payments.cash_summary_all
+	for _, prefix := range []string{"/admin", "/staff"} {
+		counterRoutes := r.Group(prefix)
+		counterRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_PAYMENTS_COUNTER))
+		counterRoutes.POST("/bookings/:id/counter-payment", h.RecordCounterPayment)
+		counterRoutes.GET("/cash-summary", h.GetCashSummary)
+	}
+}
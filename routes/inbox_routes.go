@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InboxRoutes serve the in-app notifications of the authenticated user, staff alerts included
+func InboxRoutes(r *gin.RouterGroup, h *controllers.InboxController) {
+	notifications := r.Group("/notifications")
+	notifications.Use(middleware.AuthMiddleware())
+	notifications.GET("", h.GetNotifications)
+	notifications.GET("/unread-count", h.GetUnreadCount)
+	notifications.POST("/read-all", h.MarkAllRead)
+	notifications.POST("/:id/read", h.MarkRead)
+}
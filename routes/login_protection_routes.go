@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func LoginProtectionRoutes(r *gin.RouterGroup, h *controllers.LoginProtectionController) {
+	adminRoutes := r.Group("/admin")
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_SECURITY_MANAGE))
+	adminRoutes.POST("/users/:id/unlock-login", h.UnlockUser)
+	adminRoutes.GET("/login-audit-logs", h.GetAuditLogs)
+}
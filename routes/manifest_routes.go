@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ManifestRoutes serve the passenger manifests of schedules to the back office
+func ManifestRoutes(r *gin.RouterGroup, h *controllers.ManifestController) {
+	manifests := r.Group("/admin/schedules/:id/manifest")
+	manifests.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_MANIFESTS_READ))
+	manifests.GET("", h.GetManifest)
+	manifests.GET("/pdf", h.DownloadManifestPDF)
+	manifests.GET("/csv", h.DownloadManifestCSV)
+}
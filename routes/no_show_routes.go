@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoShowRoutes serve the no-show statistics of customers
+func NoShowRoutes(r *gin.RouterGroup, h *controllers.NoShowController) {
+	me := r.Group("/me")
+	me.Use(middleware.AuthMiddleware())
+	me.GET("/no-show-stats", h.GetMyNoShowStats)
+
+	admin := r.Group("/admin/users")
+	admin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_BOOKINGS_READ_ALL))
+	admin.GET("/:id/no-show-stats", h.GetUserNoShowStats)
+}
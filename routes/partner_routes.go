@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func PartnerRoutes(r *gin.RouterGroup, h *controllers.PartnerController) {
+	adminRoutes := r.Group("/admin/partners")
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_PARTNERS_MANAGE))
+	adminRoutes.GET("", h.GetPartners)
+	adminRoutes.POST("", h.CreatePartner)
+	adminRoutes.GET("/:id", h.GetPartnerByID)
+	adminRoutes.PUT("/:id", h.UpdatePartner)
+	adminRoutes.GET("/:id/api-keys", h.GetAPIKeys)
+	adminRoutes.POST("/:id/api-keys", h.CreateAPIKey)
+	adminRoutes.POST("/:id/api-keys/:keyId/rotate", h.RotateAPIKey)
+	adminRoutes.DELETE("/:id/api-keys/:keyId", h.RevokeAPIKey)
+	adminRoutes.GET("/:id/usage", h.GetUsage)
+	adminRoutes.GET("/:id/commission", h.GetCommission)
+}
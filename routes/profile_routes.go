@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ProfileRoutes(r *gin.RouterGroup, h *controllers.ProfileController) {
+	me := r.Group("/me")
+	me.Use(middleware.AuthMiddleware())
+	me.GET("", h.GetProfile)
+	me.PATCH("", h.UpdateProfile)
+	me.POST("/change-password", h.ChangePassword)
+	me.POST("/change-email", h.ChangeEmail)
+
+	// Saved passengers pre-fill bookings, so they need the permission to book
+	passengers := me.Group("/passengers")
+	passengers.Use(middleware.RequirePermission(constants.PERMISSION_BOOKINGS_CREATE))
+	passengers.GET("", h.GetSavedPassengers)
+	passengers.POST("", h.CreateSavedPassenger)
+	passengers.GET("/:id", h.GetSavedPassenger)
+	passengers.PUT("/:id", h.UpdateSavedPassenger)
+	passengers.DELETE("/:id", h.DeleteSavedPassenger)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ReconciliationRoutes(r *gin.RouterGroup, h *controllers.ReconciliationController) {
+	adminRoutes := r.Group("/admin/reconciliation")
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_RECONCILIATION))
+	adminRoutes.POST("/imports", h.ImportBankStatement)
+	adminRoutes.GET("/imports", h.GetImports)
+	adminRoutes.GET("/imports/:id", h.GetImportByID)
+	adminRoutes.GET("/entries", h.GetEntries)
+	adminRoutes.POST("/entries/:id/resolve", h.ResolveEntry)
+	adminRoutes.POST("/entries/:id/dismiss", h.DismissEntry)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RoleRoutes(r *gin.RouterGroup, h *controllers.RoleController) {
+	adminRoutes := r.Group("/admin")
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_ROLES_MANAGE))
+	adminRoutes.GET("/permissions", h.GetPermissions)
+	adminRoutes.GET("/roles", h.GetRoles)
+	adminRoutes.GET("/roles/:name", h.GetRoleByName)
+	adminRoutes.POST("/roles", h.CreateRole)
+	adminRoutes.PUT("/roles/:name", h.UpdateRole)
+	adminRoutes.DELETE("/roles/:name", h.DeleteRole)
+}
@@ -11,7 +11,7 @@ import (
 func RouteRoutes(r *gin.RouterGroup, h *controllers.RouteController) {
 
 	adminRoutes := r.Group("admin/routes")
-	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole(constants.ROLE_ADMIN))
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_ROUTES_WRITE))
 	adminRoutes.GET("", h.GetAllRoutes)
 	adminRoutes.GET("/:id", h.GetRouteByID)
 	adminRoutes.POST("", h.CreateRoute)
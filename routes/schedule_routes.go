@@ -10,15 +10,17 @@ import (
 
 func ScheduleRoutes(r *gin.RouterGroup, h *controllers.ScheduleController) {
 	userRoutes := r.Group("/schedules")
-	userRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole(constants.ROLE_USER))
+	userRoutes.Use(middleware.AuthMiddleware(middleware.AcceptAPIKeys), middleware.RequirePermission(constants.PERMISSION_SCHEDULES_READ, constants.PERMISSION_SCHEDULES_WRITE))
 	userRoutes.GET("/search", h.SearchSchedules)
 	userRoutes.GET("/:id", h.GetScheduleByID)
+	userRoutes.GET("/:id/seats/stream", h.StreamSeatAvailability)
 
 	adminRoutes := r.Group("admin/schedules")
-	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole(constants.ROLE_ADMIN))
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_SCHEDULES_WRITE))
 	adminRoutes.GET("", h.GetAllSchedules)
 	adminRoutes.POST("", h.CreateSchedule)
 	adminRoutes.GET("/:id", h.GetScheduleByID)
 	adminRoutes.PUT("/:id", h.UpdateSchedule)
 	adminRoutes.DELETE("/:id", h.DeleteSchedule)
+	adminRoutes.POST("/:id/cancel", h.CancelSchedule)
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TripRoutes serve drivers their assigned trips and let the back office assign drivers
+func TripRoutes(r *gin.RouterGroup, h *controllers.TripController) {
+	trips := r.Group("/driver/trips")
+	trips.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_TRIPS_DRIVE))
+	trips.GET("", h.GetUpcomingTrips)
+	trips.GET("/:id", h.GetTrip)
+	trips.GET("/:id/manifest", h.GetTripManifest)
+	trips.POST("/:id/boarding/scan", h.ScanBoardingPass)
+	trips.POST("/:id/start", h.StartTrip)
+	trips.POST("/:id/end", h.EndTrip)
+	trips.GET("/:id/incidents", h.GetTripIncidents)
+	trips.POST("/:id/incidents", h.ReportIncident)
+
+	admin := r.Group("/admin/schedules/:id")
+	admin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_SCHEDULES_WRITE))
+	admin.PUT("/driver", h.AssignDriver)
+	admin.GET("/incidents", h.GetScheduleIncidents)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TwoFactorRoutes(r *gin.RouterGroup, h *controllers.TwoFactorController) {
+	// Only AuthMiddleware, and it lets users who still have to enable two-factor authentication reach these
+	twoFactor := r.Group("/auth/2fa")
+	twoFactor.Use(middleware.AuthMiddleware(middleware.AllowTwoFactorSetup))
+	twoFactor.GET("", h.GetStatus)
+	twoFactor.POST("/setup", h.Setup)
+	twoFactor.POST("/enable", h.Enable)
+	twoFactor.POST("/disable", h.Disable)
+	twoFactor.POST("/recovery-codes", h.RegenerateRecoveryCodes)
+
+	adminRoutes := r.Group("/admin")
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_SECURITY_MANAGE))
+	adminRoutes.GET("/2fa/policies", h.GetPolicies)
+	adminRoutes.PUT("/2fa/policies/:role", h.UpdatePolicy)
+	adminRoutes.POST("/users/:id/2fa/reset", h.ResetUser)
+}
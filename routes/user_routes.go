@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"malakashuttle/constants"
 	"malakashuttle/controllers"
 	"malakashuttle/middleware"
 
@@ -10,7 +11,7 @@ import (
 func UserRoutes(router *gin.RouterGroup, userController *controllers.UserController) {
 	// Admin-only routes for user management
 	adminRoutes := router.Group("/admin")
-	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole("admin"))
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_USERS_MANAGE))
 	{
 		// User management routes
 		adminRoutes.GET("/users", userController.GetAllUsers)
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func VerificationRoutes(r *gin.RouterGroup, h *controllers.VerificationController) {
+	auth := r.Group("/auth")
+	// Opened from the emailed link, so no login is required
+	auth.POST("/verify-email", h.VerifyEmail)
+
+	verification := r.Group("/auth")
+	verification.Use(middleware.AuthMiddleware())
+	verification.GET("/verification", h.GetStatus)
+	verification.POST("/verification/resend", h.Resend)
+	verification.POST("/verify-phone", h.VerifyPhone)
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"malakashuttle/constants"
+	"malakashuttle/controllers"
+	"malakashuttle/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func WebhookRoutes(r *gin.RouterGroup, h *controllers.WebhookController) {
+	adminRoutes := r.Group("/admin/webhooks")
+	adminRoutes.Use(middleware.AuthMiddleware(), middleware.RequirePermission(constants.PERMISSION_WEBHOOKS_MANAGE))
+	adminRoutes.GET("/events", h.GetEvents)
+	adminRoutes.GET("", h.GetEndpoints)
+	adminRoutes.POST("", h.CreateEndpoint)
+	adminRoutes.GET("/:id", h.GetEndpoint)
+	adminRoutes.PUT("/:id", h.UpdateEndpoint)
+	adminRoutes.DELETE("/:id", h.DeleteEndpoint)
+	adminRoutes.POST("/:id/rotate-secret", h.RotateSecret)
+	adminRoutes.GET("/:id/deliveries", h.GetDeliveries)
+	adminRoutes.GET("/:id/deliveries/:deliveryId", h.GetDelivery)
+	adminRoutes.POST("/:id/deliveries/:deliveryId/redeliver", h.Redeliver)
+}
@@ -2,23 +2,61 @@
 package services
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"malakashuttle/config"
 	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/mailer"
 	"malakashuttle/repositories"
 	"malakashuttle/utils"
+	"net/url"
+	"time"
 )
 
 type AuthService interface {
 	Register(req dto.RegisterRequest) (*dto.RegisterResponse, error)
-	Login(req dto.LoginRequest) (*dto.LoginResponse, error)
+	Login(req dto.LoginRequest, client dto.ClientInfo) (*dto.LoginResponse, error)
+	Refresh(req dto.RefreshTokenRequest) (*dto.LoginResponse, error)
+	Logout(req dto.LogoutRequest) error
+	RevokeUserSessions(userID uint) (*dto.RevokeSessionsResponse, error)
+	ResolvePrincipal(claims *utils.JWTClaims) (*utils.Principal, error)
+	VerifyTwoFactor(req dto.VerifyTwoFactorLoginRequest, client dto.ClientInfo) (*dto.LoginResponse, error)
+	ForgotPassword(req dto.ForgotPasswordRequest, client dto.ClientInfo) error
+	ResetPassword(req dto.ResetPasswordRequest) error
 }
 
 type authService struct {
-	userRepo repositories.UserRepository
+	userRepo          repositories.UserRepository
+	sessionRepo       repositories.SessionRepository
+	passwordResetRepo repositories.PasswordResetRepository
+	mailSender        mailer.Sender
+	verificationSvc   *VerificationService
+	loginProtection   *LoginProtectionService
+	twoFactorSvc      *TwoFactorService
+	roleSvc           *RoleService
 }
 
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
+func NewAuthService(
+	userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionRepository,
+	passwordResetRepo repositories.PasswordResetRepository,
+	mailSender mailer.Sender,
+	verificationSvc *VerificationService,
+	loginProtection *LoginProtectionService,
+	twoFactorSvc *TwoFactorService,
+	roleSvc *RoleService,
+) AuthService {
 	return &authService{
-		userRepo: userRepo,
+		userRepo:          userRepo,
+		sessionRepo:       sessionRepo,
+		passwordResetRepo: passwordResetRepo,
+		mailSender:        mailSender,
+		verificationSvc:   verificationSvc,
+		loginProtection:   loginProtection,
+		twoFactorSvc:      twoFactorSvc,
+		roleSvc:           roleSvc,
 	}
 }
 
@@ -42,33 +80,320 @@ func (s *authService) Register(req dto.RegisterRequest) (*dto.RegisterResponse,
 		return nil, utils.NewInternalServerError("Failed to create user", err)
 	}
 
+	// Send the email link and phone code; the user can request them again if sending fails
+	if err := s.verificationSvc.SendInitialVerifications(userEntity); err != nil {
+		log.Printf("Failed to send verification codes to user #%d: %v", userEntity.ID, err)
+	}
+
 	// Prepare response using DTO mapping
 	response := dto.NewRegisterResponseFromEntity(userEntity)
 
 	return response, nil
 }
 
-func (s *authService) Login(req dto.LoginRequest) (*dto.LoginResponse, error) {
+func (s *authService) Login(req dto.LoginRequest, client dto.ClientInfo) (*dto.LoginResponse, error) {
+	// Reject attempts while the account or IP is locked or throttled
+	if err := s.loginProtection.CheckAllowed(req.Email, client); err != nil {
+		return nil, err
+	}
+
 	// Find user by email
 	userEntity, err := s.userRepo.FindByEmail(req.Email)
 	if err != nil {
+		s.loginProtection.RecordFailure(req.Email, nil, client, entities.LoginFailureUnknownEmail)
 		return nil, utils.NewUnauthorizedError("Invalid email or password", nil)
 	}
 
 	// Check password
 	if err := userEntity.CheckPassword(req.Password); err != nil {
+		s.loginProtection.RecordFailure(req.Email, &userEntity.ID, client, entities.LoginFailureWrongPassword)
 		return nil, utils.NewUnauthorizedError("Invalid email or password", nil)
 	}
-	// Generate token
-	token, err := utils.GenerateToken(userEntity.Email, userEntity.Role)
+
+	// Accounts with two-factor authentication get a challenge instead of tokens
+	if userEntity.IsTwoFactorEnabled() {
+		challengeToken, err := utils.GenerateTwoFactorChallengeToken(userEntity.ID)
+		if err != nil {
+			return nil, utils.NewInternalServerError("Failed to generate challenge token", err)
+		}
+		return &dto.LoginResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+		}, nil
+	}
+
+	s.loginProtection.RecordSuccess(req.Email)
+	return s.startSession(userEntity, client)
+}
+
+// VerifyTwoFactor completes a login of a two-factor account with a TOTP or recovery code
+func (s *authService) VerifyTwoFactor(req dto.VerifyTwoFactorLoginRequest, client dto.ClientInfo) (*dto.LoginResponse, error) {
+	if (req.Code == "") == (req.RecoveryCode == "") {
+		return nil, utils.NewBadRequestError("Provide either code or recovery_code", nil)
+	}
+
+	userID, err := utils.ValidateTwoFactorChallengeToken(req.ChallengeToken)
+	if err != nil {
+		return nil, utils.NewUnauthorizedError("Invalid or expired challenge token, please login again", nil)
+	}
+
+	userEntity, err := s.userRepo.FindByID(userID)
+	if err != nil || !userEntity.IsTwoFactorEnabled() {
+		return nil, utils.NewUnauthorizedError("Invalid or expired challenge token, please login again", nil)
+	}
+
+	// Wrong codes count towards the same lockout as wrong passwords
+	if err := s.loginProtection.CheckAllowed(userEntity.Email, client); err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorSvc.VerifyLoginCode(userEntity, req.Code, req.RecoveryCode); err != nil {
+		var customErr *utils.CustomError
+		if errors.As(err, &customErr) && customErr.StatusCode == 401 {
+			s.loginProtection.RecordFailure(userEntity.Email, &userEntity.ID, client, entities.LoginFailureTwoFactorCode)
+		}
+		return nil, err
+	}
+
+	s.loginProtection.RecordSuccess(userEntity.Email)
+	return s.startSession(userEntity, client)
+}
+
+// startSession creates a new session with its first refresh token and issues the tokens
+func (s *authService) startSession(userEntity *entities.User, client dto.ClientInfo) (*dto.LoginResponse, error) {
+	refreshToken, refreshTokenEntity, err := newRefreshToken()
 	if err != nil {
 		return nil, utils.NewInternalServerError("Failed to generate token", err)
 	}
+	session := &entities.UserSession{
+		UserID:     userEntity.ID,
+		UserAgent:  truncate(client.UserAgent, 255),
+		IPAddress:  truncate(client.IPAddress, 45),
+		LastUsedAt: time.Now(),
+	}
+	if err := s.sessionRepo.CreateSession(session, refreshTokenEntity); err != nil {
+		return nil, utils.NewInternalServerError("Failed to create session", err)
+	}
 
-	// Prepare response
-	response := &dto.LoginResponse{
-		Token: token,
+	response, err := newLoginResponse(userEntity, session.ID, refreshToken)
+	if err != nil {
+		return nil, err
 	}
 
+	setupRequired, err := s.twoFactorSvc.IsSetupRequired(userEntity)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get two-factor policy", err)
+	}
+	response.TwoFactorSetupRequired = setupRequired
 	return response, nil
 }
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token.
+// Each refresh token can only be used once; reusing one revokes the whole session.
+func (s *authService) Refresh(req dto.RefreshTokenRequest) (*dto.LoginResponse, error) {
+	refreshToken, refreshTokenEntity, err := newRefreshToken()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to generate token", err)
+	}
+
+	session, err := s.sessionRepo.RotateRefreshToken(utils.HashOpaqueToken(req.RefreshToken), refreshTokenEntity)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrRefreshTokenReused):
+			return nil, utils.NewUnauthorizedError("Refresh token has already been used, all tokens of this session have been revoked", nil)
+		case errors.Is(err, repositories.ErrRefreshTokenNotFound), errors.Is(err, repositories.ErrSessionRevoked):
+			return nil, utils.NewUnauthorizedError("Invalid or expired refresh token", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to refresh token", err)
+	}
+
+	userEntity, err := s.userRepo.FindByID(session.UserID)
+	if err != nil {
+		return nil, utils.NewUnauthorizedError("Invalid or expired refresh token", nil)
+	}
+
+	return newLoginResponse(userEntity, session.ID, refreshToken)
+}
+
+// Logout revokes the session the refresh token belongs to
+func (s *authService) Logout(req dto.LogoutRequest) error {
+	token, err := s.sessionRepo.FindRefreshToken(utils.HashOpaqueToken(req.RefreshToken))
+	if err != nil {
+		return utils.NewUnauthorizedError("Invalid refresh token", nil)
+	}
+
+	if !token.Session.IsActive() {
+		return nil
+	}
+
+	if err := s.sessionRepo.RevokeSession(token.SessionID, entities.SessionRevokedLogout); err != nil {
+		return utils.NewInternalServerError("Failed to logout", err)
+	}
+
+	return nil
+}
+
+// RevokeUserSessions revokes every active session of a user (for admin)
+func (s *authService) RevokeUserSessions(userID uint) (*dto.RevokeSessionsResponse, error) {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return nil, utils.NewNotFoundError("User not found", nil)
+	}
+
+	revoked, err := s.sessionRepo.RevokeAllForUser(userID, entities.SessionRevokedByAdmin)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to revoke sessions", err)
+	}
+
+	return &dto.RevokeSessionsResponse{
+		UserID:          userID,
+		RevokedSessions: revoked,
+	}, nil
+}
+
+// ResolvePrincipal loads the user behind an access token. The token is rejected when its
+// session has been revoked or the user's token version has changed since it was issued.
+func (s *authService) ResolvePrincipal(claims *utils.JWTClaims) (*utils.Principal, error) {
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, err
+	}
+
+	userEntity, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.New("user no longer exists")
+	}
+	if userEntity.TokenVersion != claims.TokenVersion {
+		return nil, errors.New("token has been invalidated")
+	}
+
+	if claims.SessionID == 0 {
+		return nil, errors.New("token is not bound to a session")
+	}
+	if _, err := s.sessionRepo.FindActiveSession(claims.SessionID); err != nil {
+		return nil, errors.New("session has been revoked")
+	}
+
+	setupRequired, err := s.twoFactorSvc.IsSetupRequired(userEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.roleSvc.GetPermissions(userEntity.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &utils.Principal{
+		UserID:                 userEntity.ID,
+		Email:                  userEntity.Email,
+		Role:                   userEntity.Role,
+		SessionID:              claims.SessionID,
+		TwoFactorSetupRequired: setupRequired,
+		Permissions:            permissions,
+		Locale:                 userEntity.Locale,
+	}, nil
+}
+
+// ForgotPassword emails a password reset link. It behaves the same whether or not the email
+// is registered, so it cannot be used to find out which emails have an account.
+func (s *authService) ForgotPassword(req dto.ForgotPasswordRequest, client dto.ClientInfo) error {
+	userEntity, err := s.userRepo.FindByEmail(req.Email)
+	if err != nil {
+		return nil
+	}
+
+	limit, window := config.GetPasswordResetRateLimit()
+	recent, err := s.passwordResetRepo.CountRecentTokens(userEntity.ID, time.Now().Add(-window))
+	if err != nil {
+		return utils.NewInternalServerError("Failed to process password reset request", err)
+	}
+	if recent >= int64(limit) {
+		log.Printf("Password reset rate limit reached for user #%d", userEntity.ID)
+		return nil
+	}
+
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return utils.NewInternalServerError("Failed to generate reset token", err)
+	}
+
+	ttl := config.GetPasswordResetTokenTTL()
+	resetToken := &entities.PasswordResetToken{
+		UserID:      userEntity.ID,
+		TokenHash:   hash,
+		ExpiresAt:   time.Now().Add(ttl),
+		RequestedIP: truncate(client.IPAddress, 45),
+	}
+	if err := s.passwordResetRepo.CreateToken(resetToken); err != nil {
+		return utils.NewInternalServerError("Failed to create reset token", err)
+	}
+
+	msg := mailer.Message{
+		To:      userEntity.Email,
+		Subject: "Reset your Malaka Shuttle password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Open the link below to choose a new password:\n\n%s/reset-password?token=%s\n\nThe link expires in %d minutes and can only be used once. If you did not request a password reset, you can ignore this email.\n\nMalaka Shuttle",
+			userEntity.FirstName,
+			config.GetFrontendURL(),
+			url.QueryEscape(token),
+			int(ttl.Minutes()),
+		),
+	}
+
+	// Send in the background so the response time does not reveal whether the email exists
+	go func() {
+		if err := s.mailSender.Send(msg); err != nil {
+			log.Printf("Failed to send password reset email to user #%d: %v", resetToken.UserID, err)
+		}
+	}()
+
+	return nil
+}
+
+// ResetPassword sets a new password using a reset token and logs the user out everywhere
+func (s *authService) ResetPassword(req dto.ResetPasswordRequest) error {
+	hashed := &entities.User{Password: req.NewPassword}
+	if err := hashed.HashPassword(); err != nil {
+		return utils.NewInternalServerError("Failed to hash password", err)
+	}
+
+	if _, err := s.passwordResetRepo.ResetPassword(utils.HashOpaqueToken(req.Token), hashed.Password); err != nil {
+		if errors.Is(err, repositories.ErrPasswordResetTokenInvalid) {
+			return utils.NewBadRequestError("Password reset token is invalid or expired", nil)
+		}
+		return utils.NewInternalServerError("Failed to reset password", err)
+	}
+
+	return nil
+}
+
+func newRefreshToken() (string, *entities.RefreshToken, error) {
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+	return token, &entities.RefreshToken{
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(config.GetRefreshTokenDuration()),
+	}, nil
+}
+
+func newLoginResponse(user *entities.User, sessionID uint, refreshToken string) (*dto.LoginResponse, error) {
+	token, err := utils.GenerateToken(user, sessionID)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to generate token", err)
+	}
+
+	return &dto.LoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(config.GetJWTDuration().Seconds()),
+	}, nil
+}
+
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
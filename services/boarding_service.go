@@ -0,0 +1,252 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/qrcode"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// boardingPassScale is the width of a QR module in pixels in the boarding pass image
+const boardingPassScale = 8
+
+// BoardingService issues the boarding passes of paid bookings and boards passengers
+// when their pass is scanned
+type BoardingService struct {
+	boardingRepo repositories.BoardingRepository
+	bookingRepo  *repositories.BookingRepository
+}
+
+func NewBoardingService(boardingRepo repositories.BoardingRepository, bookingRepo *repositories.BookingRepository) *BoardingService {
+	return &BoardingService{
+		boardingRepo: boardingRepo,
+		bookingRepo:  bookingRepo,
+	}
+}
+
+// GetBoardingPassImage returns the QR code of a passenger's boarding pass as PNG.
+// userID limits the lookup to the bookings of the user.
+func (s *BoardingService) GetBoardingPassImage(bookingID, passengerID uint, userID *uint) ([]byte, error) {
+	booking, err := s.bookingRepo.GetBookingByID(bookingID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Booking not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get booking", err)
+	}
+	if booking.Status != entities.BookingStatusSuccess {
+		return nil, utils.NewBadRequestError("Boarding passes are only available for paid bookings", nil)
+	}
+
+	for _, detail := range booking.BookingDetails {
+		if detail.ID != passengerID {
+			continue
+		}
+		code, err := signBoardingPass(booking, &detail)
+		if err != nil {
+			return nil, utils.NewInternalServerError("Failed to generate boarding pass", err)
+		}
+		qr, err := qrcode.Encode(code)
+		if err != nil {
+			return nil, utils.NewInternalServerError("Failed to generate boarding pass", err)
+		}
+		image, err := qr.PNG(boardingPassScale)
+		if err != nil {
+			return nil, utils.NewInternalServerError("Failed to generate boarding pass", err)
+		}
+		return image, nil
+	}
+	return nil, utils.NewNotFoundError("Passenger not found", nil)
+}
+
+// Scan checks a scanned boarding pass against the schedule that is boarding and boards the passenger.
+// Forged codes, codes of other schedules and passengers who already boarded are rejected.
+func (s *BoardingService) Scan(req dto.ScanBoardingPassRequest, scannedByID uint) (*dto.BoardingScanResponse, error) {
+	detail, err := s.scannedPassenger(req.ScheduleID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if detail.BoardedAt != nil {
+		return nil, alreadyBoardedError(*detail.BoardedAt)
+	}
+
+	now := time.Now()
+	boarded, err := s.boardingRepo.MarkBoarded(detail.ID, scannedByID, now)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to board passenger", err)
+	}
+	if !boarded {
+		// Another scanner boarded the passenger in the meantime
+		if current, err := s.boardingRepo.FindPassenger(detail.ID); err == nil && current.BoardedAt != nil {
+			return nil, alreadyBoardedError(*current.BoardedAt)
+		}
+		return nil, utils.NewConflictError("Passenger has already boarded", nil)
+	}
+
+	return &dto.BoardingScanResponse{
+		BookingID:     detail.BookingID,
+		PassengerID:   detail.ID,
+		PassengerName: detail.PassengerName,
+		SeatNumber:    detail.Seat.SeatNumber,
+		ScheduleID:    detail.Booking.ScheduleID,
+		BoardedAt:     now,
+	}, nil
+}
+
+// CheckIn checks a passenger in at the pool with their boarding pass. Passengers who already
+// checked in or boarded are rejected.
+func (s *BoardingService) CheckIn(req dto.CheckInRequest) (*dto.CheckInResponse, error) {
+	detail, err := s.scannedPassenger(req.ScheduleID, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if detail.BoardedAt != nil {
+		return nil, alreadyBoardedError(*detail.BoardedAt)
+	}
+	if detail.CheckedInAt != nil {
+		return nil, alreadyCheckedInError(*detail.CheckedInAt)
+	}
+
+	now := time.Now()
+	checkedIn, err := s.boardingRepo.MarkCheckedIn(detail.ID, now)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to check in passenger", err)
+	}
+	if !checkedIn {
+		if current, err := s.boardingRepo.FindPassenger(detail.ID); err == nil && current.CheckedInAt != nil {
+			return nil, alreadyCheckedInError(*current.CheckedInAt)
+		}
+		return nil, utils.NewConflictError("Passenger has already checked in", nil)
+	}
+
+	return &dto.CheckInResponse{
+		BookingID:     detail.BookingID,
+		PassengerID:   detail.ID,
+		PassengerName: detail.PassengerName,
+		SeatNumber:    detail.Seat.SeatNumber,
+		ScheduleID:    detail.Booking.ScheduleID,
+		CheckedInAt:   now,
+	}, nil
+}
+
+// UpdateBoardingStatus corrects the boarding state of a passenger of a paid booking by hand,
+// for example when a no-show was marked for a passenger who did travel
+func (s *BoardingService) UpdateBoardingStatus(passengerID uint, req dto.UpdateBoardingStatusRequest, changedByID uint) (*dto.BoardingStatusResponse, error) {
+	detail, err := s.boardingRepo.FindPassenger(passengerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Passenger not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get passenger", err)
+	}
+	if detail.Booking.Status != entities.BookingStatusSuccess {
+		return nil, utils.NewConflictErrorWithDetails("Booking is not valid for boarding", nil, map[string]interface{}{
+			"status": detail.Booking.Status,
+		})
+	}
+
+	if err := s.boardingRepo.SetBoardingStatus(detail.ID, req.Status, changedByID, time.Now()); err != nil {
+		return nil, utils.NewInternalServerError("Failed to update boarding status", err)
+	}
+	updated, err := s.boardingRepo.FindPassenger(detail.ID)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get passenger", err)
+	}
+
+	return &dto.BoardingStatusResponse{
+		PassengerID:    updated.ID,
+		BookingID:      updated.BookingID,
+		BoardingStatus: updated.BoardingStatus(),
+		CheckedInAt:    updated.CheckedInAt,
+		BoardedAt:      updated.BoardedAt,
+		NoShowAt:       updated.NoShowAt,
+	}, nil
+}
+
+// scannedPassenger verifies a scanned boarding pass against the schedule that is boarding and returns
+// the passenger, as long as their booking is paid and the schedule still runs
+func (s *BoardingService) scannedPassenger(scheduleID uint, code string) (*entities.BookingDetail, error) {
+	payload, err := utils.VerifyBoardingPass(code)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidBoardingPass) {
+			return nil, utils.NewBadRequestError("Invalid boarding pass", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to verify boarding pass", err)
+	}
+	if payload.ScheduleID != scheduleID {
+		return nil, utils.NewConflictErrorWithDetails("Boarding pass is for another schedule", nil, map[string]interface{}{
+			"schedule_id": payload.ScheduleID,
+		})
+	}
+
+	detail, err := s.boardingRepo.FindPassenger(payload.PassengerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Passenger not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get passenger", err)
+	}
+	// A signed code only stays valid while it matches the booking
+	if detail.BookingID != payload.BookingID || detail.Booking.ScheduleID != payload.ScheduleID || detail.Seat.SeatNumber != payload.SeatNumber {
+		return nil, utils.NewBadRequestError("Invalid boarding pass", nil)
+	}
+	if detail.Booking.Status != entities.BookingStatusSuccess {
+		return nil, utils.NewConflictErrorWithDetails("Booking is not valid for boarding", nil, map[string]interface{}{
+			"status": detail.Booking.Status,
+		})
+	}
+	if detail.Booking.Schedule.CancelledAt != nil {
+		return nil, utils.NewConflictError("Schedule is cancelled", nil)
+	}
+	return detail, nil
+}
+
+func alreadyBoardedError(boardedAt time.Time) error {
+	return utils.NewConflictErrorWithDetails("Passenger has already boarded", nil, map[string]interface{}{
+		"boarded_at": boardedAt,
+	})
+}
+
+func alreadyCheckedInError(checkedInAt time.Time) error {
+	return utils.NewConflictErrorWithDetails("Passenger has already checked in", nil, map[string]interface{}{
+		"checked_in_at": checkedInAt,
+	})
+}
+
+// boardingPasses signs the boarding passes of every passenger of a booking. The booking needs
+// its details and their seats loaded.
+func boardingPasses(booking *entities.Booking) ([]utils.BoardingPass, error) {
+	passes := make([]utils.BoardingPass, 0, len(booking.BookingDetails))
+	for i := range booking.BookingDetails {
+		detail := &booking.BookingDetails[i]
+		code, err := signBoardingPass(booking, detail)
+		if err != nil {
+			return nil, err
+		}
+		passes = append(passes, utils.BoardingPass{
+			PassengerName: detail.PassengerName,
+			SeatNumber:    detail.Seat.SeatNumber,
+			Code:          code,
+		})
+	}
+	return passes, nil
+}
+
+func signBoardingPass(booking *entities.Booking, detail *entities.BookingDetail) (string, error) {
+	code, err := utils.SignBoardingPass(utils.BoardingPassPayload{
+		BookingID:   booking.ID,
+		PassengerID: detail.ID,
+		ScheduleID:  booking.ScheduleID,
+		SeatNumber:  detail.Seat.SeatNumber,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign boarding pass of passenger #%d: %w", detail.ID, err)
+	}
+	return code, nil
+}
@@ -3,14 +3,17 @@ package services
 import (
 	"errors"
 	"fmt"
-	"io"
+	"math"
 	"mime/multipart"
 	"os"
 	"path/filepath"
 	"time"
 
+	"malakashuttle/config"
+	"malakashuttle/constants"
 	"malakashuttle/dto"
 	"malakashuttle/entities"
+	"malakashuttle/i18n"
 	"malakashuttle/repositories"
 	"malakashuttle/utils"
 
@@ -18,30 +21,55 @@ import (
 )
 
 type BookingService struct {
-	bookingRepo  *repositories.BookingRepository
-	scheduleRepo *repositories.ScheduleRepository
-	userRepo     repositories.UserRepository
+	bookingRepo        *repositories.BookingRepository
+	scheduleRepo       *repositories.ScheduleRepository
+	userRepo           repositories.UserRepository
+	savedPassengerRepo repositories.SavedPassengerRepository
+	notificationSvc    *NotificationService
+	webhookSvc         *WebhookService
+	seatSvc            *SeatAvailabilityService
+	noShowSvc          *NoShowService
 }
 
 func NewBookingService(
 	bookingRepo *repositories.BookingRepository,
 	scheduleRepo *repositories.ScheduleRepository,
 	userRepo repositories.UserRepository,
+	savedPassengerRepo repositories.SavedPassengerRepository,
+	notificationSvc *NotificationService,
+	webhookSvc *WebhookService,
+	seatSvc *SeatAvailabilityService,
+	noShowSvc *NoShowService,
 ) *BookingService {
 	return &BookingService{
-		bookingRepo:  bookingRepo,
-		scheduleRepo: scheduleRepo,
-		userRepo:     userRepo,
+		bookingRepo:        bookingRepo,
+		scheduleRepo:       scheduleRepo,
+		userRepo:           userRepo,
+		savedPassengerRepo: savedPassengerRepo,
+		notificationSvc:    notificationSvc,
+		webhookSvc:         webhookSvc,
+		seatSvc:            seatSvc,
+		noShowSvc:          noShowSvc,
 	}
 }
 
-// CreateBooking creates a new booking
-func (s *BookingService) CreateBooking(userEmail string, req dto.CreateBookingRequest) (*dto.BookingResponse, error) {
-	// Get user by email first
-	user, err := s.userRepo.FindByEmail(userEmail)
+// CreateBooking creates a new booking. Bookings made with a partner API key are attributed to the partner.
+func (s *BookingService) CreateBooking(userID uint, req dto.CreateBookingRequest, attribution *dto.BookingAttribution) (*dto.BookingResponse, error) {
+	// Unverified accounts cannot hold seats
+	user, err := s.userRepo.FindByID(userID)
 	if err != nil {
 		return nil, errors.New("user not found")
 	}
+	if !user.IsVerified() {
+		return nil, errors.New("account is not verified: verify your email and phone number before booking")
+	}
+	// Customers who keep missing their trips are limited or blocked. Partner bookings are made for
+	// the agent's own customers, so the agent account is not held to it.
+	if attribution == nil {
+		if err := s.noShowSvc.CheckBookingPolicy(userID, len(req.Passengers)); err != nil {
+			return nil, err
+		}
+	}
 
 	// Validate schedule exists and is available
 	schedule, err := s.scheduleRepo.GetScheduleByID(req.ScheduleID)
@@ -51,11 +79,21 @@ func (s *BookingService) CreateBooking(userEmail string, req dto.CreateBookingRe
 		}
 		return nil, err
 	}
-	// Check if schedule is in the future
-	if schedule.DepartureTime.Before(time.Now()) {
+	if schedule.CancelledAt != nil {
+		return nil, errors.New("schedule has been cancelled")
+	}
+	// Check if schedule is in the future and its driver has not set off yet
+	if schedule.DepartureTime.Before(time.Now()) || schedule.Status != entities.ScheduleStatusScheduled {
 		return nil, errors.New("cannot book past schedule")
 	}
 
+	// Fill in passengers picked from the user's saved passengers and validate their identity
+	passengers, err := s.resolvePassengers(userID, req.Passengers)
+	if err != nil {
+		return nil, err
+	}
+	req.Passengers = passengers
+
 	// Validate no duplicate seat IDs in the same booking
 	seatMap := make(map[uint]bool)
 	for _, passenger := range req.Passengers {
@@ -75,55 +113,114 @@ func (s *BookingService) CreateBooking(userEmail string, req dto.CreateBookingRe
 	if err != nil {
 		return nil, err
 	}
-	// Calculate total amount based on number of passengers and ticket price
+	// Calculate total amount based on number of passengers and ticket price,
+	// the repository adds the unique payment code on top of it
 	totalAmount := float64(len(req.Passengers)) * schedule.Price
 	// Create booking
 	booking := &entities.Booking{
-		UserID:        user.ID,
+		UserID:        userID,
 		ScheduleID:    req.ScheduleID,
 		BookingTime:   time.Now(),
 		Status:        entities.BookingStatusPending,
 		ExpiresAt:     time.Now().Add(30 * time.Minute), // 30 minutes expiry
 		PaymentAmount: totalAmount,
 	}
+	if attribution != nil {
+		booking.PartnerID = &attribution.PartnerID
+		booking.PartnerCommission = math.Round(totalAmount*attribution.CommissionRate) / 100
+	}
 
 	// Create booking details
 	bookingDetails := make([]entities.BookingDetail, len(req.Passengers))
 
 	for i, passenger := range req.Passengers {
+		identity, err := encryptPassengerIdentity(passenger.PassengerIdentityRequest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt passenger identity: %w", err)
+		}
 		bookingDetails[i] = entities.BookingDetail{
 			SeatID:        passenger.SeatID,
 			PassengerName: passenger.PassengerName,
 			Price:         schedule.Price, // Assuming all seats have same price
+			Identity:      identity,
 		}
 	}
 
-	// Create booking in database
-	err = s.bookingRepo.CreateBooking(booking, bookingDetails)
+	// Create booking in database, the customer and webhooks are notified once it is committed
+	err = s.bookingRepo.CreateBooking(booking, bookingDetails, config.GetPaymentUniqueCodeMax(), repositories.CombineOutbox(
+		s.notificationSvc.BookingOutbox(entities.NotificationEventBookingCreated),
+		s.webhookSvc.BookingOutbox(entities.WebhookEventBookingCreated),
+	))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create booking: %w", err)
-	} // Get created booking with relations
-	createdBooking, err := s.bookingRepo.GetBookingByID(booking.ID, &user.ID)
+	}
+	s.seatSvc.PublishSeatChanges(dto.SeatChangeBookingCreated, booking.ScheduleID)
+
+	// Get created booking with relations
+	createdBooking, err := s.bookingRepo.GetBookingByID(booking.ID, &userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return dto.NewBookingResponseFromEntity(createdBooking), nil
+	return newBookingResponse(createdBooking, false)
 }
 
-// GetBookingByID gets booking by ID
-func (s *BookingService) GetBookingByID(id uint, userEmail *string) (*dto.BookingResponse, error) {
-	var userIDPtr *uint
-	if userEmail != nil {
-		// Get user by email first
-		user, err := s.userRepo.FindByEmail(*userEmail)
+// resolvePassengers fills the name and identity of passengers that reference a saved passenger
+// of the user, then validates and normalizes the identity details
+func (s *BookingService) resolvePassengers(userID uint, passengers []dto.BookingPassenger) ([]dto.BookingPassenger, error) {
+	var savedIDs []uint
+	for _, passenger := range passengers {
+		if passenger.SavedPassengerID != nil {
+			savedIDs = append(savedIDs, *passenger.SavedPassengerID)
+		}
+	}
+
+	saved, err := s.savedPassengerRepo.FindByIDs(savedIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	savedByID := make(map[uint]entities.SavedPassenger, len(saved))
+	for _, passenger := range saved {
+		savedByID[passenger.ID] = passenger
+	}
+
+	requireIdentity := config.IsPassengerIdentityRequired()
+	resolved := make([]dto.BookingPassenger, len(passengers))
+	for i, passenger := range passengers {
+		if passenger.SavedPassengerID != nil {
+			savedPassenger, ok := savedByID[*passenger.SavedPassengerID]
+			if !ok {
+				return nil, fmt.Errorf("saved passenger %d not found", *passenger.SavedPassengerID)
+			}
+			if passenger.PassengerName == "" {
+				passenger.PassengerName = savedPassenger.Name
+			}
+			savedIdentity, err := decryptPassengerIdentity(savedPassenger.PassengerIdentity)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read saved passenger: %w", err)
+			}
+			passenger.PassengerIdentityRequest = mergePassengerIdentity(passenger.PassengerIdentityRequest, savedIdentity)
+		}
+		if passenger.PassengerName == "" {
+			return nil, errors.New("invalid passenger: passenger_name or saved_passenger_id is required")
+		}
+
+		identity, err := normalizePassengerIdentity(passenger.PassengerIdentityRequest)
 		if err != nil {
-			return nil, errors.New("user not found")
+			return nil, err
+		}
+		if requireIdentity && !isCompletePassengerIdentity(identity) {
+			return nil, fmt.Errorf("invalid passenger identity: id_type, id_number, phone and gender are required for %s", passenger.PassengerName)
 		}
-		userIDPtr = &user.ID
+		passenger.PassengerIdentityRequest = identity
+		resolved[i] = passenger
 	}
+	return resolved, nil
+}
 
-	booking, err := s.bookingRepo.GetBookingByID(id, userIDPtr)
+// GetBookingByID gets booking by ID
+func (s *BookingService) GetBookingByID(id uint, userID *uint) (*dto.BookingResponse, error) {
+	booking, err := s.bookingRepo.GetBookingByID(id, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("booking not found")
@@ -132,21 +229,29 @@ func (s *BookingService) GetBookingByID(id uint, userEmail *string) (*dto.Bookin
 		return nil, errors.New("failed to retrieve booking")
 	}
 
-	return dto.NewBookingResponseFromEntity(booking), nil
+	return newBookingResponse(booking, false)
 }
 
-// GetBookingDetailByID gets detailed booking by ID
-func (s *BookingService) GetBookingDetailByID(id uint, userEmail *string) (*dto.BookingFullResponse, error) {
-	var userIDPtr *uint
-	if userEmail != nil {
-		// Get user by email first
-		user, err := s.userRepo.FindByEmail(*userEmail)
+// newBookingResponse maps a booking including the decrypted identity of its passengers
+func newBookingResponse(booking *entities.Booking, masked bool) (*dto.BookingResponse, error) {
+	response := dto.NewBookingResponseFromEntity(booking)
+	for i, detail := range booking.BookingDetails {
+		identity, err := decryptPassengerIdentity(detail.Identity)
 		if err != nil {
-			return nil, errors.New("user not found")
+			return nil, fmt.Errorf("failed to read passenger identity: %w", err)
+		}
+		if identity != nil && masked {
+			maskedIdentity := identity.Masked()
+			identity = &maskedIdentity
 		}
-		userIDPtr = &user.ID
+		response.Passengers[i].Identity = identity
 	}
-	booking, err := s.bookingRepo.GetBookingByID(id, userIDPtr)
+	return response, nil
+}
+
+// GetBookingDetailByID gets detailed booking by ID
+func (s *BookingService) GetBookingDetailByID(id uint, userID *uint) (*dto.BookingFullResponse, error) {
+	booking, err := s.bookingRepo.GetBookingByID(id, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("booking not found")
@@ -155,27 +260,33 @@ func (s *BookingService) GetBookingDetailByID(id uint, userEmail *string) (*dto.
 		return nil, errors.New("failed to retrieve booking")
 	}
 
-	return dto.NewBookingFullResponseFromEntity(booking), nil
+	response := dto.NewBookingFullResponseFromEntity(booking)
+	for i, detail := range booking.BookingDetails {
+		identity, err := decryptPassengerIdentity(detail.Identity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passenger identity: %w", err)
+		}
+		response.PassengerDetails[i].Identity = identity
+	}
+	return response, nil
 }
 
 // GetUserBookings gets all bookings for a user
 // GetUserBookings gets all bookings for a user
-func (s *BookingService) GetUserBookings(userEmail string, params utils.PaginationParams, status []entities.BookingStatus) (*utils.PaginationResponse, error) {
-	// Get user by email first
-	user, err := s.userRepo.FindByEmail(userEmail)
-	if err != nil {
-		return nil, errors.New("user not found")
-	}
-
-	bookings, total, err := s.bookingRepo.GetBookingsByUserID(user.ID, params.Page, params.Limit, status)
+func (s *BookingService) GetUserBookings(userID uint, params utils.PaginationParams, status []entities.BookingStatus) (*utils.PaginationResponse, error) {
+	bookings, total, err := s.bookingRepo.GetBookingsByUserID(userID, params.Page, params.Limit, status)
 	if err != nil {
 		return nil, err
 	}
 
-	// Map to response DTOs
+	// Map to response DTOs, identity details are masked in lists
 	data := make([]dto.BookingResponse, len(bookings))
-	for i, booking := range bookings {
-		data[i] = *dto.NewBookingResponseFromEntity(&booking)
+	for i := range bookings {
+		bookingResponse, err := newBookingResponse(&bookings[i], true)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = *bookingResponse
 	}
 
 	response := utils.CreatePaginationResponse(data, total, params)
@@ -183,14 +294,8 @@ func (s *BookingService) GetUserBookings(userEmail string, params utils.Paginati
 }
 
 // GetUserBookingsList gets all bookings for a user with list view response format
-func (s *BookingService) GetUserBookingsList(userEmail string, params utils.PaginationParams, status []entities.BookingStatus) (*utils.PaginationResponse, error) {
-	// Get user by email first
-	user, err := s.userRepo.FindByEmail(userEmail)
-	if err != nil {
-		return nil, errors.New("user not found")
-	}
-
-	bookings, total, err := s.bookingRepo.GetBookingsByUserID(user.ID, params.Page, params.Limit, status)
+func (s *BookingService) GetUserBookingsList(userID uint, params utils.PaginationParams, status []entities.BookingStatus) (*utils.PaginationResponse, error) {
+	bookings, total, err := s.bookingRepo.GetBookingsByUserID(userID, params.Page, params.Limit, status)
 	if err != nil {
 		return nil, err
 	}
@@ -212,10 +317,14 @@ func (s *BookingService) GetAllBookings(params utils.PaginationParams, status []
 		return nil, err
 	}
 
-	// Map to response DTOs
+	// Map to response DTOs, identity details are masked in lists
 	data := make([]dto.BookingResponse, len(bookings))
-	for i, booking := range bookings {
-		data[i] = *dto.NewBookingResponseFromEntity(&booking)
+	for i := range bookings {
+		bookingResponse, err := newBookingResponse(&bookings[i], true)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = *bookingResponse
 	}
 
 	response := utils.CreatePaginationResponse(data, total, params)
@@ -240,14 +349,9 @@ func (s *BookingService) GetAllBookingsList(params utils.PaginationParams, statu
 }
 
 // UploadPaymentProof uploads payment proof
-func (s *BookingService) UploadPaymentProof(bookingID uint, userEmail string, file *multipart.FileHeader, paymentMethod string) error {
-	// Get user by email first
-	user, err := s.userRepo.FindByEmail(userEmail)
-	if err != nil {
-		return errors.New("user not found")
-	}
+func (s *BookingService) UploadPaymentProof(bookingID uint, userID uint, file *multipart.FileHeader, paymentMethod string) error {
 	// Check if booking exists and belongs to user
-	booking, err := s.bookingRepo.GetBookingForPayment(bookingID, user.ID)
+	booking, err := s.bookingRepo.GetBookingForPayment(bookingID, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("booking not found or not eligible for payment")
@@ -270,12 +374,26 @@ func (s *BookingService) UploadPaymentProof(bookingID uint, userEmail string, fi
 		return errors.New("payment proof already uploaded")
 	}
 
-	// Save file (implement file upload logic)
-	filename := fmt.Sprintf("payment_%d_%d%s", bookingID, time.Now().Unix(), filepath.Ext(file.Filename))
+	// Validate the file by its content and strip metadata before storing it
+	proof, err := sanitizePaymentProof(file)
+	if err != nil {
+		return err
+	}
+
+	// Reject proofs that were already submitted for another booking
+	reusedPayment, err := s.bookingRepo.FindPaymentByProofHash(proof.SHA256)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("failed to check payment proof")
+	}
+	if reusedPayment != nil {
+		return fmt.Errorf("payment proof has already been used for booking #%d", reusedPayment.BookingID)
+	}
+
+	// Save file using the extension of the detected content type
+	filename := fmt.Sprintf("payment_%d_%d%s", bookingID, time.Now().Unix(), proof.Extension)
 	savePath := filepath.Join("uploads", "payments", filename)
 
-	// Create file and save
-	if err := saveUploadedFile(file, savePath); err != nil {
+	if err := saveFile(proof.Content, savePath); err != nil {
 		return fmt.Errorf("failed to save file: %w", err)
 	}
 
@@ -283,17 +401,41 @@ func (s *BookingService) UploadPaymentProof(bookingID uint, userEmail string, fi
 
 	// Create payment record
 	payment := &entities.Payment{
-		BookingID:     bookingID,
-		PaymentMethod: paymentMethod,
-		PaymentStatus: entities.PaymentStatusPending,
-		ProofImageURL: proofURL,
+		BookingID:        bookingID,
+		PaymentMethod:    paymentMethod,
+		PaymentStatus:    entities.PaymentStatusPending,
+		ProofImageURL:    proofURL,
+		ProofContentType: proof.ContentType,
+		ProofSHA256:      proof.SHA256,
+	}
+
+	err = s.bookingRepo.CreatePayment(payment, repositories.CombineOutbox(
+		s.notificationSvc.StaffOutbox(entities.NotificationEventPaymentProofUploaded, constants.PERMISSION_BOOKINGS_VERIFY),
+		s.webhookSvc.BookingOutbox(entities.WebhookEventBookingPaid),
+	))
+	if err != nil {
+		os.Remove(savePath)
+
+		// A concurrent upload stored the same proof or a proof for this booking after the checks above
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			if reusedPayment, findErr := s.bookingRepo.FindPaymentByProofHash(proof.SHA256); findErr == nil {
+				return fmt.Errorf("payment proof has already been used for booking #%d", reusedPayment.BookingID)
+			}
+			return errors.New("payment proof already uploaded")
+		}
+		return err
 	}
-
-	return s.bookingRepo.CreatePayment(payment)
+	return nil
 }
 
 // UpdateBookingStatus updates booking status (for staff)
 func (s *BookingService) UpdateBookingStatus(bookingID uint, status entities.BookingStatus) error {
+	return s.VerifyBooking(bookingID, status, nil)
+}
+
+// VerifyBooking approves or rejects a booking waiting for verification. record (optional) is stored
+// in the same transaction, so the booking never changes without it.
+func (s *BookingService) VerifyBooking(bookingID uint, status entities.BookingStatus, record repositories.TxFunc) error {
 	// Validate status
 	if status != entities.BookingStatusSuccess && status != entities.BookingStatusRejected {
 		return errors.New("invalid status")
@@ -312,41 +454,37 @@ func (s *BookingService) UpdateBookingStatus(bookingID uint, status entities.Boo
 	if booking.Status != entities.BookingStatusWaitingVerification {
 		return errors.New("booking is not in waiting verification status")
 	}
-	// Update booking status
-	err = s.bookingRepo.UpdateBookingStatus(bookingID, status)
+	// Update booking and payment status in one transaction (rejected bookings free their seats)
+	event := entities.NotificationEventBookingApproved
+	if status == entities.BookingStatusRejected {
+		event = entities.NotificationEventBookingRejected
+	}
+	err = s.bookingRepo.VerifyBooking(bookingID, status, repositories.CombineOutbox(
+		s.notificationSvc.BookingOutbox(event),
+		s.webhookSvc.BookingOutbox(entities.WebhookEventBookingVerified),
+	), record)
 	if err != nil {
 		return err
 	}
 
-	// Handle post-status update actions
-	if status == entities.BookingStatusSuccess {
-		// If status is success, update payment status
-		payment, err := s.bookingRepo.GetPaymentByBookingID(bookingID)
-		if err != nil {
-			return err
-		}
-
-		// Update payment status and date
-		now := time.Now()
-		payment.PaymentStatus = entities.PaymentStatusSuccess
-		payment.PaymentDate = &now
-
-		// You would need to implement UpdatePayment method in repository
-		// For now, we'll assume it's handled
-	} else if status == entities.BookingStatusRejected {
-		// If status is rejected, free the seats so they can be booked again
-		err = s.bookingRepo.FreeSeatsByBookingID(bookingID)
-		if err != nil {
-			return fmt.Errorf("failed to free seats: %w", err)
-		}
+	if status == entities.BookingStatusRejected {
+		s.seatSvc.PublishSeatChanges(dto.SeatChangeBookingRejected, booking.ScheduleID)
 	}
-
 	return nil
 }
 
 // ExpireBookings expires bookings that have passed their expiry time
 func (s *BookingService) ExpireBookings() error {
-	return s.bookingRepo.ExpireBookings()
+	scheduleIDs, err := s.bookingRepo.ExpireBookings(repositories.CombineOutbox(
+		s.notificationSvc.BookingOutbox(entities.NotificationEventBookingExpired),
+		s.webhookSvc.BookingOutbox(entities.WebhookEventBookingExpired),
+	))
+	if err != nil {
+		return err
+	}
+
+	s.seatSvc.PublishSeatChanges(dto.SeatChangeBookingExpired, scheduleIDs...)
+	return nil
 }
 
 // GetAvailableSeats gets available seats for a schedule
@@ -418,20 +556,10 @@ func (s *BookingService) GetPaymentByBookingID(bookingID uint) (*dto.PaymentResp
 	return response, nil
 }
 
-// GenerateBookingReceipt generates PDF receipt for a booking
-func (s *BookingService) GenerateBookingReceipt(bookingID uint, userEmail *string) (string, error) {
-	var userIDPtr *uint
-	if userEmail != nil {
-		// Get user by email first
-		user, err := s.userRepo.FindByEmail(*userEmail)
-		if err != nil {
-			return "", errors.New("user not found")
-		}
-		userIDPtr = &user.ID
-	}
-
+// GenerateBookingReceipt generates PDF receipt for a booking in the given language
+func (s *BookingService) GenerateBookingReceipt(bookingID uint, userID *uint, locale i18n.Locale) (string, error) {
 	// Get booking details
-	booking, err := s.bookingRepo.GetBookingByID(bookingID, userIDPtr)
+	booking, err := s.bookingRepo.GetBookingByID(bookingID, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return "", errors.New("booking not found")
@@ -446,6 +574,11 @@ func (s *BookingService) GenerateBookingReceipt(bookingID uint, userEmail *strin
 	// Map to response DTO
 	bookingResponse := dto.NewBookingResponseFromEntity(booking)
 
+	passes, err := boardingPasses(booking)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate boarding passes: %w", err)
+	}
+
 	// Create receipts directory if not exists
 	receiptsDir := "uploads/receipts"
 	if err := os.MkdirAll(receiptsDir, 0755); err != nil {
@@ -458,7 +591,7 @@ func (s *BookingService) GenerateBookingReceipt(bookingID uint, userEmail *strin
 
 	// Generate PDF
 	pdfGenerator := utils.NewPDFReceiptGenerator()
-	err = pdfGenerator.GenerateBookingReceipt(bookingResponse, outputPath)
+	err = pdfGenerator.GenerateBookingReceipt(bookingResponse, passes, outputPath, locale)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate PDF: %w", err)
 	}
@@ -466,25 +599,30 @@ func (s *BookingService) GenerateBookingReceipt(bookingID uint, userEmail *strin
 	return outputPath, nil
 }
 
-// saveUploadedFile saves multipart file to disk
-func saveUploadedFile(fileHeader *multipart.FileHeader, dst string) error {
+// sanitizePaymentProof reads an uploaded payment proof and validates it by content
+func sanitizePaymentProof(fileHeader *multipart.FileHeader) (*utils.PaymentProof, error) {
 	src, err := fileHeader.Open()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer src.Close()
 
-	// Create directory if not exists
-	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
-		return err
+	proof, err := utils.SanitizePaymentProof(src, config.GetPaymentProofMaxDimension())
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidPaymentProof) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read payment proof: %w", err)
 	}
+	return proof, nil
+}
 
-	out, err := os.Create(dst)
-	if err != nil {
+// saveFile writes content to disk, creating the parent directory if needed
+func saveFile(content []byte, dst string) error {
+	// Create directory if not exists
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
 		return err
 	}
-	defer out.Close()
 
-	_, err = io.Copy(out, src)
-	return err
+	return os.WriteFile(dst, content, 0644)
 }
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+
+	"gorm.io/gorm"
+)
+
+type CounterPaymentService struct {
+	bookingRepo     *repositories.BookingRepository
+	notificationSvc *NotificationService
+	webhookSvc      *WebhookService
+}
+
+func NewCounterPaymentService(
+	bookingRepo *repositories.BookingRepository,
+	notificationSvc *NotificationService,
+	webhookSvc *WebhookService,
+) *CounterPaymentService {
+	return &CounterPaymentService{
+		bookingRepo:     bookingRepo,
+		notificationSvc: notificationSvc,
+		webhookSvc:      webhookSvc,
+	}
+}
+
+// RecordCounterPayment records a cash/EDC payment taken by staff and confirms the booking immediately
+func (s *CounterPaymentService) RecordCounterPayment(bookingID uint, cashierID uint, req dto.RecordCounterPaymentRequest) (*dto.CounterPaymentResponse, error) {
+	booking, err := s.bookingRepo.GetBookingByID(bookingID, nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("booking not found")
+		}
+		return nil, errors.New("failed to retrieve booking")
+	}
+
+	if booking.Status != entities.BookingStatusPending {
+		return nil, errors.New("booking is not pending payment")
+	}
+	if booking.ExpiresAt.Before(time.Now()) {
+		return nil, errors.New("booking has expired")
+	}
+
+	// The unique code only tells bank transfers apart, at the counter the ticket total is charged.
+	// Cash may be overpaid and change is given back, EDC is charged the exact amount
+	ticketTotal := booking.PaymentAmount - float64(booking.UniqueCode)
+	var change float64
+	switch req.PaymentMethod {
+	case entities.PaymentMethodCash:
+		if req.AmountReceived < ticketTotal {
+			return nil, fmt.Errorf("amount received is less than the ticket total (%.0f)", ticketTotal)
+		}
+		change = req.AmountReceived - ticketTotal
+	case entities.PaymentMethodEDC:
+		if req.AmountReceived != ticketTotal {
+			return nil, fmt.Errorf("EDC amount must equal the ticket total (%.0f)", ticketTotal)
+		}
+	default:
+		return nil, errors.New("invalid payment method")
+	}
+
+	now := time.Now()
+	payment := &entities.Payment{
+		BookingID:      bookingID,
+		PaymentMethod:  req.PaymentMethod,
+		PaymentStatus:  entities.PaymentStatusSuccess,
+		PaymentDate:    &now,
+		Amount:         ticketTotal,
+		AmountReceived: req.AmountReceived,
+		ChangeAmount:   change,
+		CashierID:      &cashierID,
+	}
+
+	// A counter payment is paid and verified at once
+	err = s.bookingRepo.CreateCounterPayment(payment, repositories.CombineOutbox(
+		s.notificationSvc.BookingOutbox(entities.NotificationEventBookingApproved),
+		s.webhookSvc.BookingOutbox(entities.WebhookEventBookingPaid),
+		s.webhookSvc.BookingOutbox(entities.WebhookEventBookingVerified),
+	))
+	if err != nil {
+		if strings.Contains(err.Error(), "not pending") {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	return dto.NewCounterPaymentResponseFromEntity(payment), nil
+}
+
+// GetCashSummary summarizes counter payments per staff user for a shift period.
+// cashierID limits the summary to the staff user's own payments (nil for admin, who may filter by cashier_id).
+func (s *CounterPaymentService) GetCashSummary(query dto.CashSummaryQuery, cashierID *uint) (*dto.CashSummaryResponse, error) {
+	if cashierID == nil && query.CashierID != 0 {
+		cashierID = &query.CashierID
+	}
+
+	loc := wibLocation()
+	now := time.Now().In(loc)
+
+	// Default to today's shift until now
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	to := now
+	if query.From != "" {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", query.From, loc)
+		if err != nil {
+			return nil, errors.New("invalid from format, use YYYY-MM-DD HH:mm")
+		}
+		from = parsed
+	}
+	if query.To != "" {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", query.To, loc)
+		if err != nil {
+			return nil, errors.New("invalid to format, use YYYY-MM-DD HH:mm")
+		}
+		to = parsed
+	}
+	if to.Before(from) {
+		return nil, errors.New("to must be after from")
+	}
+
+	payments, err := s.bookingRepo.GetCounterPayments(cashierID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get counter payments: %w", err)
+	}
+
+	// Group payments per cashier and per method
+	summaries := make(map[uint]*dto.CashierSummaryResponse)
+	methods := make(map[uint]map[string]*dto.CashSummaryMethodResponse)
+	for i := range payments {
+		payment := &payments[i]
+		id := *payment.CashierID
+
+		summary, ok := summaries[id]
+		if !ok {
+			summary = &dto.CashierSummaryResponse{CashierID: id}
+			if payment.Cashier != nil {
+				summary.CashierName = strings.TrimSpace(payment.Cashier.FirstName + " " + payment.Cashier.LastName)
+				summary.CashierEmail = payment.Cashier.Email
+			}
+			summaries[id] = summary
+			methods[id] = make(map[string]*dto.CashSummaryMethodResponse)
+		}
+
+		amount := payment.Amount
+		summary.Transactions++
+		summary.TotalAmount += amount
+		if payment.PaymentMethod == entities.PaymentMethodCash {
+			summary.CashInDrawer += amount
+		}
+		summary.Payments = append(summary.Payments, *dto.NewCounterPaymentResponseFromEntity(payment))
+
+		method, ok := methods[id][payment.PaymentMethod]
+		if !ok {
+			method = &dto.CashSummaryMethodResponse{PaymentMethod: payment.PaymentMethod}
+			methods[id][payment.PaymentMethod] = method
+		}
+		method.Transactions++
+		method.TotalAmount += amount
+		method.AmountReceived += payment.AmountReceived
+		method.ChangeGiven += payment.ChangeAmount
+	}
+
+	response := &dto.CashSummaryResponse{
+		From:     from.Format("2006-01-02 15:04"),
+		To:       to.Format("2006-01-02 15:04"),
+		Cashiers: []dto.CashierSummaryResponse{},
+	}
+	for id, summary := range summaries {
+		for _, method := range methods[id] {
+			summary.ByMethod = append(summary.ByMethod, *method)
+		}
+		sort.Slice(summary.ByMethod, func(i, j int) bool {
+			return summary.ByMethod[i].PaymentMethod < summary.ByMethod[j].PaymentMethod
+		})
+		response.Cashiers = append(response.Cashiers, *summary)
+	}
+	sort.Slice(response.Cashiers, func(i, j int) bool {
+		return response.Cashiers[i].CashierID < response.Cashiers[j].CashierID
+	})
+
+	return response, nil
+}
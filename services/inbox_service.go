@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	"malakashuttle/dto"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InboxService serves the in-app notifications of the authenticated user
+type InboxService struct {
+	inboxRepo repositories.InboxRepository
+}
+
+func NewInboxService(inboxRepo repositories.InboxRepository) *InboxService {
+	return &InboxService{
+		inboxRepo: inboxRepo,
+	}
+}
+
+// GetNotifications lists the notifications of a user. status is "unread", "read" or empty for all.
+func (s *InboxService) GetNotifications(userID uint, params utils.PaginationParams, status string) (*utils.PaginationResponse, error) {
+	var unread *bool
+	switch status {
+	case "":
+	case "unread", "read":
+		value := status == "unread"
+		unread = &value
+	default:
+		return nil, utils.NewBadRequestError("Invalid notification status, use read or unread", nil)
+	}
+
+	notifications, total, err := s.inboxRepo.GetNotifications(userID, params.Page, params.Limit, unread)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get notifications", err)
+	}
+
+	responses := make([]dto.NotificationResponse, len(notifications))
+	for i := range notifications {
+		responses[i] = dto.NewNotificationResponseFromEntity(&notifications[i])
+	}
+
+	response := utils.CreatePaginationResponse(responses, total, params)
+	return &response, nil
+}
+
+// GetUnreadCount returns how many notifications the user has not read yet
+func (s *InboxService) GetUnreadCount(userID uint) (*dto.UnreadNotificationCountResponse, error) {
+	count, err := s.inboxRepo.CountUnread(userID)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to count unread notifications", err)
+	}
+	return &dto.UnreadNotificationCountResponse{UnreadCount: count}, nil
+}
+
+// MarkRead marks a notification of the user as read
+func (s *InboxService) MarkRead(userID, notificationID uint) (*dto.NotificationResponse, error) {
+	notification, err := s.inboxRepo.MarkRead(notificationID, userID, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Notification not found", err)
+		}
+		return nil, utils.NewInternalServerError("Failed to mark notification as read", err)
+	}
+
+	response := dto.NewNotificationResponseFromEntity(notification)
+	return &response, nil
+}
+
+// MarkAllRead marks every unread notification of the user as read
+func (s *InboxService) MarkAllRead(userID uint) (*dto.MarkAllNotificationsReadResponse, error) {
+	updated, err := s.inboxRepo.MarkAllRead(userID, time.Now())
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to mark notifications as read", err)
+	}
+	return &dto.MarkAllNotificationsReadResponse{Updated: updated}, nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"malakashuttle/config"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+
+	"gorm.io/gorm"
+)
+
+type InvoiceService struct {
+	invoiceRepo *repositories.InvoiceRepository
+	bookingRepo *repositories.BookingRepository
+}
+
+func NewInvoiceService(
+	invoiceRepo *repositories.InvoiceRepository,
+	bookingRepo *repositories.BookingRepository,
+) *InvoiceService {
+	return &InvoiceService{
+		invoiceRepo: invoiceRepo,
+		bookingRepo: bookingRepo,
+	}
+}
+
+// GetInvoice gets the invoice of a booking once it has been issued.
+// userID limits access to the booking owner (nil for staff/admin).
+func (s *InvoiceService) GetInvoice(bookingID uint, userID *uint) (*dto.InvoiceResponse, error) {
+	invoice, err := s.getIssuedInvoice(bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewInvoiceResponseFromEntity(invoice), nil
+}
+
+// IssueInvoice issues the invoice of a successful booking with the given billing details.
+// Discounts can only be given by staff/admin (userID == nil).
+func (s *InvoiceService) IssueInvoice(bookingID uint, userID *uint, issuerID uint, req dto.AdminIssueInvoiceRequest) (*dto.InvoiceResponse, error) {
+	if userID != nil && req.DiscountAmount > 0 {
+		return nil, errors.New("discounts can only be given by staff")
+	}
+
+	booking, err := s.getInvoiceableBooking(bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.invoiceRepo.GetInvoiceByBookingID(bookingID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("failed to check existing invoice")
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("invoice %s has already been issued for this booking", existing.InvoiceNumber)
+	}
+
+	invoice, err := s.createInvoice(booking, &issuerID, req)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewInvoiceResponseFromEntity(invoice), nil
+}
+
+// GenerateInvoicePDF generates the PDF of a booking invoice and returns its path
+func (s *InvoiceService) GenerateInvoicePDF(bookingID uint, userID *uint) (string, error) {
+	invoice, err := s.getIssuedInvoice(bookingID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	// Create invoices directory if not exists
+	invoicesDir := "uploads/invoices"
+	if err := os.MkdirAll(invoicesDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create invoices directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("invoice_%d_%d.pdf", bookingID, time.Now().Unix())
+	outputPath := filepath.Join(invoicesDir, filename)
+
+	pdfGenerator := utils.NewPDFInvoiceGenerator()
+	if err := pdfGenerator.GenerateInvoice(dto.NewInvoiceResponseFromEntity(invoice), outputPath); err != nil {
+		return "", fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return outputPath, nil
+}
+
+// getIssuedInvoice returns the invoice of a booking. Reading never issues one, since that would use up
+// an invoice number and keep the customer from issuing it later with their own billing details.
+func (s *InvoiceService) getIssuedInvoice(bookingID uint, userID *uint) (*entities.Invoice, error) {
+	if _, err := s.getInvoiceableBooking(bookingID, userID); err != nil {
+		return nil, err
+	}
+
+	invoice, err := s.invoiceRepo.GetInvoiceByBookingID(bookingID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("invoice not found: it has not been issued for this booking yet")
+		}
+		return nil, errors.New("failed to retrieve invoice")
+	}
+	return invoice, nil
+}
+
+// getInvoiceableBooking gets a booking and checks that an invoice can be issued for it
+func (s *InvoiceService) getInvoiceableBooking(bookingID uint, userID *uint) (*entities.Booking, error) {
+	booking, err := s.bookingRepo.GetBookingByID(bookingID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("booking not found")
+		}
+		return nil, errors.New("failed to retrieve booking")
+	}
+
+	// Only paid (verified) bookings can be invoiced
+	if booking.Status != entities.BookingStatusSuccess {
+		return nil, errors.New("invoice can only be issued for successful bookings")
+	}
+	return booking, nil
+}
+
+// createInvoice builds the invoice and stores it with the next invoice number
+func (s *InvoiceService) createInvoice(booking *entities.Booking, issuedByID *uint, req dto.AdminIssueInvoiceRequest) (*entities.Invoice, error) {
+	invoice, err := buildInvoice(booking, issuedByID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.invoiceRepo.CreateInvoice(invoice); err != nil {
+		// Another request issued the invoice between the check and the insert
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errors.New("invoice has already been issued for this booking")
+		}
+		return nil, fmt.Errorf("failed to create invoice: %w", err)
+	}
+	return invoice, nil
+}
+
+// buildInvoice builds the invoice lines and tax breakdown of a booking
+func buildInvoice(booking *entities.Booking, issuedByID *uint, req dto.AdminIssueInvoiceRequest) (*entities.Invoice, error) {
+	company := config.GetCompanyProfile()
+	invoice := &entities.Invoice{
+		BookingID:      booking.ID,
+		IssuedAt:       time.Now(),
+		IssuedByID:     issuedByID,
+		CompanyName:    company.Name,
+		CompanyAddress: company.Address,
+		CompanyPhone:   company.Phone,
+		CompanyEmail:   company.Email,
+		CompanyNPWP:    company.NPWP,
+		BillingName:    firstNonEmpty(req.BillingName, strings.TrimSpace(booking.User.FirstName+" "+booking.User.LastName)),
+		BillingCompany: req.BillingCompany,
+		BillingAddress: req.BillingAddress,
+		BillingNPWP:    req.BillingNPWP,
+		BillingEmail:   firstNonEmpty(req.BillingEmail, booking.User.Email),
+		BillingPhone:   firstNonEmpty(req.BillingPhone, booking.User.PhoneNumber),
+		DiscountAmount: req.DiscountAmount,
+		DiscountNote:   req.DiscountNote,
+	}
+
+	// One line per passenger for the booked leg
+	leg := fmt.Sprintf("Shuttle ticket %s - %s, %s WIB",
+		booking.Schedule.Route.OriginCity,
+		booking.Schedule.Route.DestinationCity,
+		booking.Schedule.DepartureTime.In(wibLocation()).Format("02 Jan 2006 15:04"))
+	for _, detail := range booking.BookingDetails {
+		invoice.Items = append(invoice.Items, entities.InvoiceItem{
+			Description: fmt.Sprintf("%s, seat %s (%s)", leg, detail.Seat.SeatNumber, detail.PassengerName),
+			Quantity:    1,
+			UnitPrice:   detail.Price,
+			Amount:      detail.Price,
+		})
+		invoice.Subtotal += detail.Price
+	}
+
+	// The unique transfer code is part of the amount the customer paid, except at the counter
+	if uniqueCode := booking.ChargedUniqueCode(); uniqueCode > 0 {
+		invoice.Items = append(invoice.Items, entities.InvoiceItem{
+			Description: "Unique transfer code",
+			Quantity:    1,
+			UnitPrice:   float64(uniqueCode),
+			Amount:      float64(uniqueCode),
+		})
+		invoice.Subtotal += float64(uniqueCode)
+	}
+
+	if invoice.DiscountAmount > invoice.Subtotal {
+		return nil, errors.New("discount cannot exceed the invoice subtotal")
+	}
+
+	// Ticket prices include PPN, so the tax is extracted from the total
+	invoice.TaxRate = config.GetInvoiceTaxRate()
+	invoice.TotalAmount = invoice.Subtotal - invoice.DiscountAmount
+	invoice.TaxableAmount = math.Round(invoice.TotalAmount / (1 + invoice.TaxRate))
+	invoice.TaxAmount = invoice.TotalAmount - invoice.TaxableAmount
+	return invoice, nil
+}
+
+// firstNonEmpty returns the first value that is not blank
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+)
+
+// newPaidBooking returns a successful booking of two 150.000 tickets with unique code 123
+func newPaidBooking(payment *entities.Payment) *entities.Booking {
+	booking := &entities.Booking{
+		Status:        entities.BookingStatusSuccess,
+		PaymentAmount: 300123,
+		UniqueCode:    123,
+		Schedule: entities.Schedule{
+			Route:         entities.Route{OriginCity: "Jakarta", DestinationCity: "Bandung"},
+			DepartureTime: time.Date(2026, 10, 20, 8, 0, 0, 0, wibLocation()),
+		},
+		BookingDetails: []entities.BookingDetail{
+			{PassengerName: "Budi", Price: 150000, Seat: entities.Seat{SeatNumber: "1"}},
+			{PassengerName: "Sari", Price: 150000, Seat: entities.Seat{SeatNumber: "2"}},
+		},
+		Payment: payment,
+	}
+	booking.ID = 1
+	payment.BookingID = booking.ID
+	return booking
+}
+
+func TestInvoiceOfCounterPaymentTotalsAmountCharged(t *testing.T) {
+	cashierID := uint(7)
+	booking := newPaidBooking(&entities.Payment{
+		PaymentMethod:  entities.PaymentMethodCash,
+		PaymentStatus:  entities.PaymentStatusSuccess,
+		Amount:         300000,
+		AmountReceived: 300000,
+		CashierID:      &cashierID,
+	})
+
+	invoice, err := buildInvoice(booking, &cashierID, dto.AdminIssueInvoiceRequest{})
+	if err != nil {
+		t.Fatalf("buildInvoice: %v", err)
+	}
+
+	if invoice.TotalAmount != booking.Payment.Amount {
+		t.Errorf("total amount = %.0f, want the amount charged %.0f", invoice.TotalAmount, booking.Payment.Amount)
+	}
+	if invoice.TaxableAmount+invoice.TaxAmount != invoice.TotalAmount {
+		t.Errorf("taxable %.0f + tax %.0f does not add up to total %.0f", invoice.TaxableAmount, invoice.TaxAmount, invoice.TotalAmount)
+	}
+	for _, item := range invoice.Items {
+		if item.Description == "Unique transfer code" {
+			t.Errorf("invoice of a counter payment lists the unique transfer code")
+		}
+	}
+
+	receipt := dto.NewBookingResponseFromEntity(booking)
+	if receipt.TotalAmount != booking.Payment.Amount || receipt.UniqueCode != 0 {
+		t.Errorf("receipt total = %.0f with unique code %d, want %.0f without unique code",
+			receipt.TotalAmount, receipt.UniqueCode, booking.Payment.Amount)
+	}
+}
+
+func TestInvoiceOfTransferIncludesUniqueCode(t *testing.T) {
+	booking := newPaidBooking(&entities.Payment{
+		PaymentMethod: "bank_transfer",
+		PaymentStatus: entities.PaymentStatusSuccess,
+	})
+
+	invoice, err := buildInvoice(booking, nil, dto.AdminIssueInvoiceRequest{})
+	if err != nil {
+		t.Fatalf("buildInvoice: %v", err)
+	}
+
+	if invoice.TotalAmount != booking.PaymentAmount {
+		t.Errorf("total amount = %.0f, want the transferred amount %.0f", invoice.TotalAmount, booking.PaymentAmount)
+	}
+	if len(invoice.Items) != 3 || invoice.Items[2].Amount != float64(booking.UniqueCode) {
+		t.Errorf("invoice items = %+v, want both tickets and the unique transfer code", invoice.Items)
+	}
+}
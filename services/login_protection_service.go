@@ -0,0 +1,168 @@
+package services
+
+import (
+	"log"
+	"malakashuttle/config"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"net"
+	"strings"
+	"time"
+)
+
+// LoginProtectionService throttles failed logins per account and per client IP
+type LoginProtectionService struct {
+	attemptStore repositories.LoginAttemptStore
+	userRepo     repositories.UserRepository
+}
+
+func NewLoginProtectionService(attemptStore repositories.LoginAttemptStore, userRepo repositories.UserRepository) *LoginProtectionService {
+	return &LoginProtectionService{
+		attemptStore: attemptStore,
+		userRepo:     userRepo,
+	}
+}
+
+// CheckAllowed rejects a login attempt while the account or IP is locked, or while the
+// progressive delay after the last failed attempt on the account has not passed yet
+func (s *LoginProtectionService) CheckAllowed(email string, client dto.ClientInfo) error {
+	cfg := config.GetLoginProtectionConfig()
+	now := time.Now()
+
+	ipThrottle, err := s.attemptStore.GetThrottle(ipThrottleKey(client.IPAddress))
+	if err != nil {
+		return utils.NewInternalServerError("Failed to check login attempts", err)
+	}
+	if ipThrottle != nil && ipThrottle.LockedUntil != nil && now.Before(*ipThrottle.LockedUntil) {
+		s.audit(email, nil, client, entities.LoginFailureIPLocked)
+		return tooManyLoginAttempts("Too many failed login attempts from this IP address", ipThrottle.LockedUntil.Sub(now))
+	}
+
+	accountThrottle, err := s.attemptStore.GetThrottle(accountThrottleKey(email))
+	if err != nil {
+		return utils.NewInternalServerError("Failed to check login attempts", err)
+	}
+	if accountThrottle == nil || accountThrottle.LastFailedAt == nil {
+		return nil
+	}
+	if accountThrottle.LockedUntil != nil {
+		if now.Before(*accountThrottle.LockedUntil) {
+			s.audit(email, nil, client, entities.LoginFailureAccountLocked)
+			return tooManyLoginAttempts("Account is temporarily locked because of too many failed login attempts", accountThrottle.LockedUntil.Sub(now))
+		}
+		return nil
+	}
+	if now.Sub(*accountThrottle.FirstFailedAt) > cfg.FailureWindow {
+		return nil
+	}
+
+	if wait := accountThrottle.LastFailedAt.Add(progressiveDelay(cfg, accountThrottle.FailedCount)).Sub(now); wait > 0 {
+		s.audit(email, nil, client, entities.LoginFailureThrottled)
+		return tooManyLoginAttempts("Too many failed login attempts, please wait before trying again", wait)
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login against the account and the client IP and writes the audit log
+func (s *LoginProtectionService) RecordFailure(email string, userID *uint, client dto.ClientInfo, reason string) {
+	cfg := config.GetLoginProtectionConfig()
+
+	if _, err := s.attemptStore.RecordFailure(accountThrottleKey(email), cfg.FailureWindow, cfg.MaxAccountFailures, cfg.LockoutDuration); err != nil {
+		log.Printf("Failed to record failed login for account: %v", err)
+	}
+	if _, err := s.attemptStore.RecordFailure(ipThrottleKey(client.IPAddress), cfg.FailureWindow, cfg.MaxIPFailures, cfg.LockoutDuration); err != nil {
+		log.Printf("Failed to record failed login for ip: %v", err)
+	}
+
+	s.audit(email, userID, client, reason)
+}
+
+// RecordSuccess clears the failed attempts of the account after a successful login
+func (s *LoginProtectionService) RecordSuccess(email string) {
+	if err := s.attemptStore.ResetThrottle(accountThrottleKey(email)); err != nil {
+		log.Printf("Failed to reset login throttle: %v", err)
+	}
+}
+
+// UnlockUser clears the lockout and failed attempts of a user's account (for admin)
+func (s *LoginProtectionService) UnlockUser(userID uint) (*dto.UnlockLoginResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("User not found", nil)
+	}
+
+	if err := s.attemptStore.ResetThrottle(accountThrottleKey(user.Email)); err != nil {
+		return nil, utils.NewInternalServerError("Failed to unlock user", err)
+	}
+
+	return &dto.UnlockLoginResponse{
+		UserID: user.ID,
+		Email:  user.Email,
+	}, nil
+}
+
+// GetAuditLogs gets the failed login audit log, optionally filtered by email or IP address
+func (s *LoginProtectionService) GetAuditLogs(params utils.PaginationParams, email, ipAddress string) (*utils.PaginationResponse, error) {
+	logs, total, err := s.attemptStore.GetAuditLogs(params.Page, params.Limit, strings.TrimSpace(email), strings.TrimSpace(ipAddress))
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get login audit logs", err)
+	}
+
+	responses := make([]dto.LoginAuditLogResponse, len(logs))
+	for i := range logs {
+		responses[i] = dto.NewLoginAuditLogResponseFromEntity(&logs[i])
+	}
+
+	response := utils.CreatePaginationResponse(responses, total, params)
+	return &response, nil
+}
+
+func (s *LoginProtectionService) audit(email string, userID *uint, client dto.ClientInfo, reason string) {
+	entry := &entities.LoginAuditLog{
+		Email:     truncate(email, 100),
+		UserID:    userID,
+		IPAddress: truncate(client.IPAddress, 45),
+		UserAgent: truncate(client.UserAgent, 255),
+		Reason:    reason,
+	}
+	if err := s.attemptStore.CreateAuditLog(entry); err != nil {
+		log.Printf("Failed to write login audit log: %v", err)
+	}
+}
+
+// progressiveDelay doubles the wait after each failed attempt, up to the configured maximum
+func progressiveDelay(cfg config.LoginProtectionConfig, failures int) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	delay := cfg.DelayBase
+	for i := 1; i < failures && delay < cfg.DelayMax; i++ {
+		delay *= 2
+	}
+	if delay > cfg.DelayMax {
+		delay = cfg.DelayMax
+	}
+	return delay
+}
+
+func tooManyLoginAttempts(message string, retryAfter time.Duration) error {
+	return utils.NewTooManyRequestsErrorWithDetails(message, nil, map[string]interface{}{
+		"retry_after_seconds": int(retryAfter.Seconds()) + 1,
+	})
+}
+
+func accountThrottleKey(email string) string {
+	return "account:" + truncate(strings.ToLower(strings.TrimSpace(email)), 100)
+}
+
+// ipThrottleKey throttles IPv6 clients per /64, since a single host usually gets a whole /64
+// and could otherwise rotate through addresses on every attempt
+func ipThrottleKey(ipAddress string) string {
+	ip := net.ParseIP(ipAddress)
+	if ip != nil && ip.To4() == nil {
+		return "ip:" + ip.Mask(net.CIDRMask(64, 128)).String() + "/64"
+	}
+	return "ip:" + ipAddress
+}
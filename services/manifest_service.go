@@ -0,0 +1,69 @@
+package services
+
+import (
+	"bytes"
+	"malakashuttle/dto"
+	"malakashuttle/i18n"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"time"
+)
+
+// ManifestService lists the passengers of a schedule for the staff and the driver of the trip
+type ManifestService struct {
+	scheduleRepo *repositories.ScheduleRepository
+	boardingRepo repositories.BoardingRepository
+}
+
+func NewManifestService(scheduleRepo *repositories.ScheduleRepository, boardingRepo repositories.BoardingRepository) *ManifestService {
+	return &ManifestService{
+		scheduleRepo: scheduleRepo,
+		boardingRepo: boardingRepo,
+	}
+}
+
+// GetManifest returns who sits where on a schedule, with a summary of the empty seats
+func (s *ManifestService) GetManifest(scheduleID uint) (*dto.ManifestResponse, error) {
+	schedule, err := s.scheduleRepo.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("Schedule not found", nil)
+	}
+	seats, err := s.scheduleRepo.GetSeatsByScheduleID(scheduleID)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get seats", err)
+	}
+	passengers, err := s.boardingRepo.FindPaidPassengers(scheduleID)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get passengers", err)
+	}
+
+	return dto.NewManifestResponse(schedule, seats, passengers, time.Now()), nil
+}
+
+// ExportManifestPDF returns the manifest of a schedule as PDF in the given language
+func (s *ManifestService) ExportManifestPDF(scheduleID uint, locale i18n.Locale) ([]byte, error) {
+	manifest, err := s.GetManifest(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := utils.NewPDFManifestGenerator().GenerateManifest(manifest, &buf, locale); err != nil {
+		return nil, utils.NewInternalServerError("Failed to generate manifest", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportManifestCSV returns the passengers of the manifest of a schedule as CSV
+func (s *ManifestService) ExportManifestCSV(scheduleID uint, locale i18n.Locale) ([]byte, error) {
+	manifest, err := s.GetManifest(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := utils.WriteManifestCSV(manifest, &buf, locale); err != nil {
+		return nil, utils.NewInternalServerError("Failed to generate manifest", err)
+	}
+	return buf.Bytes(), nil
+}
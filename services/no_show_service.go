@@ -0,0 +1,119 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"malakashuttle/config"
+	"malakashuttle/dto"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NoShowService marks passengers who missed their trip and applies the no-show policy to new bookings
+type NoShowService struct {
+	boardingRepo repositories.BoardingRepository
+	userRepo     repositories.UserRepository
+}
+
+func NewNoShowService(boardingRepo repositories.BoardingRepository, userRepo repositories.UserRepository) *NoShowService {
+	return &NoShowService{
+		boardingRepo: boardingRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// MarkNoShows marks the passengers who did not board once NO_SHOW_GRACE_PERIOD has passed since departure.
+// Only schedules that departed within NO_SHOW_LOOKBACK are checked.
+func (s *NoShowService) MarkNoShows() (int64, error) {
+	cfg := config.GetNoShowConfig()
+	now := time.Now()
+	departedBefore := now.Add(-cfg.GracePeriod)
+	return s.boardingRepo.MarkNoShows(departedBefore.Add(-cfg.Lookback), departedBefore, now)
+}
+
+// GetStats returns the no-show statistics of a user and the restriction the policy puts on them
+func (s *NoShowService) GetStats(userID uint) (*dto.NoShowStatsResponse, error) {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("User not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get user", err)
+	}
+
+	stats, err := s.boardingRepo.GetNoShowStats(userID)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get no-show statistics", err)
+	}
+	policy, err := s.evaluatePolicy(userID, time.Now())
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get no-show statistics", err)
+	}
+
+	return &dto.NoShowStatsResponse{
+		UserID:            userID,
+		Passengers:        stats.Passengers,
+		CheckedIn:         stats.CheckedIn,
+		Boarded:           stats.Boarded,
+		NoShows:           stats.NoShows,
+		RecentMissedTrips: policy.missedTrips,
+		LastNoShowAt:      stats.LastNoShowAt,
+		Restriction:       policy.restriction,
+		MaxPassengers:     policy.maxPassengers,
+		RestrictedUntil:   policy.restrictedUntil,
+	}, nil
+}
+
+// CheckBookingPolicy rejects a booking of the given number of passengers when the user missed too many
+// trips within NO_SHOW_POLICY_WINDOW
+func (s *NoShowService) CheckBookingPolicy(userID uint, passengers int) error {
+	policy, err := s.evaluatePolicy(userID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to check no-show policy: %w", err)
+	}
+	switch policy.restriction {
+	case dto.NoShowRestrictionBlocked:
+		return errors.New("booking blocked: too many missed trips, please contact customer service")
+	case dto.NoShowRestrictionLimited:
+		if passengers > *policy.maxPassengers {
+			return errors.New("too many passengers: accounts with recent missed trips have a lower passenger limit")
+		}
+	}
+	return nil
+}
+
+type noShowPolicy struct {
+	missedTrips     int
+	restriction     dto.NoShowRestriction
+	maxPassengers   *int
+	restrictedUntil *time.Time
+}
+
+// evaluatePolicy counts the bookings the user missed within the policy window. A restriction lasts
+// until enough missed trips have left the window to bring the count below its threshold.
+func (s *NoShowService) evaluatePolicy(userID uint, now time.Time) (*noShowPolicy, error) {
+	cfg := config.GetNoShowConfig()
+	missed, err := s.boardingRepo.FindNoShowTimes(userID, now.Add(-cfg.PolicyWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &noShowPolicy{missedTrips: len(missed), restriction: dto.NoShowRestrictionNone}
+	until := func(threshold int) *time.Time {
+		t := missed[threshold-1].Add(cfg.PolicyWindow)
+		return &t
+	}
+	switch {
+	case cfg.BlockThreshold > 0 && len(missed) >= cfg.BlockThreshold:
+		policy.restriction = dto.NoShowRestrictionBlocked
+		policy.restrictedUntil = until(cfg.BlockThreshold)
+	case cfg.PenaltyThreshold > 0 && len(missed) >= cfg.PenaltyThreshold:
+		maxPassengers := cfg.PenaltyMaxPassengers
+		policy.restriction = dto.NoShowRestrictionLimited
+		policy.maxPassengers = &maxPassengers
+		policy.restrictedUntil = until(cfg.PenaltyThreshold)
+	}
+	return policy, nil
+}
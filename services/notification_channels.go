@@ -0,0 +1,37 @@
+package services
+
+import (
+	"malakashuttle/mailer"
+	"malakashuttle/sms"
+	"malakashuttle/whatsapp"
+)
+
+// NotificationChannel delivers a rendered notification to a recipient. Channels without
+// a subject line ignore it.
+type NotificationChannel interface {
+	Send(recipient, subject, body string) error
+}
+
+type emailChannel struct {
+	sender mailer.Sender
+}
+
+func (c emailChannel) Send(recipient, subject, body string) error {
+	return c.sender.Send(mailer.Message{To: recipient, Subject: subject, Body: body})
+}
+
+type smsChannel struct {
+	sender sms.Sender
+}
+
+func (c smsChannel) Send(recipient, _, body string) error {
+	return c.sender.Send(sms.Message{To: recipient, Body: body})
+}
+
+type whatsappChannel struct {
+	sender whatsapp.Sender
+}
+
+func (c whatsappChannel) Send(recipient, _, body string) error {
+	return c.sender.Send(whatsapp.Message{To: recipient, Body: body})
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"malakashuttle/config"
+	"malakashuttle/constants"
+	"malakashuttle/entities"
+	"malakashuttle/i18n"
+	"malakashuttle/mailer"
+	"malakashuttle/repositories"
+	"malakashuttle/sms"
+	"malakashuttle/whatsapp"
+	"time"
+)
+
+// notificationSendLease is how long a claimed outbox message is hidden from other dispatchers
+const notificationSendLease = 5 * time.Minute
+
+// NotificationService queues notifications in the outbox and delivers them. Every notification is
+// also put in the in-app inbox of the user.
+type NotificationService struct {
+	notificationRepo repositories.NotificationRepository
+	userRepo         repositories.UserRepository
+	roleService      *RoleService
+	cfg              config.NotificationConfig
+	channels         map[entities.NotificationChannel]NotificationChannel
+	// enabled keeps the configured order of the channels
+	enabled []entities.NotificationChannel
+}
+
+func NewNotificationService(
+	notificationRepo repositories.NotificationRepository,
+	userRepo repositories.UserRepository,
+	roleService *RoleService,
+	mailSender mailer.Sender,
+	smsSender sms.Sender,
+	whatsappSender whatsapp.Sender,
+) *NotificationService {
+	s := &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		roleService:      roleService,
+		cfg:              config.GetNotificationConfig(),
+		channels:         make(map[entities.NotificationChannel]NotificationChannel),
+	}
+
+	available := map[entities.NotificationChannel]NotificationChannel{
+		entities.NotificationChannelEmail:    emailChannel{sender: mailSender},
+		entities.NotificationChannelSMS:      smsChannel{sender: smsSender},
+		entities.NotificationChannelWhatsApp: whatsappChannel{sender: whatsappSender},
+	}
+	for _, name := range s.cfg.Channels {
+		channel := entities.NotificationChannel(name)
+		if _, ok := available[channel]; !ok {
+			log.Printf("Ignoring unknown notification channel %q", name)
+			continue
+		}
+		if _, ok := s.channels[channel]; ok {
+			continue
+		}
+		s.RegisterChannel(channel, available[channel])
+	}
+	return s
+}
+
+// RegisterChannel enables a channel or replaces the one registered under the name
+func (s *NotificationService) RegisterChannel(name entities.NotificationChannel, channel NotificationChannel) {
+	if _, ok := s.channels[name]; !ok {
+		s.enabled = append(s.enabled, name)
+	}
+	s.channels[name] = channel
+}
+
+// BookingOutbox returns the builder that queues the notifications of a booking event on every enabled
+// channel the customer can be reached on, and in the inbox of the customer
+func (s *NotificationService) BookingOutbox(event entities.NotificationEvent) repositories.OutboxBuilder {
+	return func(booking *entities.Booking) (*repositories.Outbox, error) {
+		// Partner service accounts have no real contact details, partners track their bookings through the API
+		if booking.User.Role == constants.ROLE_PARTNER {
+			return nil, nil
+		}
+
+		locale := i18n.Resolve(booking.User.Locale)
+		data := newBookingNotificationData(booking, locale)
+		now := time.Now()
+
+		var messages []entities.OutboxMessage
+		for _, channel := range s.enabled {
+			recipient := notificationRecipient(&booking.User, channel)
+			if recipient == "" {
+				continue
+			}
+
+			subject, body, err := renderNotification(event, channel, locale, data)
+			if err != nil {
+				return nil, err
+			}
+
+			userID, bookingID := booking.UserID, booking.ID
+			messages = append(messages, entities.OutboxMessage{
+				Event:         event,
+				Channel:       channel,
+				UserID:        &userID,
+				BookingID:     &bookingID,
+				Recipient:     recipient,
+				Subject:       subject,
+				Body:          body,
+				Status:        entities.OutboxStatusPending,
+				NextAttemptAt: now,
+			})
+		}
+
+		inbox, err := newInboxNotification(booking.UserID, event, booking.ID, locale, data)
+		if err != nil {
+			return nil, err
+		}
+		return &repositories.Outbox{Messages: messages, Notifications: []entities.Notification{*inbox}}, nil
+	}
+}
+
+// StaffOutbox returns the builder that puts an alert about a booking in the inbox of every user whose
+// role grants the permission. Staff alerts are only shown in the app.
+func (s *NotificationService) StaffOutbox(event entities.NotificationEvent, permission string) repositories.OutboxBuilder {
+	return func(booking *entities.Booking) (*repositories.Outbox, error) {
+		roles, err := s.roleService.GetRolesWithPermission(permission)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get roles with permission %s: %w", permission, err)
+		}
+		staff, err := s.userRepo.FindByRoles(roles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get staff users: %w", err)
+		}
+
+		// Staff users can prefer different languages, the data is formatted once per language
+		data := make(map[i18n.Locale]bookingNotificationData)
+		notifications := make([]entities.Notification, 0, len(staff))
+		for _, user := range staff {
+			locale := i18n.Resolve(user.Locale)
+			if _, ok := data[locale]; !ok {
+				data[locale] = newBookingNotificationData(booking, locale)
+			}
+			alert, err := newInboxNotification(user.ID, event, booking.ID, locale, data[locale])
+			if err != nil {
+				return nil, err
+			}
+			notifications = append(notifications, *alert)
+		}
+		return &repositories.Outbox{Notifications: notifications}, nil
+	}
+}
+
+// newInboxNotification renders the in-app notification of an event for a user in their language
+func newInboxNotification(userID uint, event entities.NotificationEvent, bookingID uint, locale i18n.Locale, data bookingNotificationData) (*entities.Notification, error) {
+	title, body, err := renderNotification(event, entities.NotificationChannelInApp, locale, data)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.Notification{
+		UserID:    userID,
+		Event:     event,
+		BookingID: &bookingID,
+		Title:     title,
+		Body:      body,
+	}, nil
+}
+
+// DispatchPending delivers the outbox messages that are due. Failed deliveries are retried with
+// exponential backoff until the maximum number of attempts is reached.
+func (s *NotificationService) DispatchPending() error {
+	messages, err := s.notificationRepo.ClaimDueMessages(time.Now(), s.cfg.BatchSize, notificationSendLease)
+	if err != nil {
+		return fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	for _, message := range messages {
+		attempts := message.Attempts + 1
+
+		if err := s.deliver(message); err != nil {
+			lastError := truncate(err.Error(), 500)
+			if attempts >= s.cfg.MaxAttempts {
+				log.Printf("Giving up on notification #%d (%s via %s) after %d attempts: %v", message.ID, message.Event, message.Channel, attempts, err)
+				err = s.notificationRepo.MarkFailed(message.ID, attempts, lastError)
+			} else {
+				err = s.notificationRepo.MarkRetry(message.ID, attempts, time.Now().Add(s.retryBackoff(attempts)), lastError)
+			}
+			if err != nil {
+				log.Printf("Failed to update notification #%d: %v", message.ID, err)
+			}
+			continue
+		}
+
+		if err := s.notificationRepo.MarkSent(message.ID, attempts, time.Now()); err != nil {
+			log.Printf("Failed to mark notification #%d as sent: %v", message.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *NotificationService) deliver(message entities.OutboxMessage) error {
+	channel, ok := s.channels[message.Channel]
+	if !ok {
+		return fmt.Errorf("notification channel %s is not enabled", message.Channel)
+	}
+	return channel.Send(message.Recipient, message.Subject, message.Body)
+}
+
+// retryBackoff doubles the delay with every failed attempt, up to the configured maximum
+func (s *NotificationService) retryBackoff(attempts int) time.Duration {
+	backoff := s.cfg.RetryBackoff
+	for i := 1; i < attempts && backoff < s.cfg.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.cfg.MaxRetryBackoff {
+		backoff = s.cfg.MaxRetryBackoff
+	}
+	return backoff
+}
+
+// notificationRecipient returns the address of the user on a channel, empty if the user has none
+func notificationRecipient(user *entities.User, channel entities.NotificationChannel) string {
+	switch channel {
+	case entities.NotificationChannelEmail:
+		return user.Email
+	case entities.NotificationChannelSMS, entities.NotificationChannelWhatsApp:
+		return user.PhoneNumber
+	default:
+		return ""
+	}
+}
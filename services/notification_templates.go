@@ -0,0 +1,338 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"malakashuttle/entities"
+	"malakashuttle/i18n"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// notificationTemplate holds the texts of one event. Email uses the subject and the email body,
+// SMS and WhatsApp use the short text, the in-app inbox uses the subject as title and the short text.
+type notificationTemplate struct {
+	subject *template.Template
+	email   *template.Template
+	text    *template.Template
+}
+
+func newNotificationTemplate(name, subject, email, text string) notificationTemplate {
+	return notificationTemplate{
+		subject: template.Must(template.New(name + ".subject").Parse(subject)),
+		email:   template.Must(template.New(name + ".email").Parse(email)),
+		text:    template.Must(template.New(name + ".text").Parse(text)),
+	}
+}
+
+// notificationTemplates holds the texts of every event per language. English is the fallback
+// when an event has no text in the language of the recipient.
+var notificationTemplates = map[entities.NotificationEvent]map[i18n.Locale]notificationTemplate{
+	entities.NotificationEventBookingCreated: {
+		i18n.EN: newNotificationTemplate("booking_created.en",
+			`Booking #{{.BookingID}} created, please complete your payment`,
+			`Hi {{.Name}},
+
+Your booking #{{.BookingID}} for {{.Origin}} - {{.Destination}} departing {{.DepartureTime}} has been created.
+Seats: {{.Seats}}
+
+Please transfer exactly {{.Amount}} before {{.ExpiresAt}}. The booking expires and the seats are released if the payment is not received in time.
+
+Malaka Shuttle`,
+			`Booking #{{.BookingID}} {{.Origin}}-{{.Destination}} {{.DepartureTime}} created. Transfer exactly {{.Amount}} before {{.ExpiresAt}}.`,
+		),
+		i18n.ID: newNotificationTemplate("booking_created.id",
+			`Pemesanan #{{.BookingID}} dibuat, silakan selesaikan pembayaran`,
+			`Halo {{.Name}},
+
+Pemesanan #{{.BookingID}} untuk {{.Origin}} - {{.Destination}} dengan keberangkatan {{.DepartureTime}} telah dibuat.
+Kursi: {{.Seats}}
+
+Silakan transfer tepat {{.Amount}} sebelum {{.ExpiresAt}}. Pemesanan akan kedaluwarsa dan kursi dilepas jika pembayaran tidak diterima tepat waktu.
+
+Malaka Shuttle`,
+			`Pemesanan #{{.BookingID}} {{.Origin}}-{{.Destination}} {{.DepartureTime}} dibuat. Transfer tepat {{.Amount}} sebelum {{.ExpiresAt}}.`,
+		),
+	},
+	entities.NotificationEventBookingApproved: {
+		i18n.EN: newNotificationTemplate("booking_approved.en",
+			`Booking #{{.BookingID}} confirmed`,
+			`Hi {{.Name}},
+
+We received your payment of {{.Amount}}. Your booking #{{.BookingID}} for {{.Origin}} - {{.Destination}} departing {{.DepartureTime}} is confirmed.
+Seats: {{.Seats}}
+Pickup point: {{.PickupPoint}}
+
+Please arrive at least 15 minutes before departure.
+
+Malaka Shuttle`,
+			`Booking #{{.BookingID}} {{.Origin}}-{{.Destination}} {{.DepartureTime}} is confirmed. Seats: {{.Seats}}.`,
+		),
+		i18n.ID: newNotificationTemplate("booking_approved.id",
+			`Pemesanan #{{.BookingID}} terkonfirmasi`,
+			`Halo {{.Name}},
+
+Pembayaran Anda sebesar {{.Amount}} telah kami terima. Pemesanan #{{.BookingID}} untuk {{.Origin}} - {{.Destination}} dengan keberangkatan {{.DepartureTime}} telah terkonfirmasi.
+Kursi: {{.Seats}}
+Titik penjemputan: {{.PickupPoint}}
+
+Harap tiba paling lambat 15 menit sebelum keberangkatan.
+
+Malaka Shuttle`,
+			`Pemesanan #{{.BookingID}} {{.Origin}}-{{.Destination}} {{.DepartureTime}} terkonfirmasi. Kursi: {{.Seats}}.`,
+		),
+	},
+	entities.NotificationEventBookingRejected: {
+		i18n.EN: newNotificationTemplate("booking_rejected.en",
+			`Booking #{{.BookingID}} rejected`,
+			`Hi {{.Name}},
+
+We could not verify the payment for your booking #{{.BookingID}} for {{.Origin}} - {{.Destination}} departing {{.DepartureTime}}, so the booking was rejected and the seats were released.
+
+Please contact us if you believe this is a mistake.
+
+Malaka Shuttle`,
+			`The payment for booking #{{.BookingID}} could not be verified, the booking was rejected. Contact us if this is a mistake.`,
+		),
+		i18n.ID: newNotificationTemplate("booking_rejected.id",
+			`Pemesanan #{{.BookingID}} ditolak`,
+			`Halo {{.Name}},
+
+Kami tidak dapat memverifikasi pembayaran untuk pemesanan #{{.BookingID}} {{.Origin}} - {{.Destination}} dengan keberangkatan {{.DepartureTime}}, sehingga pemesanan ditolak dan kursi telah dilepas.
+
+Silakan hubungi kami jika menurut Anda ini adalah kekeliruan.
+
+Malaka Shuttle`,
+			`Pembayaran pemesanan #{{.BookingID}} tidak dapat diverifikasi, pemesanan ditolak. Hubungi kami jika ini kekeliruan.`,
+		),
+	},
+	entities.NotificationEventBookingExpired: {
+		i18n.EN: newNotificationTemplate("booking_expired.en",
+			`Booking #{{.BookingID}} expired`,
+			`Hi {{.Name}},
+
+Your booking #{{.BookingID}} for {{.Origin}} - {{.Destination}} departing {{.DepartureTime}} expired because the payment was not received before {{.ExpiresAt}}. The seats were released.
+
+You are welcome to book again if seats are still available.
+
+Malaka Shuttle`,
+			`Booking #{{.BookingID}} expired because the payment was not received in time. The seats were released.`,
+		),
+		i18n.ID: newNotificationTemplate("booking_expired.id",
+			`Pemesanan #{{.BookingID}} kedaluwarsa`,
+			`Halo {{.Name}},
+
+Pemesanan #{{.BookingID}} untuk {{.Origin}} - {{.Destination}} dengan keberangkatan {{.DepartureTime}} telah kedaluwarsa karena pembayaran tidak diterima sebelum {{.ExpiresAt}}. Kursi telah dilepas.
+
+Silakan memesan kembali jika kursi masih tersedia.
+
+Malaka Shuttle`,
+			`Pemesanan #{{.BookingID}} kedaluwarsa karena pembayaran tidak diterima tepat waktu. Kursi telah dilepas.`,
+		),
+	},
+	entities.NotificationEventBookingCancelled: {
+		i18n.EN: newNotificationTemplate("booking_cancelled.en",
+			`Trip cancelled: booking #{{.BookingID}}`,
+			`Hi {{.Name}},
+
+We are sorry to tell you that the trip {{.Origin}} - {{.Destination}} departing {{.DepartureTime}} has been cancelled, so your booking #{{.BookingID}} was cancelled as well.
+Reason: {{.CancellationReason}}
+
+If you already paid, our team will contact you about the refund.
+
+Malaka Shuttle`,
+			`Trip {{.Origin}}-{{.Destination}} {{.DepartureTime}} is cancelled, booking #{{.BookingID}} was cancelled. Reason: {{.CancellationReason}}`,
+		),
+		i18n.ID: newNotificationTemplate("booking_cancelled.id",
+			`Perjalanan dibatalkan: pemesanan #{{.BookingID}}`,
+			`Halo {{.Name}},
+
+Mohon maaf, perjalanan {{.Origin}} - {{.Destination}} dengan keberangkatan {{.DepartureTime}} dibatalkan, sehingga pemesanan #{{.BookingID}} juga dibatalkan.
+Alasan: {{.CancellationReason}}
+
+Jika Anda sudah membayar, tim kami akan menghubungi Anda terkait pengembalian dana.
+
+Malaka Shuttle`,
+			`Perjalanan {{.Origin}}-{{.Destination}} {{.DepartureTime}} dibatalkan, pemesanan #{{.BookingID}} ikut dibatalkan. Alasan: {{.CancellationReason}}`,
+		),
+	},
+	entities.NotificationEventPaymentReminder: {
+		i18n.EN: newNotificationTemplate("payment_reminder.en",
+			`Booking #{{.BookingID}} expires soon, please complete your payment`,
+			`Hi {{.Name}},
+
+Your booking #{{.BookingID}} for {{.Origin}} - {{.Destination}} departing {{.DepartureTime}} is still waiting for payment.
+Seats: {{.Seats}}
+
+Please transfer exactly {{.Amount}} before {{.ExpiresAt}}, otherwise the booking expires and the seats are released.
+
+Malaka Shuttle`,
+			`Booking #{{.BookingID}} expires at {{.ExpiresAt}}. Transfer exactly {{.Amount}} to keep your seats.`,
+		),
+		i18n.ID: newNotificationTemplate("payment_reminder.id",
+			`Pemesanan #{{.BookingID}} segera kedaluwarsa, silakan selesaikan pembayaran`,
+			`Halo {{.Name}},
+
+Pemesanan #{{.BookingID}} untuk {{.Origin}} - {{.Destination}} dengan keberangkatan {{.DepartureTime}} masih menunggu pembayaran.
+Kursi: {{.Seats}}
+
+Silakan transfer tepat {{.Amount}} sebelum {{.ExpiresAt}}, jika tidak pemesanan akan kedaluwarsa dan kursi dilepas.
+
+Malaka Shuttle`,
+			`Pemesanan #{{.BookingID}} kedaluwarsa pada {{.ExpiresAt}}. Transfer tepat {{.Amount}} agar kursi Anda tidak dilepas.`,
+		),
+	},
+	entities.NotificationEventPaymentProofUploaded: {
+		i18n.EN: newNotificationTemplate("payment_proof_uploaded.en",
+			`Payment proof for booking #{{.BookingID}} awaiting verification`,
+			`A payment proof was uploaded for booking #{{.BookingID}} and is waiting for verification.
+
+Customer: {{.Name}}
+Route: {{.Origin}} - {{.Destination}}
+Departure: {{.DepartureTime}}
+Amount: {{.Amount}}
+
+Malaka Shuttle`,
+			`{{.Name}} uploaded a payment proof of {{.Amount}} for booking #{{.BookingID}} {{.Origin}}-{{.Destination}} {{.DepartureTime}}.`,
+		),
+		i18n.ID: newNotificationTemplate("payment_proof_uploaded.id",
+			`Bukti pembayaran pemesanan #{{.BookingID}} menunggu verifikasi`,
+			`Bukti pembayaran untuk pemesanan #{{.BookingID}} telah diunggah dan menunggu verifikasi.
+
+Pelanggan: {{.Name}}
+Rute: {{.Origin}} - {{.Destination}}
+Keberangkatan: {{.DepartureTime}}
+Jumlah: {{.Amount}}
+
+Malaka Shuttle`,
+			`{{.Name}} mengunggah bukti pembayaran {{.Amount}} untuk pemesanan #{{.BookingID}} {{.Origin}}-{{.Destination}} {{.DepartureTime}}.`,
+		),
+	},
+	entities.NotificationEventDepartureReminder: {
+		i18n.EN: newNotificationTemplate("departure_reminder.en",
+			`Reminder: your trip to {{.Destination}} departs {{.DepartureTime}}`,
+			`Hi {{.Name}},
+
+This is a reminder for your booking #{{.BookingID}}.
+
+Route: {{.Origin}} - {{.Destination}}
+Departure: {{.DepartureTime}}
+Pickup point: {{.PickupPoint}}
+Seats: {{.Seats}}
+
+Please arrive at the pickup point at least 15 minutes before departure.
+
+Malaka Shuttle`,
+			`Booking #{{.BookingID}} {{.Origin}}-{{.Destination}} departs {{.DepartureTime}} from {{.PickupPoint}}. Seats: {{.Seats}}.`,
+		),
+		i18n.ID: newNotificationTemplate("departure_reminder.id",
+			`Pengingat: perjalanan Anda ke {{.Destination}} berangkat {{.DepartureTime}}`,
+			`Halo {{.Name}},
+
+Ini adalah pengingat untuk pemesanan #{{.BookingID}}.
+
+Rute: {{.Origin}} - {{.Destination}}
+Keberangkatan: {{.DepartureTime}}
+Titik penjemputan: {{.PickupPoint}}
+Kursi: {{.Seats}}
+
+Harap tiba di titik penjemputan paling lambat 15 menit sebelum keberangkatan.
+
+Malaka Shuttle`,
+			`Pemesanan #{{.BookingID}} {{.Origin}}-{{.Destination}} berangkat {{.DepartureTime}} dari {{.PickupPoint}}. Kursi: {{.Seats}}.`,
+		),
+	},
+}
+
+// bookingNotificationData is what the booking templates can use
+type bookingNotificationData struct {
+	Name          string
+	BookingID     uint
+	Origin        string
+	Destination   string
+	DepartureTime string
+	PickupPoint   string
+	Seats         string
+	Amount        string
+	ExpiresAt     string
+	// CancellationReason is set when the schedule was cancelled
+	CancellationReason string
+}
+
+// newBookingNotificationData expects the booking with its user, schedule route and seats loaded.
+// Dates and amounts are formatted for the language of the recipient.
+func newBookingNotificationData(booking *entities.Booking, locale i18n.Locale) bookingNotificationData {
+	loc := wibLocation()
+
+	seats := make([]string, 0, len(booking.BookingDetails))
+	for _, detail := range booking.BookingDetails {
+		seats = append(seats, detail.Seat.SeatNumber)
+	}
+	sort.Strings(seats)
+
+	// Passengers board in the origin city when the schedule has no specific pickup point
+	pickupPoint := booking.Schedule.PickupPoint
+	if pickupPoint == "" {
+		pickupPoint = booking.Schedule.Route.OriginCity
+	}
+
+	name := strings.TrimSpace(booking.User.FirstName)
+	if name == "" {
+		name = i18n.T(locale, "Customer")
+	}
+
+	return bookingNotificationData{
+		Name:               name,
+		BookingID:          booking.ID,
+		Origin:             booking.Schedule.Route.OriginCity,
+		Destination:        booking.Schedule.Route.DestinationCity,
+		DepartureTime:      i18n.FormatDateTime(locale, booking.Schedule.DepartureTime.In(loc)) + " WIB",
+		PickupPoint:        pickupPoint,
+		Seats:              strings.Join(seats, ", "),
+		Amount:             i18n.FormatCurrency(locale, booking.ChargedAmount()),
+		ExpiresAt:          i18n.FormatDateTime(locale, booking.ExpiresAt.In(loc)) + " WIB",
+		CancellationReason: booking.Schedule.CancellationReason,
+	}
+}
+
+// renderNotification renders the subject and body of an event for a channel in the given language
+func renderNotification(event entities.NotificationEvent, channel entities.NotificationChannel, locale i18n.Locale, data interface{}) (string, string, error) {
+	translations, ok := notificationTemplates[event]
+	if !ok {
+		return "", "", fmt.Errorf("no template for notification event %s", event)
+	}
+	tmpl, ok := translations[locale]
+	if !ok {
+		tmpl = translations[i18n.EN]
+	}
+
+	switch channel {
+	case entities.NotificationChannelEmail:
+		subject, err := executeTemplate(tmpl.subject, data)
+		if err != nil {
+			return "", "", err
+		}
+		body, err := executeTemplate(tmpl.email, data)
+		return subject, body, err
+	case entities.NotificationChannelInApp:
+		title, err := executeTemplate(tmpl.subject, data)
+		if err != nil {
+			return "", "", err
+		}
+		body, err := executeTemplate(tmpl.text, data)
+		return title, body, err
+	default:
+		// Text messages don't show a sender name, so they are prefixed with it
+		body, err := executeTemplate(tmpl.text, data)
+		return "", "Malaka Shuttle: " + body, err
+	}
+}
+
+func executeTemplate(tmpl *template.Template, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
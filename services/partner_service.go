@@ -0,0 +1,498 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"malakashuttle/config"
+	"malakashuttle/constants"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const apiKeyPrefix = "msk_"
+
+// PartnerService manages partners and authenticates their API keys
+type PartnerService struct {
+	partnerRepo repositories.PartnerRepository
+	roleService *RoleService
+	limiter     *apiKeyRateLimiter
+}
+
+func NewPartnerService(partnerRepo repositories.PartnerRepository, roleService *RoleService) *PartnerService {
+	return &PartnerService{
+		partnerRepo: partnerRepo,
+		roleService: roleService,
+		limiter:     newAPIKeyRateLimiter(),
+	}
+}
+
+// ResolveAPIKey authenticates a request made with an API key. The principal acts as the
+// partner's service user and only has the key's scopes that the partner role still grants.
+func (s *PartnerService) ResolveAPIKey(rawKey, clientIP string) (*utils.Principal, error) {
+	key, err := s.partnerRepo.FindAPIKeyByHash(utils.HashOpaqueToken(rawKey))
+	if err != nil {
+		return nil, utils.NewUnauthorizedError("Invalid API key", nil)
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) {
+		return nil, utils.NewUnauthorizedError("API key has expired or been revoked", nil)
+	}
+	if !key.Partner.IsActive || key.Partner.User.ID == 0 {
+		return nil, utils.NewForbiddenError("Partner account is disabled", nil)
+	}
+	if !isIPAllowed(clientIP, key.AllowedIPList()) {
+		return nil, utils.NewForbiddenErrorWithDetails("API key cannot be used from this IP address", nil,
+			map[string]string{"client_ip": clientIP})
+	}
+	if allowed, retryAfter := s.limiter.allow(key.ID, key.RateLimitPerMinute, now); !allowed {
+		return nil, utils.NewTooManyRequestsErrorWithDetails("API key rate limit exceeded", nil,
+			map[string]interface{}{
+				"limit_per_minute":    key.RateLimitPerMinute,
+				"retry_after_seconds": int(retryAfter.Seconds()) + 1,
+			})
+	}
+
+	rolePermissions, err := s.roleService.GetPermissions(constants.ROLE_PARTNER)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to load permissions", err)
+	}
+	permissions := make(map[string]bool)
+	for _, scope := range key.ScopeList() {
+		if rolePermissions[scope] {
+			permissions[scope] = true
+		}
+	}
+
+	partnerID := key.PartnerID
+	return &utils.Principal{
+		UserID:                key.Partner.UserID,
+		Email:                 key.Partner.User.Email,
+		Role:                  constants.ROLE_PARTNER,
+		Permissions:           permissions,
+		APIKeyID:              key.ID,
+		PartnerID:             &partnerID,
+		PartnerCommissionRate: key.Partner.CommissionRate,
+		Locale:                key.Partner.User.Locale,
+	}, nil
+}
+
+// RecordAPIKeyUsage counts a finished request in the usage statistics of the key
+func (s *PartnerService) RecordAPIKeyUsage(keyID uint, clientIP string, statusCode int) {
+	if err := s.partnerRepo.RecordUsage(keyID, time.Now().In(wibLocation()), clientIP, statusCode >= 400); err != nil {
+		log.Printf("Failed to record usage of api key #%d: %v", keyID, err)
+	}
+}
+
+// CreatePartner creates a partner with a service user that owns the partner's bookings
+func (s *PartnerService) CreatePartner(req dto.CreatePartnerRequest) (*dto.PartnerResponse, error) {
+	suffix, _, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to create partner", err)
+	}
+	password, _, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to create partner", err)
+	}
+
+	// The service user cannot log in, nobody knows its random password
+	user := &entities.User{
+		Email:     fmt.Sprintf("partner-%s@partners.malakashuttle.local", strings.ToLower(suffix[:10])),
+		Password:  password,
+		Role:      constants.ROLE_PARTNER,
+		FirstName: req.Name,
+		LastName:  "(partner)",
+	}
+	if err := user.HashPassword(); err != nil {
+		return nil, utils.NewInternalServerError("Failed to create partner", err)
+	}
+	user.MarkVerified()
+
+	partner := &entities.Partner{
+		Name:           req.Name,
+		ContactEmail:   req.ContactEmail,
+		CommissionRate: req.CommissionRate,
+		IsActive:       true,
+	}
+	if err := s.partnerRepo.CreatePartner(partner, user); err != nil {
+		return nil, utils.NewInternalServerError("Failed to create partner", err)
+	}
+
+	response := dto.NewPartnerResponseFromEntity(partner)
+	return &response, nil
+}
+
+func (s *PartnerService) GetPartners() ([]dto.PartnerResponse, error) {
+	partners, err := s.partnerRepo.GetPartners()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get partners", err)
+	}
+
+	responses := make([]dto.PartnerResponse, len(partners))
+	for i := range partners {
+		responses[i] = dto.NewPartnerResponseFromEntity(&partners[i])
+	}
+	return responses, nil
+}
+
+func (s *PartnerService) GetPartnerByID(id uint) (*dto.PartnerResponse, error) {
+	partner, err := s.getPartner(id)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.NewPartnerResponseFromEntity(partner)
+	return &response, nil
+}
+
+// UpdatePartner changes the partner details. A new commission rate only applies to new bookings.
+func (s *PartnerService) UpdatePartner(id uint, req dto.UpdatePartnerRequest) (*dto.PartnerResponse, error) {
+	partner, err := s.getPartner(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		partner.Name = req.Name
+	}
+	if req.ContactEmail != "" {
+		partner.ContactEmail = req.ContactEmail
+	}
+	if req.CommissionRate != nil {
+		partner.CommissionRate = *req.CommissionRate
+	}
+	if req.IsActive != nil {
+		partner.IsActive = *req.IsActive
+	}
+	if err := s.partnerRepo.UpdatePartner(partner); err != nil {
+		return nil, utils.NewInternalServerError("Failed to update partner", err)
+	}
+
+	response := dto.NewPartnerResponseFromEntity(partner)
+	return &response, nil
+}
+
+// CreateAPIKey issues a new key for the partner. The plain key is only returned here.
+func (s *PartnerService) CreateAPIKey(partnerID uint, req dto.CreateAPIKeyRequest) (*dto.CreatedAPIKeyResponse, error) {
+	if _, err := s.getPartner(partnerID); err != nil {
+		return nil, err
+	}
+	if err := s.validateScopes(req.Scopes); err != nil {
+		return nil, err
+	}
+	if err := validateAllowedIPs(req.AllowedIPs); err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && req.ExpiresAt.Before(time.Now()) {
+		return nil, utils.NewBadRequestError("expires_at must be in the future", nil)
+	}
+
+	rateLimit := req.RateLimitPerMinute
+	if rateLimit == 0 {
+		rateLimit = config.GetAPIKeyDefaultRateLimit()
+	}
+
+	key := &entities.APIKey{
+		PartnerID:          partnerID,
+		Name:               req.Name,
+		Scopes:             strings.Join(uniquePermissions(req.Scopes), ","),
+		RateLimitPerMinute: rateLimit,
+		AllowedIPs:         strings.Join(req.AllowedIPs, ","),
+		ExpiresAt:          req.ExpiresAt,
+	}
+	rawKey, err := assignNewAPIKey(key)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to generate API key", err)
+	}
+	if err := s.partnerRepo.CreateAPIKey(key); err != nil {
+		return nil, utils.NewInternalServerError("Failed to create API key", err)
+	}
+
+	return &dto.CreatedAPIKeyResponse{APIKeyResponse: dto.NewAPIKeyResponseFromEntity(key), Key: rawKey}, nil
+}
+
+func (s *PartnerService) GetAPIKeys(partnerID uint) ([]dto.APIKeyResponse, error) {
+	if _, err := s.getPartner(partnerID); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.partnerRepo.GetAPIKeys(partnerID)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get API keys", err)
+	}
+
+	responses := make([]dto.APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = dto.NewAPIKeyResponseFromEntity(&keys[i])
+	}
+	return responses, nil
+}
+
+// RotateAPIKey issues a replacement with the same settings. The old key keeps working for
+// the grace period so the partner can deploy the new key without downtime.
+func (s *PartnerService) RotateAPIKey(partnerID, keyID uint) (*dto.CreatedAPIKeyResponse, error) {
+	old, err := s.getAPIKey(partnerID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if !old.IsUsable(time.Now()) {
+		return nil, utils.NewBadRequestError("Only active API keys can be rotated", nil)
+	}
+	if old.RotatedToID != nil {
+		return nil, utils.NewConflictError("API key has already been rotated", nil)
+	}
+
+	next := &entities.APIKey{
+		PartnerID:          old.PartnerID,
+		Name:               old.Name,
+		Scopes:             old.Scopes,
+		RateLimitPerMinute: old.RateLimitPerMinute,
+		AllowedIPs:         old.AllowedIPs,
+		ExpiresAt:          old.ExpiresAt,
+	}
+	rawKey, err := assignNewAPIKey(next)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to generate API key", err)
+	}
+	if err := s.partnerRepo.RotateAPIKey(old, next, time.Now().Add(config.GetAPIKeyRotationGracePeriod())); err != nil {
+		return nil, utils.NewInternalServerError("Failed to rotate API key", err)
+	}
+
+	return &dto.CreatedAPIKeyResponse{APIKeyResponse: dto.NewAPIKeyResponseFromEntity(next), Key: rawKey}, nil
+}
+
+// RevokeAPIKey stops a key from working immediately
+func (s *PartnerService) RevokeAPIKey(partnerID, keyID uint) error {
+	key, err := s.getAPIKey(partnerID, keyID)
+	if err != nil {
+		return err
+	}
+	if err := s.partnerRepo.RevokeAPIKey(key.ID); err != nil {
+		return utils.NewInternalServerError("Failed to revoke API key", err)
+	}
+	return nil
+}
+
+// GetUsage returns the daily request counts of the partner's keys
+func (s *PartnerService) GetUsage(partnerID uint, query dto.PartnerReportQuery) (*dto.PartnerUsageResponse, error) {
+	if _, err := s.getPartner(partnerID); err != nil {
+		return nil, err
+	}
+	from, to, err := parsePartnerReportPeriod(query)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.partnerRepo.GetUsage(partnerID, from, to)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get API key usage", err)
+	}
+
+	response := &dto.PartnerUsageResponse{
+		PartnerID: partnerID,
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Daily:     make([]dto.APIKeyUsageResponse, len(usage)),
+	}
+	for i, day := range usage {
+		response.Daily[i] = dto.APIKeyUsageResponse{
+			APIKeyID:     day.APIKeyID,
+			Date:         day.Date.Format("2006-01-02"),
+			RequestCount: day.RequestCount,
+			ErrorCount:   day.ErrorCount,
+		}
+		response.TotalRequests += day.RequestCount
+		response.TotalErrors += day.ErrorCount
+	}
+	return response, nil
+}
+
+// GetCommission totals the successful bookings made with the partner's keys and their commission
+func (s *PartnerService) GetCommission(partnerID uint, query dto.PartnerReportQuery) (*dto.PartnerCommissionResponse, error) {
+	partner, err := s.getPartner(partnerID)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := parsePartnerReportPeriod(query)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.partnerRepo.GetCommissionSummary(partnerID, from, to.Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get commission report", err)
+	}
+
+	return &dto.PartnerCommissionResponse{
+		PartnerID:      partner.ID,
+		PartnerName:    partner.Name,
+		From:           from.Format("2006-01-02"),
+		To:             to.Format("2006-01-02"),
+		BookingCount:   summary.BookingCount,
+		TicketAmount:   summary.TicketAmount,
+		Commission:     summary.Commission,
+		CommissionRate: partner.CommissionRate,
+	}, nil
+}
+
+func (s *PartnerService) getPartner(id uint) (*entities.Partner, error) {
+	partner, err := s.partnerRepo.GetPartnerByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Partner not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get partner", err)
+	}
+	return partner, nil
+}
+
+func (s *PartnerService) getAPIKey(partnerID, keyID uint) (*entities.APIKey, error) {
+	key, err := s.partnerRepo.GetAPIKey(keyID, partnerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("API key not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get API key", err)
+	}
+	return key, nil
+}
+
+// validateScopes only allows scopes the partner role grants
+func (s *PartnerService) validateScopes(scopes []string) error {
+	rolePermissions, err := s.roleService.GetPermissions(constants.ROLE_PARTNER)
+	if err != nil {
+		return utils.NewInternalServerError("Failed to load permissions", err)
+	}
+
+	for _, scope := range scopes {
+		if !rolePermissions[scope] {
+			allowed := make([]string, 0, len(rolePermissions))
+			for permission := range rolePermissions {
+				allowed = append(allowed, permission)
+			}
+			sort.Strings(allowed)
+			return utils.NewBadRequestErrorWithDetails(fmt.Sprintf("Scope %s is not available for partners", scope), nil,
+				map[string]interface{}{"allowed_scopes": allowed})
+		}
+	}
+	return nil
+}
+
+// assignNewAPIKey generates a key, stores its hash and prefix on the entity and returns the plain key
+func assignNewAPIKey(key *entities.APIKey) (string, error) {
+	token, _, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	rawKey := apiKeyPrefix + token
+	key.KeyHash = utils.HashOpaqueToken(rawKey)
+	key.Prefix = rawKey[:12]
+	return rawKey, nil
+}
+
+func validateAllowedIPs(entries []string) error {
+	for _, entry := range entries {
+		if strings.Contains(entry, ",") {
+			return utils.NewBadRequestError(fmt.Sprintf("Invalid IP address or CIDR: %s", entry), nil)
+		}
+		if _, _, err := net.ParseCIDR(entry); err == nil {
+			continue
+		}
+		if net.ParseIP(entry) == nil {
+			return utils.NewBadRequestError(fmt.Sprintf("Invalid IP address or CIDR: %s", entry), nil)
+		}
+	}
+	return nil
+}
+
+// isIPAllowed reports whether the client IP matches the allowlist, an empty allowlist allows every IP
+func isIPAllowed(clientIP string, allowlist []string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	for _, entry := range allowlist {
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePartnerReportPeriod parses the report period in WIB, defaulting to the last 30 days
+func parsePartnerReportPeriod(query dto.PartnerReportQuery) (time.Time, time.Time, error) {
+	loc := wibLocation()
+	now := time.Now().In(loc)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	from := to.AddDate(0, 0, -29)
+
+	if query.From != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", query.From, loc)
+		if err != nil {
+			return from, to, utils.NewBadRequestError("Invalid from format, use YYYY-MM-DD", nil)
+		}
+		from = parsed
+	}
+	if query.To != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", query.To, loc)
+		if err != nil {
+			return from, to, utils.NewBadRequestError("Invalid to format, use YYYY-MM-DD", nil)
+		}
+		to = parsed
+	}
+	if to.Before(from) {
+		return from, to, utils.NewBadRequestError("to must be after from", nil)
+	}
+	return from, to, nil
+}
+
+// apiKeyRateLimiter counts requests per key in fixed one minute windows. The counters are kept
+// in memory, so with several replicas each replica enforces the limit on its own.
+type apiKeyRateLimiter struct {
+	mu      sync.Mutex
+	windows map[uint]*rateWindow
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func newAPIKeyRateLimiter() *apiKeyRateLimiter {
+	return &apiKeyRateLimiter{windows: make(map[uint]*rateWindow)}
+}
+
+// allow counts a request and reports whether it is within the limit, otherwise how long to wait
+func (l *apiKeyRateLimiter) allow(keyID uint, limit int, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	window, ok := l.windows[keyID]
+	if !ok || now.Sub(window.start) >= time.Minute {
+		window = &rateWindow{start: now.Truncate(time.Minute)}
+		l.windows[keyID] = window
+	}
+	if window.count >= limit {
+		return false, window.start.Add(time.Minute).Sub(now)
+	}
+	window.count++
+	return true, 0
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"errors"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/utils"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	nikPattern      = regexp.MustCompile(`^[0-9]{16}$`)
+	passportPattern = regexp.MustCompile(`^[A-Z0-9]{6,9}$`)
+	phonePattern    = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	phoneFormatting = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+)
+
+// normalizePassengerIdentity validates the identity of a passenger and brings it into canonical form:
+// upper case ID numbers and phone numbers in E.164 (+62...). The gender is derived from a NIK when missing.
+func normalizePassengerIdentity(req dto.PassengerIdentityRequest) (dto.PassengerIdentityRequest, error) {
+	req.IDNumber = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(req.IDNumber), " ", ""))
+	req.Phone = phoneFormatting.Replace(strings.TrimSpace(req.Phone))
+
+	if (req.IDType == "") != (req.IDNumber == "") {
+		return req, errors.New("invalid passenger identity: id_type and id_number must be sent together")
+	}
+
+	switch entities.PassengerIDType(req.IDType) {
+	case entities.PassengerIDTypeNIK:
+		gender, err := genderFromNIK(req.IDNumber)
+		if err != nil {
+			return req, err
+		}
+		if req.Gender == "" {
+			req.Gender = string(gender)
+		} else if req.Gender != string(gender) {
+			return req, errors.New("invalid passenger identity: gender does not match the NIK")
+		}
+	case entities.PassengerIDTypePassport:
+		if !passportPattern.MatchString(req.IDNumber) {
+			return req, errors.New("invalid passenger identity: passport number must be 6 to 9 letters or digits")
+		}
+	}
+
+	if req.Phone != "" {
+		switch {
+		case strings.HasPrefix(req.Phone, "0"):
+			req.Phone = "+62" + req.Phone[1:]
+		case strings.HasPrefix(req.Phone, "62"):
+			req.Phone = "+" + req.Phone
+		}
+		if !phonePattern.MatchString(req.Phone) {
+			return req, errors.New("invalid passenger identity: phone must be a valid phone number, e.g. 081234567890")
+		}
+	}
+
+	return req, nil
+}
+
+// genderFromNIK checks the structure of a NIK: 6 digit region code, birth date (day + 40 for women)
+// and a 4 digit serial number. It returns the gender encoded in the birth day.
+func genderFromNIK(nik string) (entities.Gender, error) {
+	if !nikPattern.MatchString(nik) {
+		return "", errors.New("invalid passenger identity: NIK must be 16 digits")
+	}
+
+	day, _ := strconv.Atoi(nik[6:8])
+	month, _ := strconv.Atoi(nik[8:10])
+	if nik[:2] == "00" || month < 1 || month > 12 {
+		return "", errors.New("invalid passenger identity: NIK is not valid")
+	}
+
+	switch {
+	case day >= 1 && day <= 31:
+		return entities.GenderMale, nil
+	case day >= 41 && day <= 71:
+		return entities.GenderFemale, nil
+	}
+	return "", errors.New("invalid passenger identity: NIK is not valid")
+}
+
+// isCompletePassengerIdentity reports whether every identity field is filled in
+func isCompletePassengerIdentity(req dto.PassengerIdentityRequest) bool {
+	return req.IDType != "" && req.IDNumber != "" && req.Phone != "" && req.Gender != ""
+}
+
+// mergePassengerIdentity fills the fields missing in req from a saved passenger
+func mergePassengerIdentity(req dto.PassengerIdentityRequest, saved *dto.PassengerIdentityResponse) dto.PassengerIdentityRequest {
+	if saved == nil {
+		return req
+	}
+	if req.IDType == "" && req.IDNumber == "" {
+		req.IDType = saved.IDType
+		req.IDNumber = saved.IDNumber
+	}
+	if req.Phone == "" {
+		req.Phone = saved.Phone
+	}
+	if req.Gender == "" {
+		req.Gender = saved.Gender
+	}
+	return req
+}
+
+// encryptPassengerIdentity converts a normalized identity into the entity stored in the database
+func encryptPassengerIdentity(req dto.PassengerIdentityRequest) (entities.PassengerIdentity, error) {
+	identity := entities.PassengerIdentity{
+		IDType: entities.PassengerIDType(req.IDType),
+		Gender: entities.Gender(req.Gender),
+	}
+
+	var err error
+	if req.IDNumber != "" {
+		if identity.IDNumber, err = utils.EncryptString(req.IDNumber); err != nil {
+			return identity, err
+		}
+	}
+	if req.Phone != "" {
+		if identity.Phone, err = utils.EncryptString(req.Phone); err != nil {
+			return identity, err
+		}
+	}
+	return identity, nil
+}
+
+// decryptPassengerIdentity returns the stored identity in plain text, or nil when none was stored
+func decryptPassengerIdentity(identity entities.PassengerIdentity) (*dto.PassengerIdentityResponse, error) {
+	if identity == (entities.PassengerIdentity{}) {
+		return nil, nil
+	}
+
+	response := &dto.PassengerIdentityResponse{
+		IDType: string(identity.IDType),
+		Gender: string(identity.Gender),
+	}
+
+	var err error
+	if identity.IDNumber != "" {
+		if response.IDNumber, err = utils.DecryptString(identity.IDNumber); err != nil {
+			return nil, err
+		}
+	}
+	if identity.Phone != "" {
+		if response.Phone, err = utils.DecryptString(identity.Phone); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,258 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ProfileService lets users manage their own account and saved passengers
+type ProfileService struct {
+	userRepo           repositories.UserRepository
+	sessionRepo        repositories.SessionRepository
+	savedPassengerRepo repositories.SavedPassengerRepository
+	verificationSvc    *VerificationService
+}
+
+func NewProfileService(
+	userRepo repositories.UserRepository,
+	sessionRepo repositories.SessionRepository,
+	savedPassengerRepo repositories.SavedPassengerRepository,
+	verificationSvc *VerificationService,
+) *ProfileService {
+	return &ProfileService{
+		userRepo:           userRepo,
+		sessionRepo:        sessionRepo,
+		savedPassengerRepo: savedPassengerRepo,
+		verificationSvc:    verificationSvc,
+	}
+}
+
+func (s *ProfileService) GetProfile(userID uint) (*dto.ProfileResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("User not found", nil)
+	}
+	return dto.NewProfileResponseFromEntity(user), nil
+}
+
+// UpdateProfile changes the name or phone number. A new phone number has to be verified again.
+func (s *ProfileService) UpdateProfile(userID uint, req dto.UpdateProfileRequest) (*dto.ProfileResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("User not found", nil)
+	}
+
+	if req.FirstName != nil {
+		user.FirstName = strings.TrimSpace(*req.FirstName)
+	}
+	if req.LastName != nil {
+		user.LastName = strings.TrimSpace(*req.LastName)
+	}
+	if req.Locale != nil {
+		user.Locale = *req.Locale
+	}
+
+	phoneChanged := false
+	if req.PhoneNumber != nil {
+		phone := strings.TrimSpace(*req.PhoneNumber)
+		if phone != user.PhoneNumber {
+			user.PhoneNumber = phone
+			user.PhoneVerifiedAt = nil
+			phoneChanged = true
+		}
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, utils.NewInternalServerError("Failed to update profile", err)
+	}
+
+	if phoneChanged {
+		if err := s.verificationSvc.SendPhoneVerification(user); err != nil {
+			log.Printf("Failed to send phone verification to user #%d: %v", user.ID, err)
+		}
+	}
+
+	return dto.NewProfileResponseFromEntity(user), nil
+}
+
+// ChangePassword sets a new password after checking the current one. Other sessions are
+// logged out; the current session stays active but needs a refreshed access token.
+func (s *ProfileService) ChangePassword(userID, sessionID uint, req dto.ChangePasswordRequest) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return utils.NewNotFoundError("User not found", nil)
+	}
+	if err := user.CheckPassword(req.CurrentPassword); err != nil {
+		return utils.NewBadRequestError("Current password is incorrect", nil)
+	}
+	if req.CurrentPassword == req.NewPassword {
+		return utils.NewBadRequestError("New password must be different from the current password", nil)
+	}
+
+	user.Password = req.NewPassword
+	if err := user.HashPassword(); err != nil {
+		return utils.NewInternalServerError("Failed to hash password", err)
+	}
+	user.BumpTokenVersion()
+	if err := s.userRepo.Update(user); err != nil {
+		return utils.NewInternalServerError("Failed to change password", err)
+	}
+
+	if _, err := s.sessionRepo.RevokeOtherSessions(user.ID, sessionID, entities.SessionRevokedPassword); err != nil {
+		return utils.NewInternalServerError("Failed to revoke other sessions", err)
+	}
+	return nil
+}
+
+// ChangeEmail stores the new address as pending and sends a verification link to it.
+// The email of the account only changes once the link is opened.
+func (s *ProfileService) ChangeEmail(userID uint, req dto.ChangeEmailRequest) (*dto.ProfileResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("User not found", nil)
+	}
+	if err := user.CheckPassword(req.Password); err != nil {
+		return nil, utils.NewBadRequestError("Password is incorrect", nil)
+	}
+
+	newEmail := strings.TrimSpace(req.NewEmail)
+	if strings.EqualFold(newEmail, user.Email) {
+		return nil, utils.NewBadRequestError("New email is the same as the current email", nil)
+	}
+	_, err = s.userRepo.FindByEmail(newEmail)
+	if err == nil {
+		return nil, utils.NewConflictError("Email is already used by another account", nil)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.NewInternalServerError("Failed to check email", err)
+	}
+
+	user.PendingEmail = newEmail
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, utils.NewInternalServerError("Failed to change email", err)
+	}
+	if err := s.verificationSvc.SendEmailChangeVerification(user); err != nil {
+		return nil, err
+	}
+
+	return dto.NewProfileResponseFromEntity(user), nil
+}
+
+// GetSavedPassengers lists the saved passengers of a user with masked identity details
+func (s *ProfileService) GetSavedPassengers(userID uint) ([]dto.SavedPassengerResponse, error) {
+	passengers, err := s.savedPassengerRepo.FindByUser(userID)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get saved passengers", err)
+	}
+
+	responses := make([]dto.SavedPassengerResponse, len(passengers))
+	for i := range passengers {
+		response, err := newSavedPassengerResponse(&passengers[i], true)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = *response
+	}
+	return responses, nil
+}
+
+// GetSavedPassenger gets a saved passenger with the full identity details, e.g. to edit it
+func (s *ProfileService) GetSavedPassenger(userID, passengerID uint) (*dto.SavedPassengerResponse, error) {
+	passenger, err := s.getSavedPassenger(userID, passengerID)
+	if err != nil {
+		return nil, err
+	}
+	return newSavedPassengerResponse(passenger, false)
+}
+
+func (s *ProfileService) CreateSavedPassenger(userID uint, req dto.SavedPassengerRequest) (*dto.SavedPassengerResponse, error) {
+	identity, err := newSavedPassengerIdentity(req.PassengerIdentityRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	passenger := req.ToEntity(userID)
+	passenger.PassengerIdentity = identity
+	if err := s.savedPassengerRepo.Create(passenger); err != nil {
+		return nil, utils.NewInternalServerError("Failed to save passenger", err)
+	}
+
+	return newSavedPassengerResponse(passenger, true)
+}
+
+// UpdateSavedPassenger replaces the name, label and identity details of a saved passenger
+func (s *ProfileService) UpdateSavedPassenger(userID, passengerID uint, req dto.SavedPassengerRequest) (*dto.SavedPassengerResponse, error) {
+	passenger, err := s.getSavedPassenger(userID, passengerID)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := newSavedPassengerIdentity(req.PassengerIdentityRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	passenger.Label = req.Label
+	passenger.Name = req.Name
+	passenger.PassengerIdentity = identity
+	if err := s.savedPassengerRepo.Update(passenger); err != nil {
+		return nil, utils.NewInternalServerError("Failed to update saved passenger", err)
+	}
+
+	return newSavedPassengerResponse(passenger, true)
+}
+
+func (s *ProfileService) DeleteSavedPassenger(userID, passengerID uint) error {
+	deleted, err := s.savedPassengerRepo.Delete(passengerID, userID)
+	if err != nil {
+		return utils.NewInternalServerError("Failed to delete saved passenger", err)
+	}
+	if deleted == 0 {
+		return utils.NewNotFoundError("Saved passenger not found", nil)
+	}
+	return nil
+}
+
+func (s *ProfileService) getSavedPassenger(userID, passengerID uint) (*entities.SavedPassenger, error) {
+	passenger, err := s.savedPassengerRepo.FindByID(passengerID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Saved passenger not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get saved passenger", err)
+	}
+	return passenger, nil
+}
+
+func newSavedPassengerIdentity(req dto.PassengerIdentityRequest) (entities.PassengerIdentity, error) {
+	normalized, err := normalizePassengerIdentity(req)
+	if err != nil {
+		return entities.PassengerIdentity{}, utils.NewBadRequestError(err.Error(), nil)
+	}
+	identity, err := encryptPassengerIdentity(normalized)
+	if err != nil {
+		return identity, utils.NewInternalServerError("Failed to encrypt passenger identity", err)
+	}
+	return identity, nil
+}
+
+func newSavedPassengerResponse(passenger *entities.SavedPassenger, masked bool) (*dto.SavedPassengerResponse, error) {
+	response := dto.NewSavedPassengerResponseFromEntity(passenger)
+
+	identity, err := decryptPassengerIdentity(passenger.PassengerIdentity)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to read passenger identity", err)
+	}
+	if identity != nil && masked {
+		maskedIdentity := identity.Masked()
+		identity = &maskedIdentity
+	}
+	response.Identity = identity
+	return &response, nil
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"mime/multipart"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"malakashuttle/config"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+
+	"gorm.io/gorm"
+)
+
+// bookingReferencePattern matches booking references customers put in the transfer description,
+// e.g. "MS123", "BOOKING 123" or "#123"
+var bookingReferencePattern = regexp.MustCompile(`(?i)(?:\bMS|\bBOOKING|\bBK|#)\s*-?\s*(\d+)\b`)
+
+type ReconciliationService struct {
+	reconciliationRepo *repositories.ReconciliationRepository
+	bookingRepo        *repositories.BookingRepository
+	bookingService     *BookingService
+}
+
+func NewReconciliationService(
+	reconciliationRepo *repositories.ReconciliationRepository,
+	bookingRepo *repositories.BookingRepository,
+	bookingService *BookingService,
+) *ReconciliationService {
+	return &ReconciliationService{
+		reconciliationRepo: reconciliationRepo,
+		bookingRepo:        bookingRepo,
+		bookingService:     bookingService,
+	}
+}
+
+// ImportBankStatement parses a bank statement and reconciles its credit rows against bookings
+// waiting for verification. Exact matches are approved through the regular verification path,
+// ambiguous matches are put in the review queue.
+func (s *ReconciliationService) ImportBankStatement(userID uint, file *multipart.FileHeader, req dto.ImportBankStatementRequest) (*dto.BankStatementImportResponse, error) {
+	mapping, ok := config.GetBankStatementMapping(req.Bank)
+	if !ok {
+		return nil, fmt.Errorf("unsupported bank: %s", req.Bank)
+	}
+	req.ApplyToMapping(&mapping)
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement file: %w", err)
+	}
+	defer src.Close()
+
+	rows, err := utils.ParseBankStatement(src, mapping, wibLocation())
+	if err != nil {
+		return nil, fmt.Errorf("invalid bank statement: %v", err)
+	}
+
+	statementImport := &entities.BankStatementImport{
+		Bank:         mapping.Bank,
+		FileName:     file.Filename,
+		ImportedByID: userID,
+		Status:       entities.BankStatementImportProcessing,
+		TotalRows:    len(rows),
+	}
+	if err := s.reconciliationRepo.CreateImport(statementImport); err != nil {
+		return nil, fmt.Errorf("failed to create import: %w", err)
+	}
+
+	// Rows are reconciled one by one and bookings are approved as they match, so a failure leaves the
+	// rows processed so far in place and marks the import failed instead of rolling them back.
+	// The repository counts each stored row on the import, the response reloads the counters.
+	// Bookings matched by an earlier row of this statement can't be matched again
+	claimed := make(map[uint]bool)
+	for _, row := range rows {
+		if err := s.reconcileRow(statementImport.ID, row, claimed); err != nil {
+			s.failImport(statementImport, err)
+			return nil, err
+		}
+	}
+
+	statementImport.Status = entities.BankStatementImportCompleted
+	if err := s.reconciliationRepo.UpdateImport(statementImport); err != nil {
+		return nil, fmt.Errorf("failed to update import: %w", err)
+	}
+
+	return s.GetImportByID(statementImport.ID)
+}
+
+// reconcileRow matches a single statement row and stores the result
+func (s *ReconciliationService) reconcileRow(importID uint, row utils.BankStatementRow, claimed map[uint]bool) error {
+	entry := &entities.BankStatementEntry{
+		ImportID:        importID,
+		LineNumber:      row.RowNumber,
+		TransactionDate: row.TransactionDate,
+		Description:     row.Description,
+		Amount:          row.Amount,
+		Status:          entities.BankStatementEntryUnmatched,
+	}
+
+	candidates, exact, err := s.findCandidates(row, claimed)
+	if err != nil {
+		return fmt.Errorf("failed to find matching bookings: %w", err)
+	}
+
+	switch {
+	case len(candidates) == 1 && exact:
+		// Approve the booking and store the entry in one transaction, so an approved booking
+		// always has the statement entry that matched it
+		bookingID := candidates[0].ID
+		entry.Status = entities.BankStatementEntryMatched
+		entry.MatchedBookingID = &bookingID
+		err := s.bookingService.VerifyBooking(bookingID, entities.BookingStatusSuccess, func(tx *gorm.DB) error {
+			return s.reconciliationRepo.AddEntryTx(tx, entry)
+		})
+		if err == nil {
+			claimed[bookingID] = true
+			return nil
+		}
+
+		// Leave it to staff when the automatic approval fails
+		entry.ID = 0
+		entry.Status = entities.BankStatementEntryReview
+		entry.MatchedBookingID = nil
+		entry.CandidateBookingIDs = strconv.FormatUint(uint64(bookingID), 10)
+		entry.Notes = fmt.Sprintf("automatic approval failed: %v", err)
+	case len(candidates) > 0:
+		ids := make([]string, len(candidates))
+		for i, candidate := range candidates {
+			ids[i] = strconv.FormatUint(uint64(candidate.ID), 10)
+		}
+		entry.Status = entities.BankStatementEntryReview
+		entry.CandidateBookingIDs = strings.Join(ids, ",")
+		if exact {
+			entry.Notes = "multiple bookings match this transfer"
+		} else {
+			entry.Notes = "transfer amount differs, matched by unique code suffix"
+		}
+	}
+
+	if err := s.reconciliationRepo.AddEntry(entry); err != nil {
+		return fmt.Errorf("failed to save statement entry: %w", err)
+	}
+	return nil
+}
+
+// failImport marks an import that stopped halfway as failed, its counters cover the rows it stored
+func (s *ReconciliationService) failImport(statementImport *entities.BankStatementImport, cause error) {
+	statementImport.Status = entities.BankStatementImportFailed
+	statementImport.Error = truncate(cause.Error(), 500)
+	if err := s.reconciliationRepo.UpdateImport(statementImport); err != nil {
+		log.Printf("Failed to mark bank statement import #%d as failed: %v", statementImport.ID, err)
+	}
+}
+
+// findCandidates finds bookings waiting for verification that match the transfer amount and date.
+// exact is true when the transfer amount (which includes the unique code) matches the booking amount;
+// when several bookings match, a booking reference in the transfer description narrows them down.
+func (s *ReconciliationService) findCandidates(row utils.BankStatementRow, claimed map[uint]bool) (candidates []entities.Booking, exact bool, err error) {
+	// Statement dates have no time, so the booking may be made any time up to the end of that day
+	startOfDay := time.Date(row.TransactionDate.Year(), row.TransactionDate.Month(), row.TransactionDate.Day(), 0, 0, 0, 0, row.TransactionDate.Location())
+	from := startOfDay.Add(-config.GetReconciliationDateWindow())
+	to := startOfDay.Add(24 * time.Hour)
+
+	bookings, err := s.bookingRepo.FindWaitingVerificationByAmount(row.Amount, from, to)
+	if err != nil {
+		return nil, false, err
+	}
+	exact = true
+
+	// Transfer amount doesn't match exactly (e.g. customer left out part of the ticket price):
+	// bookings with the same unique-code suffix are candidates for review
+	if len(bookings) == 0 {
+		bookings, err = s.bookingRepo.FindWaitingVerificationBySuffix(int64(math.Round(row.Amount))%1000, from, to)
+		if err != nil {
+			return nil, false, err
+		}
+		exact = false
+	}
+
+	for _, booking := range bookings {
+		if !claimed[booking.ID] {
+			candidates = append(candidates, booking)
+		}
+	}
+	if len(candidates) <= 1 {
+		return candidates, exact, nil
+	}
+
+	references := make(map[uint]bool)
+	for _, match := range bookingReferencePattern.FindAllStringSubmatch(row.Description, -1) {
+		if id, err := strconv.ParseUint(match[1], 10, 32); err == nil {
+			references[uint(id)] = true
+		}
+	}
+	for _, candidate := range candidates {
+		if references[candidate.ID] {
+			return []entities.Booking{candidate}, exact, nil
+		}
+	}
+
+	return candidates, exact, nil
+}
+
+// GetImports retrieves bank statement imports with pagination
+func (s *ReconciliationService) GetImports(params utils.PaginationParams) (*utils.PaginationResponse, error) {
+	imports, total, err := s.reconciliationRepo.GetImports(params.Page, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]dto.BankStatementImportResponse, len(imports))
+	for i, statementImport := range imports {
+		data[i] = *dto.NewBankStatementImportResponseFromEntity(&statementImport)
+	}
+
+	response := utils.CreatePaginationResponse(data, total, params)
+	return &response, nil
+}
+
+// GetImportByID retrieves an import with all reconciled entries
+func (s *ReconciliationService) GetImportByID(id uint) (*dto.BankStatementImportResponse, error) {
+	statementImport, err := s.reconciliationRepo.GetImportByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("import not found")
+		}
+		return nil, err
+	}
+	return dto.NewBankStatementImportResponseFromEntity(statementImport), nil
+}
+
+// GetEntries retrieves statement entries, by default the review queue
+func (s *ReconciliationService) GetEntries(params utils.PaginationParams, status []entities.BankStatementEntryStatus) (*utils.PaginationResponse, error) {
+	if len(status) == 0 {
+		status = []entities.BankStatementEntryStatus{entities.BankStatementEntryReview}
+	}
+
+	entries, total, err := s.reconciliationRepo.GetEntries(params.Page, params.Limit, status)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]dto.BankStatementEntryResponse, len(entries))
+	for i, entry := range entries {
+		data[i] = *dto.NewBankStatementEntryResponseFromEntity(&entry)
+	}
+
+	response := utils.CreatePaginationResponse(data, total, params)
+	return &response, nil
+}
+
+// ResolveEntry matches a reviewed entry to a booking and approves the booking
+func (s *ReconciliationService) ResolveEntry(entryID uint, userID uint, req dto.ResolveBankStatementEntryRequest) (*dto.BankStatementEntryResponse, error) {
+	entry, err := s.getReviewableEntry(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingRepo.GetBookingByID(req.BookingID, nil)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("booking not found")
+		}
+		return nil, err
+	}
+	// Partial or rounded transfers can be accepted, but staff has to explain why
+	if booking.PaymentAmount != entry.Amount && req.Notes == "" {
+		return nil, errors.New("notes are required when the transfer amount does not match the booking amount")
+	}
+
+	now := time.Now()
+	entry.Status = entities.BankStatementEntryResolved
+	entry.MatchedBookingID = &booking.ID
+	entry.ResolvedByID = &userID
+	entry.ResolvedAt = &now
+	if req.Notes != "" {
+		entry.Notes = req.Notes
+	}
+
+	// Approve through the regular verification path, resolving the entry in the same transaction
+	err = s.bookingService.VerifyBooking(booking.ID, entities.BookingStatusSuccess, func(tx *gorm.DB) error {
+		return s.reconciliationRepo.UpdateEntryTx(tx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewBankStatementEntryResponseFromEntity(entry), nil
+}
+
+// DismissEntry marks a reviewed entry as not related to any booking
+func (s *ReconciliationService) DismissEntry(entryID uint, userID uint, req dto.DismissBankStatementEntryRequest) (*dto.BankStatementEntryResponse, error) {
+	entry, err := s.getReviewableEntry(entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry.Status = entities.BankStatementEntryDismissed
+	entry.ResolvedByID = &userID
+	entry.ResolvedAt = &now
+	if req.Notes != "" {
+		entry.Notes = req.Notes
+	}
+	if err := s.reconciliationRepo.UpdateEntry(entry); err != nil {
+		return nil, fmt.Errorf("failed to update statement entry: %w", err)
+	}
+
+	return dto.NewBankStatementEntryResponseFromEntity(entry), nil
+}
+
+// getReviewableEntry gets an entry that is still open for review
+func (s *ReconciliationService) getReviewableEntry(entryID uint) (*entities.BankStatementEntry, error) {
+	entry, err := s.reconciliationRepo.GetEntryByID(entryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("statement entry not found")
+		}
+		return nil, err
+	}
+
+	if entry.Status != entities.BankStatementEntryReview && entry.Status != entities.BankStatementEntryUnmatched {
+		return nil, errors.New("statement entry has already been reconciled")
+	}
+	return entry, nil
+}
+
+// wibLocation returns the Indonesia (WIB) timezone
+func wibLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		loc = time.FixedZone("WIB", 7*60*60) // Fallback ke WIB +7
+	}
+	return loc
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"malakashuttle/config"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"time"
+)
+
+// ReminderService queues payment and departure reminders, each one only once per booking
+type ReminderService struct {
+	reminderRepo    repositories.ReminderRepository
+	notificationSvc *NotificationService
+}
+
+func NewReminderService(reminderRepo repositories.ReminderRepository, notificationSvc *NotificationService) *ReminderService {
+	return &ReminderService{
+		reminderRepo:    reminderRepo,
+		notificationSvc: notificationSvc,
+	}
+}
+
+// SendPaymentReminders reminds customers of pending bookings that are about to expire
+func (s *ReminderService) SendPaymentReminders() (int, error) {
+	bookings, err := s.reminderRepo.FindPaymentReminderDue(time.Now(), config.GetPaymentReminderLead())
+	if err != nil {
+		return 0, fmt.Errorf("failed to find bookings for payment reminders: %w", err)
+	}
+
+	return s.sendReminders(bookings, entities.BookingReminderPaymentExpiry, entities.NotificationEventPaymentReminder), nil
+}
+
+// SendDepartureReminders reminds passengers of confirmed bookings before departure. Every offset covers
+// departures up to the next smaller offset, so a booking made late only gets the reminders still ahead of it.
+func (s *ReminderService) SendDepartureReminders() (int, error) {
+	offsets := config.GetDepartureReminderOffsets()
+	now := time.Now()
+
+	sent := 0
+	for i, offset := range offsets {
+		var lower time.Duration
+		if i+1 < len(offsets) {
+			lower = offsets[i+1]
+		}
+
+		kind := departureReminderKind(offset)
+		bookings, err := s.reminderRepo.FindDepartureReminderDue(kind, now.Add(lower), now.Add(offset))
+		if err != nil {
+			return sent, fmt.Errorf("failed to find bookings for %s reminders: %w", offset, err)
+		}
+		sent += s.sendReminders(bookings, kind, entities.NotificationEventDepartureReminder)
+	}
+	return sent, nil
+}
+
+func (s *ReminderService) sendReminders(bookings []entities.Booking, kind string, event entities.NotificationEvent) int {
+	outbox := s.notificationSvc.BookingOutbox(event)
+
+	sent := 0
+	for i := range bookings {
+		recorded, err := s.reminderRepo.RecordReminder(&bookings[i], kind, outbox)
+		if err != nil {
+			log.Printf("Failed to queue %s reminder for booking #%d: %v", kind, bookings[i].ID, err)
+			continue
+		}
+		if recorded {
+			sent++
+		}
+	}
+	return sent
+}
+
+// departureReminderKind names the reminder of an offset, e.g. "departure_24h" or "departure_90m"
+func departureReminderKind(offset time.Duration) string {
+	if offset%time.Hour == 0 {
+		return fmt.Sprintf("%s%dh", entities.BookingReminderDeparturePrefix, int(offset/time.Hour))
+	}
+	return fmt.Sprintf("%s%dm", entities.BookingReminderDeparturePrefix, int(offset/time.Minute))
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"malakashuttle/constants"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// how long role permissions are cached before they are read from the database again
+const rolePermissionCacheTTL = time.Minute
+
+type cachedPermissions struct {
+	permissions map[string]bool
+	loadedAt    time.Time
+}
+
+// RoleService manages roles and resolves the permissions of a role for the route guards
+type RoleService struct {
+	roleRepo repositories.RoleRepository
+
+	mu    sync.RWMutex
+	cache map[string]cachedPermissions
+}
+
+func NewRoleService(roleRepo repositories.RoleRepository) *RoleService {
+	return &RoleService{
+		roleRepo: roleRepo,
+		cache:    make(map[string]cachedPermissions),
+	}
+}
+
+// EnsureDefaultRoles creates the admin, staff, driver, user and partner roles when they do not exist yet
+func (s *RoleService) EnsureDefaultRoles() error {
+	defaults := []entities.Role{
+		{Name: constants.ROLE_ADMIN, Description: "Full access to every feature"},
+		{Name: constants.ROLE_STAFF, Description: "Operational staff verifying payments and serving the counter"},
+		{Name: constants.ROLE_DRIVER, Description: "Driver running the trips assigned to them"},
+		{Name: constants.ROLE_USER, Description: "Customer booking trips"},
+		{Name: constants.ROLE_PARTNER, Description: "Travel agent or OTA booking through API keys"},
+	}
+
+	for _, role := range defaults {
+		_, err := s.roleRepo.GetRoleByName(role.Name)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		role.IsSystem = true
+		for _, permission := range constants.DefaultRolePermissions[role.Name] {
+			role.Permissions = append(role.Permissions, entities.RolePermission{Permission: permission})
+		}
+		if err := s.roleRepo.CreateRole(&role); err != nil {
+			return err
+		}
+		log.Printf("Created default role %s", role.Name)
+	}
+	return nil
+}
+
+// GetPermissions returns the permission set of a role. The admin role has every permission.
+func (s *RoleService) GetPermissions(roleName string) (map[string]bool, error) {
+	if roleName == constants.ROLE_ADMIN {
+		permissions := make(map[string]bool, len(constants.PermissionDescriptions))
+		for permission := range constants.PermissionDescriptions {
+			permissions[permission] = true
+		}
+		return permissions, nil
+	}
+
+	s.mu.RLock()
+	cached, ok := s.cache[roleName]
+	s.mu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < rolePermissionCacheTTL {
+		return cached.permissions, nil
+	}
+
+	permissions := make(map[string]bool)
+	role, err := s.roleRepo.GetRoleByName(roleName)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	// a user whose role was removed keeps no permissions
+	if role != nil {
+		for _, permission := range role.Permissions {
+			permissions[permission.Permission] = true
+		}
+	}
+
+	s.mu.Lock()
+	s.cache[roleName] = cachedPermissions{permissions: permissions, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return permissions, nil
+}
+
+// RoleExists reports whether a role with the given name is stored
+func (s *RoleService) RoleExists(roleName string) (bool, error) {
+	_, err := s.roleRepo.GetRoleByName(roleName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// GetRoleNames returns the names of all stored roles
+func (s *RoleService) GetRoleNames() ([]string, error) {
+	roles, err := s.roleRepo.GetRoles()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = role.Name
+	}
+	return names, nil
+}
+
+// GetRolesWithPermission returns the names of the roles that grant a permission, including admin
+func (s *RoleService) GetRolesWithPermission(permission string) ([]string, error) {
+	roles, err := s.roleRepo.GetRoles()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{constants.ROLE_ADMIN}
+	for _, role := range roles {
+		if role.Name == constants.ROLE_ADMIN {
+			continue
+		}
+		for _, granted := range role.PermissionNames() {
+			if granted == permission {
+				names = append(names, role.Name)
+				break
+			}
+		}
+	}
+	return names, nil
+}
+
+// GetAllPermissions lists every permission that can be granted to a role
+func (s *RoleService) GetAllPermissions() []dto.PermissionResponse {
+	responses := make([]dto.PermissionResponse, 0, len(constants.PermissionDescriptions))
+	for name, description := range constants.PermissionDescriptions {
+		responses = append(responses, dto.PermissionResponse{Name: name, Description: description})
+	}
+	sort.Slice(responses, func(i, j int) bool { return responses[i].Name < responses[j].Name })
+	return responses
+}
+
+func (s *RoleService) GetRoles() ([]dto.RoleResponse, error) {
+	roles, err := s.roleRepo.GetRoles()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get roles", err)
+	}
+
+	responses := make([]dto.RoleResponse, len(roles))
+	for i := range roles {
+		responses[i] = s.toRoleResponse(&roles[i])
+	}
+	return responses, nil
+}
+
+func (s *RoleService) GetRoleByName(name string) (*dto.RoleResponse, error) {
+	role, err := s.getRole(name)
+	if err != nil {
+		return nil, err
+	}
+	response := s.toRoleResponse(role)
+	return &response, nil
+}
+
+// CreateRole adds a role such as finance or driver without code changes
+func (s *RoleService) CreateRole(req dto.CreateRoleRequest) (*dto.RoleResponse, error) {
+	if err := validatePermissions(req.Permissions); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.RoleExists(req.Name)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to check role", err)
+	}
+	if exists {
+		return nil, utils.NewConflictError("Role with this name already exists", nil)
+	}
+
+	role := &entities.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	for _, permission := range uniquePermissions(req.Permissions) {
+		role.Permissions = append(role.Permissions, entities.RolePermission{Permission: permission})
+	}
+	if err := s.roleRepo.CreateRole(role); err != nil {
+		return nil, utils.NewInternalServerError("Failed to create role", err)
+	}
+
+	s.invalidate(role.Name)
+	response := s.toRoleResponse(role)
+	return &response, nil
+}
+
+// UpdateRole replaces the description and permissions of a role. The admin role cannot be changed.
+func (s *RoleService) UpdateRole(name string, req dto.UpdateRoleRequest) (*dto.RoleResponse, error) {
+	if name == constants.ROLE_ADMIN {
+		return nil, utils.NewForbiddenError("The admin role always has every permission and cannot be changed", nil)
+	}
+	if err := validatePermissions(req.Permissions); err != nil {
+		return nil, err
+	}
+
+	role, err := s.getRole(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Description != nil {
+		role.Description = *req.Description
+	}
+	if err := s.roleRepo.UpdateRole(role, uniquePermissions(req.Permissions)); err != nil {
+		return nil, utils.NewInternalServerError("Failed to update role", err)
+	}
+
+	s.invalidate(role.Name)
+	response := s.toRoleResponse(role)
+	return &response, nil
+}
+
+// DeleteRole removes a custom role that is no longer assigned to any user
+func (s *RoleService) DeleteRole(name string) error {
+	role, err := s.getRole(name)
+	if err != nil {
+		return err
+	}
+	if role.IsSystem {
+		return utils.NewForbiddenError("System roles cannot be deleted", nil)
+	}
+
+	count, err := s.roleRepo.CountUsersWithRole(role.Name)
+	if err != nil {
+		return utils.NewInternalServerError("Failed to check role usage", err)
+	}
+	if count > 0 {
+		return utils.NewConflictErrorWithDetails("Role is still assigned to users", nil, map[string]interface{}{
+			"user_count": count,
+		})
+	}
+
+	if err := s.roleRepo.DeleteRole(role); err != nil {
+		return utils.NewInternalServerError("Failed to delete role", err)
+	}
+	s.invalidate(role.Name)
+	return nil
+}
+
+func (s *RoleService) getRole(name string) (*entities.Role, error) {
+	role, err := s.roleRepo.GetRoleByName(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Role not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get role", err)
+	}
+	return role, nil
+}
+
+func (s *RoleService) toRoleResponse(role *entities.Role) dto.RoleResponse {
+	response := dto.NewRoleResponseFromEntity(role)
+	if role.Name == constants.ROLE_ADMIN {
+		permissions, _ := s.GetPermissions(role.Name)
+		response.Permissions = make([]string, 0, len(permissions))
+		for permission := range permissions {
+			response.Permissions = append(response.Permissions, permission)
+		}
+		sort.Strings(response.Permissions)
+	}
+	return response
+}
+
+func (s *RoleService) invalidate(roleName string) {
+	s.mu.Lock()
+	delete(s.cache, roleName)
+	s.mu.Unlock()
+}
+
+func validatePermissions(permissions []string) error {
+	for _, permission := range permissions {
+		if _, ok := constants.PermissionDescriptions[permission]; !ok {
+			return utils.NewBadRequestError(fmt.Sprintf("Unknown permission: %s", permission), nil)
+		}
+	}
+	return nil
+}
+
+func uniquePermissions(permissions []string) []string {
+	seen := make(map[string]bool, len(permissions))
+	unique := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if !seen[permission] {
+			seen[permission] = true
+			unique = append(unique, permission)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
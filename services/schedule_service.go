@@ -7,16 +7,28 @@ import (
 	"malakashuttle/entities"
 	"malakashuttle/repositories"
 	"malakashuttle/utils"
+	"strings"
 	"time"
 )
 
 type ScheduleService struct {
-	scheduleRepo *repositories.ScheduleRepository
+	scheduleRepo    *repositories.ScheduleRepository
+	notificationSvc *NotificationService
+	webhookSvc      *WebhookService
+	seatSvc         *SeatAvailabilityService
 }
 
-func NewScheduleService(scheduleRepo *repositories.ScheduleRepository) *ScheduleService {
+func NewScheduleService(
+	scheduleRepo *repositories.ScheduleRepository,
+	notificationSvc *NotificationService,
+	webhookSvc *WebhookService,
+	seatSvc *SeatAvailabilityService,
+) *ScheduleService {
 	return &ScheduleService{
-		scheduleRepo: scheduleRepo,
+		scheduleRepo:    scheduleRepo,
+		notificationSvc: notificationSvc,
+		webhookSvc:      webhookSvc,
+		seatSvc:         seatSvc,
 	}
 }
 
@@ -77,6 +89,8 @@ func (s *ScheduleService) CreateSchedule(req dto.CreateScheduleRequest) (*dto.Sc
 		Price:          req.Price,
 		TotalSeats:     req.TotalSeats,
 		AvailableSeats: req.TotalSeats, // Available seats sama dengan total seats saat create
+		PickupPoint:    strings.TrimSpace(req.PickupPoint),
+		Status:         entities.ScheduleStatusScheduled,
 	}
 
 	// Save to database (dengan transaction untuk create seats juga)
@@ -100,6 +114,9 @@ func (s *ScheduleService) UpdateSchedule(id uint, req dto.UpdateScheduleRequest)
 	if err != nil {
 		return nil, errors.New("schedule not found")
 	}
+	if existingSchedule.CancelledAt != nil {
+		return nil, errors.New("schedule has been cancelled")
+	}
 	updates := make(map[string]interface{})
 
 	// Load timezone Indonesia (WIB)
@@ -160,6 +177,10 @@ func (s *ScheduleService) UpdateSchedule(id uint, req dto.UpdateScheduleRequest)
 		}
 		updates["price"] = *req.Price
 	}
+	// Set pickup point, kosong berarti penumpang naik di kota asal
+	if req.PickupPoint != nil {
+		updates["pickup_point"] = strings.TrimSpace(*req.PickupPoint)
+	}
 
 	// Update schedule
 	if err := s.scheduleRepo.UpdateSchedule(id, updates); err != nil {
@@ -196,6 +217,61 @@ func (s *ScheduleService) DeleteSchedule(id uint) error {
 	return nil
 }
 
+// CancelSchedule - Cancel schedule beserta bookingnya, customer dan webhook diberi tahu (Admin only)
+func (s *ScheduleService) CancelSchedule(id uint, req dto.CancelScheduleRequest) (*dto.CancelScheduleResponse, error) {
+	existingSchedule, err := s.scheduleRepo.GetScheduleByID(id)
+	if err != nil {
+		return nil, errors.New("schedule not found")
+	}
+	if existingSchedule.CancelledAt != nil {
+		return nil, errors.New("schedule is already cancelled")
+	}
+	// The driver may start the trip ahead of its departure time
+	if existingSchedule.DepartureTime.Before(time.Now()) || existingSchedule.Status != entities.ScheduleStatusScheduled {
+		return nil, errors.New("cannot cancel a schedule that has already departed")
+	}
+
+	notify := s.notificationSvc.BookingOutbox(entities.NotificationEventBookingCancelled)
+	bookingIDs, err := s.scheduleRepo.CancelSchedule(id, strings.TrimSpace(req.Reason),
+		func(schedule *entities.Schedule, bookings []entities.Booking) (*repositories.Outbox, error) {
+			outbox, err := s.webhookSvc.ScheduleCancelledOutbox(schedule, bookings)
+			if err != nil {
+				return nil, err
+			}
+			for i := range bookings {
+				notifications, err := notify(&bookings[i])
+				if err != nil {
+					return nil, err
+				}
+				if notifications != nil {
+					outbox.Messages = append(outbox.Messages, notifications.Messages...)
+					outbox.Notifications = append(outbox.Notifications, notifications.Notifications...)
+				}
+			}
+			return outbox, nil
+		})
+	if err != nil {
+		if err.Error() == "schedule is already cancelled" {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to cancel schedule: %v", err)
+	}
+	s.seatSvc.PublishSeatChanges(dto.SeatChangeScheduleCancelled, id)
+
+	cancelledSchedule, err := s.scheduleRepo.GetScheduleByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cancelled schedule: %v", err)
+	}
+
+	if bookingIDs == nil {
+		bookingIDs = []uint{}
+	}
+	return &dto.CancelScheduleResponse{
+		Schedule:            dto.ToScheduleResponse(*cancelledSchedule, true),
+		CancelledBookingIDs: bookingIDs,
+	}, nil
+}
+
 // GetAllSchedules - Get all schedules with pagination (Admin only)
 func (s *ScheduleService) GetAllSchedules(page, limit int) (*utils.PaginationResponse, error) {
 	// Create pagination params
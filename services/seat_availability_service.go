@@ -0,0 +1,80 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"malakashuttle/dto"
+	"malakashuttle/pubsub"
+	"malakashuttle/repositories"
+)
+
+// SeatAvailabilityService pushes the seat state of a schedule to everyone watching it.
+// Changes are published through the broker so every instance can serve the streams.
+type SeatAvailabilityService struct {
+	scheduleRepo *repositories.ScheduleRepository
+	broker       pubsub.Broker
+}
+
+func NewSeatAvailabilityService(scheduleRepo *repositories.ScheduleRepository, broker pubsub.Broker) *SeatAvailabilityService {
+	return &SeatAvailabilityService{
+		scheduleRepo: scheduleRepo,
+		broker:       broker,
+	}
+}
+
+func seatAvailabilityTopic(scheduleID uint) string {
+	return fmt.Sprintf("schedule.%d.seats", scheduleID)
+}
+
+// PublishSeatChanges publishes the current seats of the schedules. It is called after the change
+// is committed; failures are only logged since the stream is best effort and clients can refetch.
+func (s *SeatAvailabilityService) PublishSeatChanges(reason dto.SeatChangeReason, scheduleIDs ...uint) {
+	published := make(map[uint]bool)
+	for _, scheduleID := range scheduleIDs {
+		if published[scheduleID] {
+			continue
+		}
+		published[scheduleID] = true
+
+		seats, err := s.scheduleRepo.GetSeatsByScheduleID(scheduleID)
+		if err != nil {
+			log.Printf("Failed to load seats of schedule %d for the seat stream: %v", scheduleID, err)
+			continue
+		}
+
+		payload, err := json.Marshal(dto.NewSeatAvailabilityEvent(scheduleID, reason, seats, time.Now()))
+		if err != nil {
+			log.Printf("Failed to encode seat event of schedule %d: %v", scheduleID, err)
+			continue
+		}
+		if err := s.broker.Publish(seatAvailabilityTopic(scheduleID), payload); err != nil {
+			log.Printf("Failed to publish seat event of schedule %d: %v", scheduleID, err)
+		}
+	}
+}
+
+// Subscribe starts watching the seats of a schedule and returns the current state as the first event.
+// The subscription is opened before the snapshot is read so no change in between is missed.
+func (s *SeatAvailabilityService) Subscribe(scheduleID uint) (*dto.SeatAvailabilityEvent, pubsub.Subscription, error) {
+	if _, err := s.scheduleRepo.GetScheduleByID(scheduleID); err != nil {
+		return nil, nil, errors.New("schedule not found")
+	}
+
+	subscription, err := s.broker.Subscribe(seatAvailabilityTopic(scheduleID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to subscribe to seat changes: %v", err)
+	}
+
+	seats, err := s.scheduleRepo.GetSeatsByScheduleID(scheduleID)
+	if err != nil {
+		subscription.Close()
+		return nil, nil, fmt.Errorf("failed to get seats: %v", err)
+	}
+
+	snapshot := dto.NewSeatAvailabilityEvent(scheduleID, dto.SeatChangeSnapshot, seats, time.Now())
+	return &snapshot, subscription, nil
+}
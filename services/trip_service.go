@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"malakashuttle/constants"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TripService assigns drivers to schedules and serves drivers their trips. Drivers only ever
+// get to see and change the schedules assigned to them.
+type TripService struct {
+	tripRepo     repositories.TripRepository
+	scheduleRepo *repositories.ScheduleRepository
+	userRepo     repositories.UserRepository
+	manifestSvc  *ManifestService
+	boardingSvc  *BoardingService
+}
+
+func NewTripService(
+	tripRepo repositories.TripRepository,
+	scheduleRepo *repositories.ScheduleRepository,
+	userRepo repositories.UserRepository,
+	manifestSvc *ManifestService,
+	boardingSvc *BoardingService,
+) *TripService {
+	return &TripService{
+		tripRepo:     tripRepo,
+		scheduleRepo: scheduleRepo,
+		userRepo:     userRepo,
+		manifestSvc:  manifestSvc,
+		boardingSvc:  boardingSvc,
+	}
+}
+
+// AssignDriver assigns a driver to a schedule or removes the assignment when no driver is given
+func (s *TripService) AssignDriver(scheduleID uint, req dto.AssignDriverRequest) (*dto.ScheduleResponse, error) {
+	schedule, err := s.scheduleRepo.GetScheduleByID(scheduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Schedule not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get schedule", err)
+	}
+	if schedule.CancelledAt != nil {
+		return nil, utils.NewConflictError("Schedule is cancelled", nil)
+	}
+	if schedule.Status == entities.ScheduleStatusCompleted {
+		return nil, utils.NewConflictError("Trip has already ended", nil)
+	}
+
+	if req.DriverID != nil {
+		driver, err := s.userRepo.FindByID(*req.DriverID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, utils.NewNotFoundError("Driver not found", nil)
+			}
+			return nil, utils.NewInternalServerError("Failed to get driver", err)
+		}
+		if driver.Role != constants.ROLE_DRIVER {
+			return nil, utils.NewBadRequestError("User is not a driver", nil)
+		}
+	}
+
+	if err := s.tripRepo.AssignDriver(scheduleID, req.DriverID); err != nil {
+		return nil, utils.NewInternalServerError("Failed to assign driver", err)
+	}
+	schedule.DriverID = req.DriverID
+
+	response := dto.ToScheduleResponse(*schedule, true)
+	return &response, nil
+}
+
+// GetUpcomingTrips lists the trips of a driver that have not ended yet, the next departure first
+func (s *TripService) GetUpcomingTrips(driverID uint, params utils.PaginationParams) (*utils.PaginationResponse, error) {
+	schedules, total, err := s.tripRepo.FindUpcoming(driverID, params.Page, params.Limit)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get trips", err)
+	}
+
+	trips := make([]dto.TripResponse, len(schedules))
+	for i := range schedules {
+		trips[i] = dto.NewTripResponse(&schedules[i])
+	}
+
+	response := utils.CreatePaginationResponse(trips, total, params)
+	return &response, nil
+}
+
+// GetTrip returns a trip of the driver
+func (s *TripService) GetTrip(scheduleID, driverID uint) (*dto.TripResponse, error) {
+	schedule, err := s.driverTrip(scheduleID, driverID)
+	if err != nil {
+		return nil, err
+	}
+	trip := dto.NewTripResponse(schedule)
+	return &trip, nil
+}
+
+// GetTripManifest returns the passenger manifest of a trip of the driver
+func (s *TripService) GetTripManifest(scheduleID, driverID uint) (*dto.ManifestResponse, error) {
+	if _, err := s.driverTrip(scheduleID, driverID); err != nil {
+		return nil, err
+	}
+	return s.manifestSvc.GetManifest(scheduleID)
+}
+
+// ScanBoardingPass boards a passenger of a trip of the driver
+func (s *TripService) ScanBoardingPass(scheduleID, driverID uint, req dto.DriverScanRequest) (*dto.BoardingScanResponse, error) {
+	if _, err := s.driverTrip(scheduleID, driverID); err != nil {
+		return nil, err
+	}
+	return s.boardingSvc.Scan(dto.ScanBoardingPassRequest{ScheduleID: scheduleID, Code: req.Code}, driverID)
+}
+
+// StartTrip marks a trip of the driver as departed
+func (s *TripService) StartTrip(scheduleID, driverID uint) (*dto.TripResponse, error) {
+	schedule, err := s.driverTrip(scheduleID, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.CancelledAt != nil {
+		return nil, utils.NewConflictError("Schedule is cancelled", nil)
+	}
+	if schedule.Status != entities.ScheduleStatusScheduled {
+		return nil, tripStatusError("Trip has already started", schedule.Status)
+	}
+
+	return s.updateTripStatus(schedule, driverID, entities.ScheduleStatusScheduled, entities.ScheduleStatusDeparted, "Trip has already started")
+}
+
+// EndTrip marks a departed trip of the driver as completed
+func (s *TripService) EndTrip(scheduleID, driverID uint) (*dto.TripResponse, error) {
+	schedule, err := s.driverTrip(scheduleID, driverID)
+	if err != nil {
+		return nil, err
+	}
+	switch schedule.Status {
+	case entities.ScheduleStatusScheduled:
+		return nil, tripStatusError("Trip has not started yet", schedule.Status)
+	case entities.ScheduleStatusCompleted:
+		return nil, tripStatusError("Trip has already ended", schedule.Status)
+	}
+
+	return s.updateTripStatus(schedule, driverID, entities.ScheduleStatusDeparted, entities.ScheduleStatusCompleted, "Trip has already ended")
+}
+
+// ReportIncident records an incident on a trip of the driver
+func (s *TripService) ReportIncident(scheduleID, driverID uint, req dto.ReportIncidentRequest) (*dto.TripIncidentResponse, error) {
+	schedule, err := s.driverTrip(scheduleID, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.CancelledAt != nil {
+		return nil, utils.NewConflictError("Schedule is cancelled", nil)
+	}
+
+	now := time.Now()
+	occurredAt := now
+	if req.OccurredAt != nil {
+		if req.OccurredAt.After(now) {
+			return nil, utils.NewBadRequestError("Incident time cannot be in the future", nil)
+		}
+		occurredAt = *req.OccurredAt
+	}
+
+	incident := &entities.TripIncident{
+		ScheduleID:  scheduleID,
+		DriverID:    driverID,
+		Category:    req.Category,
+		Description: strings.TrimSpace(req.Description),
+		OccurredAt:  occurredAt,
+	}
+	if err := s.tripRepo.CreateIncident(incident); err != nil {
+		return nil, utils.NewInternalServerError("Failed to report incident", err)
+	}
+
+	response := dto.NewTripIncidentResponse(incident)
+	return &response, nil
+}
+
+// GetTripIncidents lists the incidents reported on a trip of the driver
+func (s *TripService) GetTripIncidents(scheduleID, driverID uint) ([]dto.TripIncidentResponse, error) {
+	if _, err := s.driverTrip(scheduleID, driverID); err != nil {
+		return nil, err
+	}
+	return s.incidents(scheduleID)
+}
+
+// GetScheduleIncidents lists the incidents reported on a schedule for the back office
+func (s *TripService) GetScheduleIncidents(scheduleID uint) ([]dto.TripIncidentResponse, error) {
+	if _, err := s.scheduleRepo.GetScheduleByID(scheduleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Schedule not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get schedule", err)
+	}
+	return s.incidents(scheduleID)
+}
+
+func (s *TripService) incidents(scheduleID uint) ([]dto.TripIncidentResponse, error) {
+	incidents, err := s.tripRepo.FindIncidents(scheduleID)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get incidents", err)
+	}
+
+	responses := make([]dto.TripIncidentResponse, len(incidents))
+	for i := range incidents {
+		responses[i] = dto.NewTripIncidentResponse(&incidents[i])
+	}
+	return responses, nil
+}
+
+// driverTrip returns a schedule assigned to the driver. Schedules of other drivers are reported
+// as not found so drivers cannot probe them.
+func (s *TripService) driverTrip(scheduleID, driverID uint) (*entities.Schedule, error) {
+	schedule, err := s.tripRepo.FindDriverSchedule(scheduleID, driverID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Trip not found", nil)
+		}
+		return nil, utils.NewInternalServerError("Failed to get trip", err)
+	}
+	return schedule, nil
+}
+
+func (s *TripService) updateTripStatus(schedule *entities.Schedule, driverID uint, from, to entities.ScheduleStatus, conflict string) (*dto.TripResponse, error) {
+	now := time.Now()
+	updated, err := s.tripRepo.UpdateStatus(schedule.ID, driverID, from, to, now)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to update trip status", err)
+	}
+	if !updated {
+		// The trip was changed or cancelled in the meantime
+		return nil, utils.NewConflictError(conflict, nil)
+	}
+
+	schedule.Status = to
+	switch to {
+	case entities.ScheduleStatusDeparted:
+		schedule.DepartedAt = &now
+	case entities.ScheduleStatusCompleted:
+		schedule.CompletedAt = &now
+	}
+	trip := dto.NewTripResponse(schedule)
+	return &trip, nil
+}
+
+func tripStatusError(message string, status entities.ScheduleStatus) error {
+	return utils.NewConflictErrorWithDetails(message, nil, map[string]interface{}{
+		"status": status,
+	})
+}
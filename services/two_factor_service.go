@@ -0,0 +1,338 @@
+package services
+
+import (
+	"crypto/rand"
+	"fmt"
+	"malakashuttle/config"
+	"malakashuttle/constants"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"strings"
+	"time"
+)
+
+const recoveryCodeCount = 10
+
+// TwoFactorService manages TOTP two-factor authentication for back office accounts,
+// that is every role except customers and partner service accounts
+type TwoFactorService struct {
+	twoFactorRepo repositories.TwoFactorRepository
+	userRepo      repositories.UserRepository
+	roleService   *RoleService
+}
+
+func NewTwoFactorService(twoFactorRepo repositories.TwoFactorRepository, userRepo repositories.UserRepository, roleService *RoleService) *TwoFactorService {
+	return &TwoFactorService{
+		twoFactorRepo: twoFactorRepo,
+		userRepo:      userRepo,
+		roleService:   roleService,
+	}
+}
+
+// GetStatus returns the two-factor state of a user
+func (s *TwoFactorService) GetStatus(userID uint) (*dto.TwoFactorStatusResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("User not found", nil)
+	}
+
+	required, err := s.isRequiredForRole(user.Role)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get two-factor policy", err)
+	}
+
+	var remaining int64
+	if user.IsTwoFactorEnabled() {
+		if remaining, err = s.twoFactorRepo.CountUnusedRecoveryCodes(user.ID); err != nil {
+			return nil, utils.NewInternalServerError("Failed to count recovery codes", err)
+		}
+	}
+
+	return &dto.TwoFactorStatusResponse{
+		Enabled:                user.IsTwoFactorEnabled(),
+		EnabledAt:              user.TwoFactorEnabledAt,
+		Required:               required,
+		RemainingRecoveryCodes: remaining,
+	}, nil
+}
+
+// Setup generates a new secret. It only becomes active after Enable confirms a code from the app.
+func (s *TwoFactorService) Setup(userID uint) (*dto.TwoFactorSetupResponse, error) {
+	user, err := s.getEnrollableUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsTwoFactorEnabled() {
+		return nil, utils.NewConflictError("Two-factor authentication is already enabled", nil)
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to generate secret", err)
+	}
+	encrypted, err := utils.EncryptString(secret)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to encrypt secret", err)
+	}
+	if err := s.twoFactorRepo.SavePendingSecret(user.ID, encrypted); err != nil {
+		return nil, utils.NewInternalServerError("Failed to save secret", err)
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(config.GetTwoFactorIssuer(), user.Email, secret),
+	}, nil
+}
+
+// Enable confirms the pending secret with a code and returns the recovery codes (shown only once)
+func (s *TwoFactorService) Enable(userID uint, req dto.TwoFactorCodeRequest) (*dto.TwoFactorRecoveryCodesResponse, error) {
+	user, err := s.getEnrollableUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsTwoFactorEnabled() {
+		return nil, utils.NewConflictError("Two-factor authentication is already enabled", nil)
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, utils.NewBadRequestError("Two-factor setup has not been started", nil)
+	}
+
+	secret, err := utils.DecryptString(user.TwoFactorSecret)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to read secret", err)
+	}
+	step, ok := utils.ValidateTOTP(secret, req.Code, time.Now())
+	if !ok {
+		return nil, utils.NewBadRequestError("Invalid two-factor code", nil)
+	}
+
+	codes, records, err := newRecoveryCodes()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to generate recovery codes", err)
+	}
+	if err := s.twoFactorRepo.Enable(user.ID, step, records); err != nil {
+		return nil, utils.NewInternalServerError("Failed to enable two-factor authentication", err)
+	}
+
+	return &dto.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable turns off two-factor authentication, unless it is required for the user's role
+func (s *TwoFactorService) Disable(userID uint, req dto.DisableTwoFactorRequest) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return utils.NewNotFoundError("User not found", nil)
+	}
+	if !user.IsTwoFactorEnabled() {
+		return utils.NewBadRequestError("Two-factor authentication is not enabled", nil)
+	}
+
+	required, err := s.isRequiredForRole(user.Role)
+	if err != nil {
+		return utils.NewInternalServerError("Failed to get two-factor policy", err)
+	}
+	if required {
+		return utils.NewForbiddenError("Two-factor authentication is required for your role", nil)
+	}
+
+	if err := user.CheckPassword(req.Password); err != nil {
+		return utils.NewBadRequestError("Invalid password", nil)
+	}
+	if err := s.verifyTOTP(user, req.Code); err != nil {
+		return err
+	}
+
+	if err := s.twoFactorRepo.Disable(user.ID); err != nil {
+		return utils.NewInternalServerError("Failed to disable two-factor authentication", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID uint, req dto.TwoFactorCodeRequest) (*dto.TwoFactorRecoveryCodesResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("User not found", nil)
+	}
+	if !user.IsTwoFactorEnabled() {
+		return nil, utils.NewBadRequestError("Two-factor authentication is not enabled", nil)
+	}
+	if err := s.verifyTOTP(user, req.Code); err != nil {
+		return nil, err
+	}
+
+	codes, records, err := newRecoveryCodes()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to generate recovery codes", err)
+	}
+	if err := s.twoFactorRepo.ReplaceRecoveryCodes(user.ID, records); err != nil {
+		return nil, utils.NewInternalServerError("Failed to save recovery codes", err)
+	}
+
+	return &dto.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// VerifyLoginCode checks the second factor of a login, either a TOTP code or a recovery code
+func (s *TwoFactorService) VerifyLoginCode(user *entities.User, code, recoveryCode string) error {
+	if recoveryCode != "" {
+		used, err := s.twoFactorRepo.UseRecoveryCode(user.ID, hashRecoveryCode(recoveryCode))
+		if err != nil {
+			return utils.NewInternalServerError("Failed to verify recovery code", err)
+		}
+		if !used {
+			return utils.NewUnauthorizedError("Invalid recovery code", nil)
+		}
+		return nil
+	}
+	return s.verifyTOTP(user, code)
+}
+
+// IsSetupRequired reports whether the user's role requires two-factor authentication
+// and the user has not enabled it yet
+func (s *TwoFactorService) IsSetupRequired(user *entities.User) (bool, error) {
+	if user.IsTwoFactorEnabled() {
+		return false, nil
+	}
+	return s.isRequiredForRole(user.Role)
+}
+
+// GetPolicies returns the two-factor policy of every role that can enroll (for admin)
+func (s *TwoFactorService) GetPolicies() ([]dto.TwoFactorPolicyResponse, error) {
+	policies, err := s.twoFactorRepo.GetPolicies()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get two-factor policies", err)
+	}
+
+	roles, err := s.roleService.GetRoleNames()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get roles", err)
+	}
+
+	byRole := make(map[string]entities.TwoFactorPolicy, len(policies))
+	for _, policy := range policies {
+		byRole[policy.Role] = policy
+	}
+
+	responses := make([]dto.TwoFactorPolicyResponse, 0, len(roles))
+	for _, role := range roles {
+		if !isTwoFactorRole(role) {
+			continue
+		}
+		policy, ok := byRole[role]
+		if !ok {
+			policy = entities.TwoFactorPolicy{Role: role}
+		}
+		responses = append(responses, dto.NewTwoFactorPolicyResponseFromEntity(&policy))
+	}
+	return responses, nil
+}
+
+// UpdatePolicy sets whether two-factor authentication is required for a role (for admin)
+func (s *TwoFactorService) UpdatePolicy(role string, req dto.UpdateTwoFactorPolicyRequest) (*dto.TwoFactorPolicyResponse, error) {
+	if !isTwoFactorRole(role) {
+		return nil, utils.NewBadRequestError(fmt.Sprintf("Two-factor authentication is not available for the %s role", role), nil)
+	}
+	exists, err := s.roleService.RoleExists(role)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to check role", err)
+	}
+	if !exists {
+		return nil, utils.NewNotFoundError("Role not found", nil)
+	}
+
+	policy := &entities.TwoFactorPolicy{
+		Role:      role,
+		Required:  *req.Required,
+		UpdatedAt: time.Now(),
+	}
+	if err := s.twoFactorRepo.SavePolicy(policy); err != nil {
+		return nil, utils.NewInternalServerError("Failed to save two-factor policy", err)
+	}
+
+	response := dto.NewTwoFactorPolicyResponseFromEntity(policy)
+	return &response, nil
+}
+
+// ResetUser removes two-factor authentication from a user who lost their device (for admin)
+func (s *TwoFactorService) ResetUser(userID uint) error {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return utils.NewNotFoundError("User not found", nil)
+	}
+	if err := s.twoFactorRepo.Disable(userID); err != nil {
+		return utils.NewInternalServerError("Failed to reset two-factor authentication", err)
+	}
+	return nil
+}
+
+func (s *TwoFactorService) verifyTOTP(user *entities.User, code string) error {
+	secret, err := utils.DecryptString(user.TwoFactorSecret)
+	if err != nil {
+		return utils.NewInternalServerError("Failed to read secret", err)
+	}
+
+	step, ok := utils.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return utils.NewUnauthorizedError("Invalid two-factor code", nil)
+	}
+
+	fresh, err := s.twoFactorRepo.MarkStepUsed(user.ID, step)
+	if err != nil {
+		return utils.NewInternalServerError("Failed to verify two-factor code", err)
+	}
+	if !fresh {
+		return utils.NewUnauthorizedError("Two-factor code has already been used, wait for the next code", nil)
+	}
+	return nil
+}
+
+func (s *TwoFactorService) getEnrollableUser(userID uint) (*entities.User, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("User not found", nil)
+	}
+	if !isTwoFactorRole(user.Role) {
+		return nil, utils.NewForbiddenError("Two-factor authentication is not available for customer accounts", nil)
+	}
+	return user, nil
+}
+
+func (s *TwoFactorService) isRequiredForRole(role string) (bool, error) {
+	if !isTwoFactorRole(role) {
+		return false, nil
+	}
+	policy, err := s.twoFactorRepo.GetPolicy(role)
+	if err != nil {
+		return false, err
+	}
+	return policy != nil && policy.Required, nil
+}
+
+func isTwoFactorRole(role string) bool {
+	return role != constants.ROLE_USER && role != constants.ROLE_PARTNER
+}
+
+// newRecoveryCodes returns the plain codes for the user and the hashed entities to store
+func newRecoveryCodes() ([]string, []entities.TwoFactorRecoveryCode, error) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	codes := make([]string, recoveryCodeCount)
+	records := make([]entities.TwoFactorRecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		buf := make([]byte, 10)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		for j := range buf {
+			buf[j] = alphabet[int(buf[j])%len(alphabet)]
+		}
+		codes[i] = string(buf[:5]) + "-" + string(buf[5:])
+		records[i] = entities.TwoFactorRecoveryCode{CodeHash: hashRecoveryCode(codes[i])}
+	}
+	return codes, records, nil
+}
+
+func hashRecoveryCode(code string) string {
+	return utils.HashOpaqueToken(strings.ToLower(strings.TrimSpace(code)))
+}
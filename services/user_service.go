@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"malakashuttle/constants"
 	"malakashuttle/dto"
 	"malakashuttle/repositories"
 	"malakashuttle/utils"
@@ -10,12 +11,14 @@ import (
 )
 
 type UserService struct {
-	userRepo repositories.UserRepository
+	userRepo    repositories.UserRepository
+	roleService *RoleService
 }
 
-func NewUserService(userRepo repositories.UserRepository) *UserService {
+func NewUserService(userRepo repositories.UserRepository, roleService *RoleService) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:    userRepo,
+		roleService: roleService,
 	}
 }
 
@@ -49,11 +52,10 @@ func (s *UserService) GetUserByID(id uint) (*dto.UserResponse, error) {
 	return dto.NewUserResponseFromEntity(user), nil
 }
 
-// CreateUser creates a new user (only staff or user role allowed)
+// CreateUser creates a new user with any stored role except admin
 func (s *UserService) CreateUser(req dto.CreateUserRequest) (*dto.UserResponse, error) {
-	// Validate role (only staff and user allowed)
-	if req.Role != "staff" && req.Role != "user" {
-		return nil, errors.New("invalid role: only 'staff' and 'user' roles are allowed")
+	if err := s.validateAssignableRole(req.Role); err != nil {
+		return nil, err
 	}
 
 	// Check if user with email already exists
@@ -65,8 +67,9 @@ func (s *UserService) CreateUser(req dto.CreateUserRequest) (*dto.UserResponse,
 		return nil, err
 	}
 
-	// Convert DTO to entity
+	// Convert DTO to entity, accounts created by an admin do not need verification
 	user := req.ToUserEntity()
+	user.MarkVerified()
 
 	// Hash password
 	if err := user.HashPassword(); err != nil {
@@ -92,9 +95,11 @@ func (s *UserService) UpdateUser(id uint, req dto.UpdateUserRequest) (*dto.UserR
 		return nil, err
 	}
 
-	// Prevent updating to admin role
-	if req.Role == "admin" {
-		return nil, errors.New("cannot update user to admin role")
+	// Prevent updating to admin role or a role that does not exist
+	if req.Role != "" {
+		if err := s.validateAssignableRole(req.Role); err != nil {
+			return nil, err
+		}
 	}
 
 	// Prevent updating admin user
@@ -113,8 +118,16 @@ func (s *UserService) UpdateUser(id uint, req dto.UpdateUserRequest) (*dto.UserR
 		}
 	}
 
+	// Tokens carry the role and email, so changing them (or the password) invalidates existing tokens
+	credentialsChanged := (req.Email != "" && req.Email != user.Email) ||
+		(req.Role != "" && req.Role != user.Role) ||
+		req.Password != ""
+
 	// Apply updates from DTO
 	req.ApplyToEntity(user)
+	if credentialsChanged {
+		user.BumpTokenVersion()
+	}
 
 	// Hash password if it's being updated
 	if req.Password != "" {
@@ -149,3 +162,21 @@ func (s *UserService) DeleteUser(id uint) error {
 
 	return s.userRepo.Delete(id)
 }
+
+// validateAssignableRole checks that the role exists and is not admin or partner
+func (s *UserService) validateAssignableRole(role string) error {
+	if role == constants.ROLE_ADMIN {
+		return errors.New("invalid role: the admin role cannot be assigned")
+	}
+	if role == constants.ROLE_PARTNER {
+		return errors.New("invalid role: partner accounts are created through /admin/partners")
+	}
+	exists, err := s.roleService.RoleExists(role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("invalid role: role does not exist")
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"malakashuttle/config"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/mailer"
+	"malakashuttle/repositories"
+	"malakashuttle/sms"
+	"malakashuttle/utils"
+	"math/big"
+	"net/url"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VerificationService verifies the email address and phone number of users
+type VerificationService struct {
+	verificationRepo repositories.VerificationRepository
+	userRepo         repositories.UserRepository
+	mailSender       mailer.Sender
+	smsSender        sms.Sender
+}
+
+func NewVerificationService(
+	verificationRepo repositories.VerificationRepository,
+	userRepo repositories.UserRepository,
+	mailSender mailer.Sender,
+	smsSender sms.Sender,
+) *VerificationService {
+	return &VerificationService{
+		verificationRepo: verificationRepo,
+		userRepo:         userRepo,
+		mailSender:       mailSender,
+		smsSender:        smsSender,
+	}
+}
+
+// SendInitialVerifications sends the email link and the phone code after registration
+func (s *VerificationService) SendInitialVerifications(user *entities.User) error {
+	if err := s.sendEmailVerification(user, user.Email); err != nil {
+		return err
+	}
+	return s.sendPhoneVerification(user)
+}
+
+// SendEmailChangeVerification sends the verification link to the pending email of the user
+func (s *VerificationService) SendEmailChangeVerification(user *entities.User) error {
+	return s.sendEmailVerification(user, user.PendingEmail)
+}
+
+// SendPhoneVerification sends a code to the current phone number of the user, e.g. after it changed
+func (s *VerificationService) SendPhoneVerification(user *entities.User) error {
+	return s.sendPhoneVerification(user)
+}
+
+// GetStatus returns the verification state of a user
+func (s *VerificationService) GetStatus(userID uint) (*dto.VerificationStatusResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, utils.NewNotFoundError("User not found", nil)
+	}
+	return dto.NewVerificationStatusResponseFromEntity(user), nil
+}
+
+// Resend sends a new verification code. Earlier codes of the same channel stop working.
+func (s *VerificationService) Resend(userID uint, req dto.ResendVerificationRequest) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return utils.NewNotFoundError("User not found", nil)
+	}
+
+	channel := entities.VerificationChannel(req.Channel)
+	pendingEmailChange := channel == entities.VerificationChannelEmail && user.PendingEmail != ""
+	if !pendingEmailChange && ((channel == entities.VerificationChannelEmail && user.EmailVerifiedAt != nil) ||
+		(channel == entities.VerificationChannelPhone && user.PhoneVerifiedAt != nil)) {
+		return utils.NewConflictError(fmt.Sprintf("%s has already been verified", req.Channel), nil)
+	}
+
+	cooldown := config.GetVerificationConfig().ResendCooldown
+	latest, err := s.verificationRepo.FindLatestCode(userID, channel)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return utils.NewInternalServerError("Failed to check verification codes", err)
+	}
+	if latest != nil {
+		if wait := time.Until(latest.CreatedAt.Add(cooldown)); wait > 0 {
+			return utils.NewTooManyRequestsErrorWithDetails("Please wait before requesting a new code", nil,
+				map[string]interface{}{"retry_after_seconds": int(wait.Seconds()) + 1})
+		}
+	}
+
+	if pendingEmailChange {
+		return s.sendEmailVerification(user, user.PendingEmail)
+	}
+	if channel == entities.VerificationChannelEmail {
+		return s.sendEmailVerification(user, user.Email)
+	}
+	return s.sendPhoneVerification(user)
+}
+
+// VerifyEmail verifies an email address with the token from the emailed link
+func (s *VerificationService) VerifyEmail(req dto.VerifyEmailRequest) error {
+	code, err := s.verificationRepo.FindActiveCodeByHash(entities.VerificationChannelEmail, utils.HashOpaqueToken(req.Token))
+	if err != nil {
+		return utils.NewBadRequestError("Verification link is invalid or expired", nil)
+	}
+
+	if err := s.verificationRepo.ConfirmCode(code); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.NewBadRequestError("Verification link is invalid or expired", nil)
+		}
+		return utils.NewInternalServerError("Failed to verify email", err)
+	}
+	return nil
+}
+
+// VerifyPhone verifies the phone number of a user with the code from the text message
+func (s *VerificationService) VerifyPhone(userID uint, req dto.VerifyPhoneRequest) error {
+	code, err := s.verificationRepo.FindActiveCode(userID, entities.VerificationChannelPhone)
+	if err != nil {
+		return utils.NewBadRequestError("Verification code is invalid or expired", nil)
+	}
+
+	if code.Attempts >= config.GetVerificationConfig().MaxAttempts {
+		return utils.NewBadRequestError("Too many wrong attempts, please request a new code", nil)
+	}
+
+	if code.CodeHash != utils.HashOpaqueToken(req.Code) {
+		if err := s.verificationRepo.IncrementAttempts(code.ID); err != nil {
+			return utils.NewInternalServerError("Failed to verify phone number", err)
+		}
+		return utils.NewBadRequestError("Verification code is invalid or expired", nil)
+	}
+
+	if err := s.verificationRepo.ConfirmCode(code); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return utils.NewBadRequestError("Verification code is invalid or expired", nil)
+		}
+		return utils.NewInternalServerError("Failed to verify phone number", err)
+	}
+	return nil
+}
+
+func (s *VerificationService) sendEmailVerification(user *entities.User, email string) error {
+	token, hash, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return utils.NewInternalServerError("Failed to generate verification token", err)
+	}
+
+	ttl := config.GetVerificationConfig().EmailTTL
+	code := &entities.VerificationCode{
+		UserID:    user.ID,
+		Channel:   entities.VerificationChannelEmail,
+		Target:    email,
+		CodeHash:  hash,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.verificationRepo.CreateCode(code); err != nil {
+		return utils.NewInternalServerError("Failed to create verification code", err)
+	}
+
+	msg := mailer.Message{
+		To:      email,
+		Subject: "Verify your Malaka Shuttle email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease verify your email address by opening the link below:\n\n%s/verify-email?token=%s\n\nThe link expires in %d hours.\n\nMalaka Shuttle",
+			user.FirstName,
+			config.GetFrontendURL(),
+			url.QueryEscape(token),
+			int(ttl.Hours()),
+		),
+	}
+	go func() {
+		if err := s.mailSender.Send(msg); err != nil {
+			log.Printf("Failed to send verification email to user #%d: %v", user.ID, err)
+		}
+	}()
+	return nil
+}
+
+func (s *VerificationService) sendPhoneVerification(user *entities.User) error {
+	otp, err := generateNumericCode(6)
+	if err != nil {
+		return utils.NewInternalServerError("Failed to generate verification code", err)
+	}
+
+	ttl := config.GetVerificationConfig().PhoneTTL
+	code := &entities.VerificationCode{
+		UserID:    user.ID,
+		Channel:   entities.VerificationChannelPhone,
+		Target:    user.PhoneNumber,
+		CodeHash:  utils.HashOpaqueToken(otp),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.verificationRepo.CreateCode(code); err != nil {
+		return utils.NewInternalServerError("Failed to create verification code", err)
+	}
+
+	msg := sms.Message{
+		To:   user.PhoneNumber,
+		Body: fmt.Sprintf("Kode verifikasi Malaka Shuttle Anda: %s. Berlaku %d menit. Jangan berikan kode ini kepada siapa pun.", otp, int(ttl.Minutes())),
+	}
+	go func() {
+		if err := s.smsSender.Send(msg); err != nil {
+			log.Printf("Failed to send verification sms to user #%d: %v", user.ID, err)
+		}
+	}()
+	return nil
+}
+
+// generateNumericCode returns a random code of the given number of digits
+func generateNumericCode(digits int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
@@ -0,0 +1,631 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"malakashuttle/config"
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/repositories"
+	"malakashuttle/utils"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	webhookSecretPrefix = "whsec_"
+	// webhookSendLease is how long a claimed delivery is hidden from other dispatchers
+	webhookSendLease = 5 * time.Minute
+
+	webhookSignatureHeader = "X-Webhook-Signature"
+	webhookEventHeader     = "X-Webhook-Event"
+	webhookEventIDHeader   = "X-Webhook-Event-ID"
+	webhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// WebhookService manages webhook endpoints and delivers signed event payloads to them
+type WebhookService struct {
+	webhookRepo repositories.WebhookRepository
+	partnerRepo repositories.PartnerRepository
+	cfg         config.WebhookConfig
+	client      *http.Client
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepository, partnerRepo repositories.PartnerRepository) *WebhookService {
+	cfg := config.GetWebhookConfig()
+
+	// Check the address actually dialed, so a host that resolves to an internal address after
+	// registration can't make the dispatcher post signed payloads into the private network
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("webhook target %s is not a public address", host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		partnerRepo: partnerRepo,
+		cfg:         cfg,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   cfg.Timeout,
+			// A redirect counts as a failed delivery, the endpoint URL has to be updated instead
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// BookingOutbox returns the builder that queues a booking event for every active endpoint subscribed to it.
+// Endpoints of a partner only receive events about the partner's bookings.
+func (s *WebhookService) BookingOutbox(event entities.WebhookEvent) repositories.OutboxBuilder {
+	return func(booking *entities.Booking) (*repositories.Outbox, error) {
+		endpoints, err := s.subscribedEndpoints(event)
+		if err != nil {
+			return nil, err
+		}
+
+		var targets []entities.WebhookEndpoint
+		for _, endpoint := range endpoints {
+			if endpoint.PartnerID == nil || (booking.PartnerID != nil && *endpoint.PartnerID == *booking.PartnerID) {
+				targets = append(targets, endpoint)
+			}
+		}
+		if len(targets) == 0 {
+			return nil, nil
+		}
+
+		deliveries, err := newWebhookDeliveries(event, newWebhookBookingData(booking), targets)
+		if err != nil {
+			return nil, err
+		}
+		return &repositories.Outbox{Deliveries: deliveries}, nil
+	}
+}
+
+// ScheduleCancelledOutbox queues the schedule.cancelled event. Endpoints of a partner are only told about
+// the cancellation if it cancelled bookings of the partner, and only see those bookings.
+func (s *WebhookService) ScheduleCancelledOutbox(schedule *entities.Schedule, bookings []entities.Booking) (*repositories.Outbox, error) {
+	endpoints, err := s.subscribedEndpoints(entities.WebhookEventScheduleCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	outbox := &repositories.Outbox{}
+	for _, endpoint := range endpoints {
+		bookingIDs := make([]uint, 0, len(bookings))
+		for _, booking := range bookings {
+			if endpoint.PartnerID == nil || (booking.PartnerID != nil && *endpoint.PartnerID == *booking.PartnerID) {
+				bookingIDs = append(bookingIDs, booking.ID)
+			}
+		}
+		if endpoint.PartnerID != nil && len(bookingIDs) == 0 {
+			continue
+		}
+
+		data := dto.WebhookScheduleCancelledData{
+			ScheduleID:          schedule.ID,
+			Origin:              schedule.Route.OriginCity,
+			Destination:         schedule.Route.DestinationCity,
+			DepartureTime:       schedule.DepartureTime,
+			CancelledAt:         schedule.CancelledAt,
+			Reason:              schedule.CancellationReason,
+			CancelledBookingIDs: bookingIDs,
+		}
+		deliveries, err := newWebhookDeliveries(entities.WebhookEventScheduleCancelled, data, []entities.WebhookEndpoint{endpoint})
+		if err != nil {
+			return nil, err
+		}
+		outbox.Deliveries = append(outbox.Deliveries, deliveries...)
+	}
+	return outbox, nil
+}
+
+func (s *WebhookService) subscribedEndpoints(event entities.WebhookEvent) ([]entities.WebhookEndpoint, error) {
+	endpoints, err := s.webhookRepo.GetActiveEndpoints()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook endpoints: %w", err)
+	}
+
+	var subscribed []entities.WebhookEndpoint
+	for _, endpoint := range endpoints {
+		if endpoint.Subscribes(event) {
+			subscribed = append(subscribed, endpoint)
+		}
+	}
+	return subscribed, nil
+}
+
+// newWebhookDeliveries creates one delivery of the same event payload per endpoint
+func newWebhookDeliveries(event entities.WebhookEvent, data interface{}, endpoints []entities.WebhookEndpoint) ([]entities.WebhookDelivery, error) {
+	eventID, err := newWebhookEventID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(dto.WebhookPayload{
+		ID:        eventID,
+		Event:     string(event),
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	deliveries := make([]entities.WebhookDelivery, len(endpoints))
+	for i, endpoint := range endpoints {
+		deliveries[i] = entities.WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			Event:         event,
+			EventID:       eventID,
+			Payload:       string(payload),
+			Status:        entities.WebhookDeliveryStatusPending,
+			NextAttemptAt: now,
+		}
+	}
+	return deliveries, nil
+}
+
+func newWebhookBookingData(booking *entities.Booking) dto.WebhookBookingData {
+	seats := make([]string, 0, len(booking.BookingDetails))
+	for _, detail := range booking.BookingDetails {
+		seats = append(seats, detail.Seat.SeatNumber)
+	}
+	sort.Strings(seats)
+
+	return dto.WebhookBookingData{
+		BookingID:      booking.ID,
+		UserID:         booking.UserID,
+		PartnerID:      booking.PartnerID,
+		Status:         string(booking.Status),
+		ScheduleID:     booking.ScheduleID,
+		Origin:         booking.Schedule.Route.OriginCity,
+		Destination:    booking.Schedule.Route.DestinationCity,
+		DepartureTime:  booking.Schedule.DepartureTime,
+		Seats:          seats,
+		PassengerCount: len(booking.BookingDetails),
+		PaymentAmount:  booking.ChargedAmount(),
+		ExpiresAt:      booking.ExpiresAt,
+		UpdatedAt:      booking.UpdatedAt,
+	}
+}
+
+func newWebhookEventID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook event id: %w", err)
+	}
+	return "evt_" + hex.EncodeToString(buf), nil
+}
+
+// signWebhookPayload returns the signature header value of a payload: the unix timestamp and the
+// hex HMAC-SHA256 of "<timestamp>.<payload>" keyed with the endpoint secret
+func signWebhookPayload(secret string, timestamp time.Time, payload []byte) string {
+	unix := timestamp.Unix()
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", unix)
+	mac.Write(payload)
+	return fmt.Sprintf("t=%d,v1=%s", unix, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// DispatchPending delivers the webhook deliveries that are due. Failed deliveries are retried with
+// exponential backoff until the maximum number of attempts is reached.
+func (s *WebhookService) DispatchPending() error {
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(time.Now(), s.cfg.BatchSize, webhookSendLease)
+	if err != nil {
+		return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		attempt := s.deliver(delivery)
+
+		delivery.Attempts++
+		delivery.LastResponseStatus = attempt.ResponseStatus
+		delivery.LastError = attempt.Error
+		switch {
+		case attempt.Error == "":
+			now := time.Now()
+			delivery.Status = entities.WebhookDeliveryStatusSucceeded
+			delivery.DeliveredAt = &now
+		case delivery.Attempts >= s.cfg.MaxAttempts || !delivery.Endpoint.IsActive:
+			// Deliveries to deleted or disabled endpoints fail right away, they can be redelivered manually
+			delivery.Status = entities.WebhookDeliveryStatusFailed
+			log.Printf("Giving up on webhook delivery #%d (%s) after %d attempts: %s", delivery.ID, delivery.Event, delivery.Attempts, attempt.Error)
+		default:
+			delivery.NextAttemptAt = time.Now().Add(s.retryBackoff(delivery.Attempts))
+		}
+
+		if err := s.webhookRepo.RecordAttempt(delivery, attempt); err != nil {
+			log.Printf("Failed to record attempt of webhook delivery #%d: %v", delivery.ID, err)
+		}
+	}
+	return nil
+}
+
+// deliver posts the payload to the endpoint and describes the outcome, a non-empty Error means it failed
+func (s *WebhookService) deliver(delivery *entities.WebhookDelivery) *entities.WebhookDeliveryAttempt {
+	attempt := &entities.WebhookDeliveryAttempt{}
+	endpoint := delivery.Endpoint
+	if endpoint.ID == 0 {
+		attempt.Error = "webhook endpoint was deleted"
+		return attempt
+	}
+	if !endpoint.IsActive {
+		attempt.Error = "webhook endpoint is disabled"
+		return attempt
+	}
+
+	secret, err := utils.DecryptString(endpoint.Secret)
+	if err != nil {
+		attempt.Error = "failed to decrypt the webhook secret"
+		return attempt
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, strings.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = truncate(err.Error(), 500)
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "MalakaShuttle-Webhooks/1.0")
+	req.Header.Set(webhookEventHeader, string(delivery.Event))
+	req.Header.Set(webhookEventIDHeader, delivery.EventID)
+	req.Header.Set(webhookDeliveryHeader, fmt.Sprintf("%d", delivery.ID))
+	req.Header.Set(webhookSignatureHeader, signWebhookPayload(secret, time.Now(), []byte(delivery.Payload)))
+
+	started := time.Now()
+	resp, err := s.client.Do(req)
+	attempt.DurationMs = time.Since(started).Milliseconds()
+	if err != nil {
+		attempt.Error = truncate(err.Error(), 500)
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1000))
+	attempt.ResponseStatus = resp.StatusCode
+	attempt.ResponseBody = string(body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+// retryBackoff doubles the delay with every failed attempt, up to the configured maximum
+func (s *WebhookService) retryBackoff(attempts int) time.Duration {
+	backoff := s.cfg.RetryBackoff
+	for i := 1; i < attempts && backoff < s.cfg.MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.cfg.MaxRetryBackoff {
+		backoff = s.cfg.MaxRetryBackoff
+	}
+	return backoff
+}
+
+// GetEvents lists the events endpoints can subscribe to
+func (s *WebhookService) GetEvents() []string {
+	events := make([]string, len(entities.WebhookEvents))
+	for i, event := range entities.WebhookEvents {
+		events[i] = string(event)
+	}
+	return events
+}
+
+// CreateEndpoint registers a webhook endpoint and returns its signing secret
+func (s *WebhookService) CreateEndpoint(req dto.CreateWebhookEndpointRequest) (*dto.WebhookEndpointSecretResponse, error) {
+	events, err := validateWebhookEvents(req.Events)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+	if req.PartnerID != nil {
+		if _, err := s.partnerRepo.GetPartnerByID(*req.PartnerID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, utils.NewBadRequestError("Partner not found", err)
+			}
+			return nil, utils.NewInternalServerError("Failed to get partner", err)
+		}
+	}
+
+	endpoint := &entities.WebhookEndpoint{
+		URL:         strings.TrimSpace(req.URL),
+		Description: strings.TrimSpace(req.Description),
+		Events:      events,
+		PartnerID:   req.PartnerID,
+		IsActive:    true,
+	}
+	secret, err := assignNewWebhookSecret(endpoint)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to create webhook secret", err)
+	}
+
+	if err := s.webhookRepo.CreateEndpoint(endpoint); err != nil {
+		return nil, utils.NewInternalServerError("Failed to create webhook endpoint", err)
+	}
+
+	return &dto.WebhookEndpointSecretResponse{
+		WebhookEndpointResponse: dto.NewWebhookEndpointResponseFromEntity(endpoint),
+		Secret:                  secret,
+	}, nil
+}
+
+// GetEndpoints lists the webhook endpoints
+func (s *WebhookService) GetEndpoints() ([]dto.WebhookEndpointResponse, error) {
+	endpoints, err := s.webhookRepo.GetEndpoints()
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get webhook endpoints", err)
+	}
+
+	responses := make([]dto.WebhookEndpointResponse, len(endpoints))
+	for i := range endpoints {
+		responses[i] = dto.NewWebhookEndpointResponseFromEntity(&endpoints[i])
+	}
+	return responses, nil
+}
+
+// GetEndpoint gets a webhook endpoint
+func (s *WebhookService) GetEndpoint(id uint) (*dto.WebhookEndpointResponse, error) {
+	endpoint, err := s.getEndpoint(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.NewWebhookEndpointResponseFromEntity(endpoint)
+	return &response, nil
+}
+
+// UpdateEndpoint changes the URL, description, events or status of an endpoint
+func (s *WebhookService) UpdateEndpoint(id uint, req dto.UpdateWebhookEndpointRequest) (*dto.WebhookEndpointResponse, error) {
+	endpoint, err := s.getEndpoint(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := s.validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		endpoint.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Description != nil {
+		endpoint.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Events != nil {
+		events, err := validateWebhookEvents(req.Events)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.Events = events
+	}
+	if req.IsActive != nil {
+		endpoint.IsActive = *req.IsActive
+	}
+
+	if err := s.webhookRepo.UpdateEndpoint(endpoint); err != nil {
+		return nil, utils.NewInternalServerError("Failed to update webhook endpoint", err)
+	}
+
+	response := dto.NewWebhookEndpointResponseFromEntity(endpoint)
+	return &response, nil
+}
+
+// DeleteEndpoint removes an endpoint, its pending deliveries fail on their next attempt
+func (s *WebhookService) DeleteEndpoint(id uint) error {
+	if _, err := s.getEndpoint(id); err != nil {
+		return err
+	}
+	if err := s.webhookRepo.DeleteEndpoint(id); err != nil {
+		return utils.NewInternalServerError("Failed to delete webhook endpoint", err)
+	}
+	return nil
+}
+
+// RotateSecret replaces the signing secret of an endpoint, deliveries are signed with the new secret right away
+func (s *WebhookService) RotateSecret(id uint) (*dto.WebhookEndpointSecretResponse, error) {
+	endpoint, err := s.getEndpoint(id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := assignNewWebhookSecret(endpoint)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to create webhook secret", err)
+	}
+	if err := s.webhookRepo.UpdateEndpoint(endpoint); err != nil {
+		return nil, utils.NewInternalServerError("Failed to update webhook endpoint", err)
+	}
+
+	return &dto.WebhookEndpointSecretResponse{
+		WebhookEndpointResponse: dto.NewWebhookEndpointResponseFromEntity(endpoint),
+		Secret:                  secret,
+	}, nil
+}
+
+// GetDeliveries lists the deliveries of an endpoint, newest first, optionally filtered by status
+func (s *WebhookService) GetDeliveries(endpointID uint, params utils.PaginationParams, status string) (*utils.PaginationResponse, error) {
+	if _, err := s.getEndpoint(endpointID); err != nil {
+		return nil, err
+	}
+	switch entities.WebhookDeliveryStatus(status) {
+	case "", entities.WebhookDeliveryStatusPending, entities.WebhookDeliveryStatusSucceeded, entities.WebhookDeliveryStatusFailed:
+	default:
+		return nil, utils.NewBadRequestError("Invalid delivery status", nil)
+	}
+
+	deliveries, total, err := s.webhookRepo.GetDeliveries(endpointID, params.Page, params.Limit, status)
+	if err != nil {
+		return nil, utils.NewInternalServerError("Failed to get webhook deliveries", err)
+	}
+
+	responses := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		responses[i] = dto.NewWebhookDeliveryResponseFromEntity(&deliveries[i])
+	}
+
+	response := utils.CreatePaginationResponse(responses, total, params)
+	return &response, nil
+}
+
+// GetDelivery gets a delivery with its payload and attempt log
+func (s *WebhookService) GetDelivery(endpointID, deliveryID uint) (*dto.WebhookDeliveryDetailResponse, error) {
+	delivery, err := s.getDelivery(endpointID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.NewWebhookDeliveryDetailResponseFromEntity(delivery)
+	return &response, nil
+}
+
+// Redeliver queues a delivery again with a fresh attempt budget, the payload and event ID stay the same
+func (s *WebhookService) Redeliver(endpointID, deliveryID uint) (*dto.WebhookDeliveryDetailResponse, error) {
+	endpoint, err := s.getEndpoint(endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.IsActive {
+		return nil, utils.NewConflictError("Webhook endpoint is disabled, enable it before redelivering", nil)
+	}
+
+	delivery, err := s.getDelivery(endpointID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status == entities.WebhookDeliveryStatusPending {
+		return nil, utils.NewConflictError("Delivery is already queued", nil)
+	}
+
+	if err := s.webhookRepo.ResetDelivery(delivery.ID, time.Now()); err != nil {
+		return nil, utils.NewInternalServerError("Failed to queue webhook delivery", err)
+	}
+	return s.GetDelivery(endpointID, deliveryID)
+}
+
+func (s *WebhookService) getEndpoint(id uint) (*entities.WebhookEndpoint, error) {
+	endpoint, err := s.webhookRepo.GetEndpointByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Webhook endpoint not found", err)
+		}
+		return nil, utils.NewInternalServerError("Failed to get webhook endpoint", err)
+	}
+	return endpoint, nil
+}
+
+func (s *WebhookService) getDelivery(endpointID, deliveryID uint) (*entities.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.GetDelivery(deliveryID, endpointID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, utils.NewNotFoundError("Webhook delivery not found", err)
+		}
+		return nil, utils.NewInternalServerError("Failed to get webhook delivery", err)
+	}
+	return delivery, nil
+}
+
+// assignNewWebhookSecret generates a signing secret, stores it encrypted on the endpoint and returns it
+func assignNewWebhookSecret(endpoint *entities.WebhookEndpoint) (string, error) {
+	token, _, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	secret := webhookSecretPrefix + token
+
+	encrypted, err := utils.EncryptString(secret)
+	if err != nil {
+		return "", err
+	}
+	endpoint.Secret = encrypted
+	return secret, nil
+}
+
+// validateWebhookEvents checks the events and returns them as the stored comma separated list
+func validateWebhookEvents(events []string) (string, error) {
+	known := make(map[entities.WebhookEvent]bool)
+	for _, event := range entities.WebhookEvents {
+		known[event] = true
+	}
+
+	seen := make(map[string]bool)
+	var valid []string
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if !known[entities.WebhookEvent(event)] {
+			return "", utils.NewBadRequestErrorWithDetails("Unknown webhook event: "+event, nil,
+				map[string]interface{}{"available_events": entities.WebhookEvents})
+		}
+		if !seen[event] {
+			seen[event] = true
+			valid = append(valid, event)
+		}
+	}
+	if len(valid) == 0 {
+		return "", utils.NewBadRequestError("At least one webhook event is required", nil)
+	}
+	return strings.Join(valid, ","), nil
+}
+
+// validateWebhookURL checks the URL and that its host only resolves to public addresses
+func (s *WebhookService) validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return utils.NewBadRequestError("Webhook URL must be an absolute http or https URL", err)
+	}
+	if s.cfg.AllowPrivateNetworks {
+		return nil
+	}
+
+	addresses, err := net.LookupIP(parsed.Hostname())
+	if err != nil || len(addresses) == 0 {
+		return utils.NewBadRequestError("Webhook URL host cannot be resolved", err)
+	}
+	for _, ip := range addresses {
+		if !isPublicIP(ip) {
+			return utils.NewBadRequestError("Webhook URL must not point to a private, loopback or link-local address", nil)
+		}
+	}
+	return nil
+}
+
+// isPublicIP reports whether an address is reachable on the internet rather than internal to the host or network
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
@@ -0,0 +1,435 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"malakashuttle/entities"
+	"malakashuttle/utils"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// fakeWebhookRepository keeps endpoints and deliveries in memory
+type fakeWebhookRepository struct {
+	mu         sync.Mutex
+	endpoints  map[uint]*entities.WebhookEndpoint
+	deliveries map[uint]*entities.WebhookDelivery
+	attempts   []entities.WebhookDeliveryAttempt
+}
+
+func newFakeWebhookRepository() *fakeWebhookRepository {
+	return &fakeWebhookRepository{
+		endpoints:  make(map[uint]*entities.WebhookEndpoint),
+		deliveries: make(map[uint]*entities.WebhookDelivery),
+	}
+}
+
+func (r *fakeWebhookRepository) CreateEndpoint(endpoint *entities.WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	endpoint.ID = uint(len(r.endpoints) + 1)
+	stored := *endpoint
+	r.endpoints[endpoint.ID] = &stored
+	return nil
+}
+
+func (r *fakeWebhookRepository) GetEndpoints() ([]entities.WebhookEndpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var endpoints []entities.WebhookEndpoint
+	for _, endpoint := range r.endpoints {
+		endpoints = append(endpoints, *endpoint)
+	}
+	return endpoints, nil
+}
+
+func (r *fakeWebhookRepository) GetEndpointByID(id uint) (*entities.WebhookEndpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	endpoint, ok := r.endpoints[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *endpoint
+	return &copied, nil
+}
+
+func (r *fakeWebhookRepository) GetActiveEndpoints() ([]entities.WebhookEndpoint, error) {
+	endpoints, _ := r.GetEndpoints()
+	var active []entities.WebhookEndpoint
+	for _, endpoint := range endpoints {
+		if endpoint.IsActive {
+			active = append(active, endpoint)
+		}
+	}
+	return active, nil
+}
+
+func (r *fakeWebhookRepository) UpdateEndpoint(endpoint *entities.WebhookEndpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *endpoint
+	r.endpoints[endpoint.ID] = &stored
+	return nil
+}
+
+func (r *fakeWebhookRepository) DeleteEndpoint(id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.endpoints, id)
+	return nil
+}
+
+func (r *fakeWebhookRepository) ClaimDueDeliveries(now time.Time, limit int, lease time.Duration) ([]entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []entities.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if len(due) == limit {
+			break
+		}
+		if delivery.Status != entities.WebhookDeliveryStatusPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		delivery.NextAttemptAt = now.Add(lease)
+		claimed := *delivery
+		if endpoint, ok := r.endpoints[delivery.EndpointID]; ok {
+			claimed.Endpoint = *endpoint
+		}
+		due = append(due, claimed)
+	}
+	return due, nil
+}
+
+func (r *fakeWebhookRepository) RecordAttempt(delivery *entities.WebhookDelivery, attempt *entities.WebhookDeliveryAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attempt.DeliveryID = delivery.ID
+	r.attempts = append(r.attempts, *attempt)
+	stored := r.deliveries[delivery.ID]
+	stored.Status = delivery.Status
+	stored.Attempts = delivery.Attempts
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.LastResponseStatus = delivery.LastResponseStatus
+	stored.LastError = delivery.LastError
+	stored.DeliveredAt = delivery.DeliveredAt
+	return nil
+}
+
+func (r *fakeWebhookRepository) GetDeliveries(endpointID uint, page, limit int, status string) ([]entities.WebhookDelivery, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deliveries []entities.WebhookDelivery
+	for _, delivery := range r.deliveries {
+		if delivery.EndpointID == endpointID && (status == "" || string(delivery.Status) == status) {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+	return deliveries, int64(len(deliveries)), nil
+}
+
+func (r *fakeWebhookRepository) GetDelivery(id, endpointID uint) (*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivery, ok := r.deliveries[id]
+	if !ok || delivery.EndpointID != endpointID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *delivery
+	for _, attempt := range r.attempts {
+		if attempt.DeliveryID == id {
+			copied.AttemptLog = append(copied.AttemptLog, attempt)
+		}
+	}
+	return &copied, nil
+}
+
+func (r *fakeWebhookRepository) ResetDelivery(id uint, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delivery := r.deliveries[id]
+	delivery.Status = entities.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	delivery.LastError = ""
+	return nil
+}
+
+func (r *fakeWebhookRepository) delivery(id uint) entities.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.deliveries[id]
+}
+
+// webhookReceiver is a local HTTP server that records the webhook requests it receives
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	requests []receivedWebhook
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver(t *testing.T, status int) *webhookReceiver {
+	receiver := &webhookReceiver{status: status}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver.mu.Lock()
+		receiver.requests = append(receiver.requests, receivedWebhook{header: r.Header.Clone(), body: body})
+		status := receiver.status
+		receiver.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+func (r *webhookReceiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status = status
+}
+
+func (r *webhookReceiver) received() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedWebhook(nil), r.requests...)
+}
+
+// newTestWebhookService returns a service with an endpoint pointing at the receiver and one queued delivery
+func newTestWebhookService(t *testing.T, receiver *webhookReceiver) (*WebhookService, *fakeWebhookRepository, string, *entities.WebhookDelivery) {
+	t.Setenv("DATA_ENCRYPTION_KEY", "webhook-test-key")
+	t.Setenv("WEBHOOK_MAX_ATTEMPTS", "3")
+	t.Setenv("WEBHOOK_RETRY_BACKOFF", "1m")
+	t.Setenv("WEBHOOK_MAX_RETRY_BACKOFF", "1h")
+	// The receiver listens on loopback
+	t.Setenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "true")
+
+	repo := newFakeWebhookRepository()
+	service := NewWebhookService(repo, nil)
+
+	endpoint := &entities.WebhookEndpoint{
+		URL:      receiver.URL,
+		Events:   string(entities.WebhookEventBookingPaid),
+		IsActive: true,
+	}
+	secret, err := assignNewWebhookSecret(endpoint)
+	if err != nil {
+		t.Fatalf("assignNewWebhookSecret: %v", err)
+	}
+	if err := repo.CreateEndpoint(endpoint); err != nil {
+		t.Fatalf("CreateEndpoint: %v", err)
+	}
+
+	deliveries, err := newWebhookDeliveries(entities.WebhookEventBookingPaid, map[string]uint{"booking_id": 7}, []entities.WebhookEndpoint{*endpoint})
+	if err != nil {
+		t.Fatalf("newWebhookDeliveries: %v", err)
+	}
+	delivery := deliveries[0]
+	delivery.ID = 1
+	repo.deliveries[delivery.ID] = &delivery
+	return service, repo, secret, &delivery
+}
+
+// dispatchDue makes the delivery due right away and runs the dispatcher once
+func dispatchDue(t *testing.T, service *WebhookService, repo *fakeWebhookRepository, id uint) {
+	repo.mu.Lock()
+	repo.deliveries[id].NextAttemptAt = time.Now().Add(-time.Second)
+	repo.mu.Unlock()
+	if err := service.DispatchPending(); err != nil {
+		t.Fatalf("DispatchPending: %v", err)
+	}
+}
+
+func TestWebhookSignatureVerifies(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	service, repo, secret, delivery := newTestWebhookService(t, receiver)
+
+	dispatchDue(t, service, repo, delivery.ID)
+
+	requests := receiver.received()
+	if len(requests) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(requests))
+	}
+	request := requests[0]
+	if got := request.header.Get(webhookEventIDHeader); got != delivery.EventID {
+		t.Errorf("event ID header = %q, want %q", got, delivery.EventID)
+	}
+
+	// t=<unix>,v1=<hex HMAC-SHA256 of "<unix>.<body>">
+	var timestamp, signature string
+	for _, part := range strings.Split(request.header.Get(webhookSignatureHeader), ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		t.Fatalf("invalid signature timestamp %q", timestamp)
+	}
+	if age := time.Since(time.Unix(unix, 0)); age < -time.Minute || age > time.Minute {
+		t.Errorf("signature timestamp is %s off", age)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s.", timestamp)
+	mac.Write(request.body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		t.Errorf("signature v1=%s does not verify, want %s", signature, expected)
+	}
+
+	stored := repo.delivery(delivery.ID)
+	if stored.Status != entities.WebhookDeliveryStatusSucceeded || stored.DeliveredAt == nil {
+		t.Errorf("delivery status = %s, delivered_at = %v, want succeeded", stored.Status, stored.DeliveredAt)
+	}
+}
+
+func TestWebhookRetriesWithBackoffUntilFailed(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusInternalServerError)
+	service, repo, _, delivery := newTestWebhookService(t, receiver)
+
+	for attempt := 1; attempt < service.cfg.MaxAttempts; attempt++ {
+		before := time.Now()
+		dispatchDue(t, service, repo, delivery.ID)
+
+		stored := repo.delivery(delivery.ID)
+		if stored.Status != entities.WebhookDeliveryStatusPending {
+			t.Fatalf("after attempt %d status = %s, want pending", attempt, stored.Status)
+		}
+		if stored.Attempts != attempt {
+			t.Fatalf("attempts = %d, want %d", stored.Attempts, attempt)
+		}
+		if stored.LastResponseStatus != http.StatusInternalServerError {
+			t.Errorf("last response status = %d, want 500", stored.LastResponseStatus)
+		}
+		backoff := service.retryBackoff(attempt)
+		if stored.NextAttemptAt.Before(before.Add(backoff)) || stored.NextAttemptAt.After(time.Now().Add(backoff)) {
+			t.Errorf("after attempt %d next attempt is %s, want %s from now", attempt, time.Until(stored.NextAttemptAt), backoff)
+		}
+	}
+	if first, second := service.retryBackoff(1), service.retryBackoff(2); second != 2*first {
+		t.Errorf("backoff does not double: %s then %s", first, second)
+	}
+
+	dispatchDue(t, service, repo, delivery.ID)
+	stored := repo.delivery(delivery.ID)
+	if stored.Status != entities.WebhookDeliveryStatusFailed {
+		t.Fatalf("after %d attempts status = %s, want failed", service.cfg.MaxAttempts, stored.Status)
+	}
+	if got := len(receiver.received()); got != service.cfg.MaxAttempts {
+		t.Errorf("receiver got %d requests, want %d", got, service.cfg.MaxAttempts)
+	}
+
+	// A failed delivery is not picked up again
+	dispatchDue(t, service, repo, delivery.ID)
+	if got := len(receiver.received()); got != service.cfg.MaxAttempts {
+		t.Errorf("failed delivery was sent again, receiver got %d requests", got)
+	}
+}
+
+func TestWebhookRedeliverKeepsEventID(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusBadGateway)
+	service, repo, _, delivery := newTestWebhookService(t, receiver)
+
+	for attempt := 0; attempt < service.cfg.MaxAttempts; attempt++ {
+		dispatchDue(t, service, repo, delivery.ID)
+	}
+	if stored := repo.delivery(delivery.ID); stored.Status != entities.WebhookDeliveryStatusFailed {
+		t.Fatalf("status = %s, want failed", stored.Status)
+	}
+
+	response, err := service.Redeliver(delivery.EndpointID, delivery.ID)
+	if err != nil {
+		t.Fatalf("Redeliver: %v", err)
+	}
+	if response.Status != string(entities.WebhookDeliveryStatusPending) || response.Attempts != 0 {
+		t.Errorf("redelivered status = %s, attempts = %d, want pending with 0 attempts", response.Status, response.Attempts)
+	}
+	if response.EventID != delivery.EventID {
+		t.Errorf("redelivered event ID = %s, want %s", response.EventID, delivery.EventID)
+	}
+
+	// Redelivering a queued delivery is rejected
+	if _, err := service.Redeliver(delivery.EndpointID, delivery.ID); err == nil {
+		t.Error("Redeliver of a pending delivery succeeded, want conflict")
+	} else if customErr, ok := err.(*utils.CustomError); !ok || customErr.StatusCode != http.StatusConflict {
+		t.Errorf("Redeliver of a pending delivery = %v, want conflict", err)
+	}
+
+	receiver.setStatus(http.StatusNoContent)
+	if err := service.DispatchPending(); err != nil {
+		t.Fatalf("DispatchPending: %v", err)
+	}
+	requests := receiver.received()
+	last := requests[len(requests)-1]
+	if got := last.header.Get(webhookEventIDHeader); got != delivery.EventID {
+		t.Errorf("redelivery event ID header = %q, want %q", got, delivery.EventID)
+	}
+	if string(last.body) != delivery.Payload {
+		t.Error("redelivery payload differs from the original")
+	}
+	if stored := repo.delivery(delivery.ID); stored.Status != entities.WebhookDeliveryStatusSucceeded {
+		t.Errorf("status after redelivery = %s, want succeeded", stored.Status)
+	}
+}
+
+func TestWebhookURLRejectsInternalAddresses(t *testing.T) {
+	t.Setenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false")
+	service := NewWebhookService(newFakeWebhookRepository(), nil)
+
+	for _, rawURL := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.5/hook",
+		"https://192.168.1.10/hook",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+	} {
+		err := service.validateWebhookURL(rawURL)
+		if customErr, ok := err.(*utils.CustomError); !ok || customErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("validateWebhookURL(%s) = %v, want bad request", rawURL, err)
+		}
+	}
+
+	if err := service.validateWebhookURL("https://203.0.113.10/hook"); err != nil {
+		t.Errorf("validateWebhookURL of a public address = %v, want nil", err)
+	}
+}
+
+func TestWebhookDispatcherRefusesInternalTargets(t *testing.T) {
+	receiver := newWebhookReceiver(t, http.StatusOK)
+	_, repo, _, delivery := newTestWebhookService(t, receiver)
+
+	// An endpoint registered while its host was public may resolve to an internal address later
+	t.Setenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false")
+	service := NewWebhookService(repo, nil)
+
+	dispatchDue(t, service, repo, delivery.ID)
+
+	if got := len(receiver.received()); got != 0 {
+		t.Errorf("receiver on loopback got %d requests, want none", got)
+	}
+	stored := repo.delivery(delivery.ID)
+	if stored.Status != entities.WebhookDeliveryStatusPending || !strings.Contains(stored.LastError, "not a public address") {
+		t.Errorf("delivery status = %s, last error = %q, want a pending retry refused as not public", stored.Status, stored.LastError)
+	}
+}
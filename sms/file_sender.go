@@ -0,0 +1,34 @@
+package sms
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^0-9+]`)
+
+// FileSender writes messages to text files instead of sending them. It is the
+// stand-in for the SMS gateway during local development.
+type FileSender struct {
+	dir string
+}
+
+func NewFileSender(dir string) *FileSender {
+	return &FileSender{dir: dir}
+}
+
+func (s *FileSender) Send(msg Message) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create sms directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("%s_%s.txt",
+		time.Now().Format("20060102_150405.000000000"),
+		unsafeFileChars.ReplaceAllString(msg.To, "_"),
+	)
+	content := fmt.Sprintf("To: %s\nDate: %s\n\n%s\n", msg.To, time.Now().Format(time.RFC1123Z), msg.Body)
+	return os.WriteFile(filepath.Join(s.dir, filename), []byte(content), 0644)
+}
@@ -0,0 +1,54 @@
+package sms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"malakashuttle/config"
+	"net/http"
+	"time"
+)
+
+// HTTPSender posts messages as JSON to an SMS gateway
+type HTTPSender struct {
+	cfg    config.SMSConfig
+	client *http.Client
+}
+
+func NewHTTPSender(cfg config.SMSConfig) *HTTPSender {
+	return &HTTPSender{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *HTTPSender) Send(msg Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"from":    s.cfg.Sender,
+		"to":      msg.To,
+		"message": msg.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.cfg.GatewayURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.APIKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach sms gateway: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sms gateway responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
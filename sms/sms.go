@@ -0,0 +1,33 @@
+package sms
+
+import (
+	"fmt"
+	"malakashuttle/config"
+)
+
+// Message is a text message to a phone number
+type Message struct {
+	To   string
+	Body string
+}
+
+// Sender delivers text messages
+type Sender interface {
+	Send(msg Message) error
+}
+
+// NewSenderFromConfig creates the sender selected by SMS_DRIVER
+func NewSenderFromConfig() (Sender, error) {
+	cfg := config.GetSMSConfig()
+	switch cfg.Driver {
+	case "http":
+		if cfg.GatewayURL == "" {
+			return nil, fmt.Errorf("SMS_GATEWAY_URL is required for the http sms driver")
+		}
+		return NewHTTPSender(cfg), nil
+	case "file":
+		return NewFileSender(cfg.FileDir), nil
+	default:
+		return nil, fmt.Errorf("unsupported sms driver: %s", cfg.Driver)
+	}
+}
@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"malakashuttle/config"
+)
+
+// BankStatementRow represents a single credit transaction parsed from a bank statement
+type BankStatementRow struct {
+	RowNumber       int
+	TransactionDate time.Time
+	Description     string
+	Amount          float64
+}
+
+// ParseBankStatement parses a bank statement CSV using the given column mapping.
+// Only credit (incoming) transactions are returned; debit rows and blank lines are skipped.
+func ParseBankStatement(r io.Reader, mapping config.BankStatementMapping, loc *time.Location) ([]BankStatementRow, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = mapping.Delimiter
+	reader.FieldsPerRecord = -1 // bank exports often have trailing summary lines
+	reader.TrimLeadingSpace = true
+
+	var rows []BankStatementRow
+	rowNumber := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		rowNumber++
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNumber, err)
+		}
+		if rowNumber <= mapping.SkipRows || isBlankRecord(record) {
+			continue
+		}
+
+		// Summary lines at the end of the export don't have all columns
+		if !hasColumns(record, mapping.DateColumn, mapping.DescriptionColumn, mapping.AmountColumn) {
+			continue
+		}
+
+		// Skip debit transactions when the export marks the transaction type
+		if mapping.TypeColumn >= 0 {
+			if mapping.TypeColumn >= len(record) ||
+				!strings.EqualFold(strings.TrimSpace(record[mapping.TypeColumn]), mapping.CreditMarker) {
+				continue
+			}
+		}
+
+		amountStr := strings.TrimSpace(record[mapping.AmountColumn])
+		if amountStr == "" {
+			continue // no credit on this row (e.g. Mandiri debit rows)
+		}
+		amount, err := parseStatementAmount(amountStr, mapping)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid amount %q", rowNumber, amountStr)
+		}
+		if amount <= 0 {
+			continue
+		}
+
+		date, err := parseStatementDate(strings.TrimSpace(record[mapping.DateColumn]), mapping.DateLayout, loc)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid date %q", rowNumber, record[mapping.DateColumn])
+		}
+
+		rows = append(rows, BankStatementRow{
+			RowNumber:       rowNumber,
+			TransactionDate: date,
+			Description:     strings.TrimSpace(record[mapping.DescriptionColumn]),
+			Amount:          amount,
+		})
+	}
+
+	return rows, nil
+}
+
+// parseStatementAmount converts a formatted amount (e.g. "1,500,000.00" or "1.500.000,00") to float
+func parseStatementAmount(value string, mapping config.BankStatementMapping) (float64, error) {
+	value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), mapping.CreditMarker))
+	value = strings.TrimPrefix(value, "Rp")
+	value = strings.TrimSpace(value)
+	if mapping.ThousandSeparator != "" {
+		value = strings.ReplaceAll(value, mapping.ThousandSeparator, "")
+	}
+	if mapping.DecimalSeparator != "" && mapping.DecimalSeparator != "." {
+		value = strings.ReplaceAll(value, mapping.DecimalSeparator, ".")
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// parseStatementDate parses a transaction date; exports without a year (e.g. "16/10") get the
+// most recent matching year
+func parseStatementDate(value, layout string, loc *time.Location) (time.Time, error) {
+	date, err := time.ParseInLocation(layout, value, loc)
+	if err == nil {
+		return date, nil
+	}
+
+	// KlikBCA sometimes exports "DD/MM" only
+	date, shortErr := time.ParseInLocation("02/01", value, loc)
+	if shortErr != nil {
+		return time.Time{}, err
+	}
+	now := time.Now().In(loc)
+	date = date.AddDate(now.Year(), 0, 0)
+	if date.After(now) {
+		date = date.AddDate(-1, 0, 0)
+	}
+	return date, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func hasColumns(record []string, columns ...int) bool {
+	for _, column := range columns {
+		if column < 0 || column >= len(record) {
+			return false
+		}
+	}
+	return true
+}
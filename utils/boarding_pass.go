@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"malakashuttle/config"
+	"strconv"
+	"strings"
+)
+
+// boardingPassPrefix marks the format of the code so it can change later
+const boardingPassPrefix = "MSBP1"
+
+// boardingPassSignatureSize is the length of the truncated HMAC, it keeps the QR code small
+const boardingPassSignatureSize = 16
+
+// ErrInvalidBoardingPass is returned for codes that are malformed or not signed by us
+var ErrInvalidBoardingPass = errors.New("invalid boarding pass")
+
+// BoardingPassPayload is what a boarding pass code proves: the passenger of a booking
+// sits on a seat of a schedule
+type BoardingPassPayload struct {
+	BookingID   uint
+	PassengerID uint // ID of the booking detail
+	ScheduleID  uint
+	SeatNumber  string
+}
+
+// BoardingPass is a passenger's boarding pass as it is printed
+type BoardingPass struct {
+	PassengerName string
+	SeatNumber    string
+	Code          string
+}
+
+// SignBoardingPass returns the code printed in the QR of a boarding pass, in the form
+// MSBP1|booking|passenger|schedule|seat|signature
+func SignBoardingPass(payload BoardingPassPayload) (string, error) {
+	key, err := config.GetBoardingPassKey()
+	if err != nil {
+		return "", err
+	}
+	message := fmt.Sprintf("%s|%d|%d|%d|%s", boardingPassPrefix, payload.BookingID, payload.PassengerID, payload.ScheduleID, payload.SeatNumber)
+	return message + "|" + boardingPassSignature(key, message), nil
+}
+
+// VerifyBoardingPass checks the signature of a boarding pass code and returns its payload
+func VerifyBoardingPass(code string) (*BoardingPassPayload, error) {
+	key, err := config.GetBoardingPassKey()
+	if err != nil {
+		return nil, err
+	}
+
+	code = strings.TrimSpace(code)
+	cut := strings.LastIndex(code, "|")
+	if cut < 0 {
+		return nil, ErrInvalidBoardingPass
+	}
+	message, signature := code[:cut], code[cut+1:]
+	if !hmac.Equal([]byte(signature), []byte(boardingPassSignature(key, message))) {
+		return nil, ErrInvalidBoardingPass
+	}
+
+	// The seat number is the last field, so it may contain any character
+	fields := strings.SplitN(message, "|", 5)
+	if len(fields) != 5 || fields[0] != boardingPassPrefix {
+		return nil, ErrInvalidBoardingPass
+	}
+	ids := make([]uint, 3)
+	for i, field := range fields[1:4] {
+		id, err := strconv.ParseUint(field, 10, 32)
+		if err != nil {
+			return nil, ErrInvalidBoardingPass
+		}
+		ids[i] = uint(id)
+	}
+
+	return &BoardingPassPayload{
+		BookingID:   ids[0],
+		PassengerID: ids[1],
+		ScheduleID:  ids[2],
+		SeatNumber:  fields[4],
+	}, nil
+}
+
+func boardingPassSignature(key []byte, message string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:boardingPassSignatureSize])
+}
@@ -64,6 +64,10 @@ func NewConflictError(message string, err error) *CustomError {
 	return NewCustomError(409, message, err)
 }
 
+func NewTooManyRequestsError(message string, err error) *CustomError {
+	return NewCustomError(429, message, err)
+}
+
 func NewValidationError(message string, err error) *CustomError {
 	return NewCustomError(422, message, err)
 }
@@ -93,6 +97,10 @@ func NewConflictErrorWithDetails(message string, err error, details interface{})
 	return NewCustomErrorWithDetails(409, message, err, details)
 }
 
+func NewTooManyRequestsErrorWithDetails(message string, err error, details interface{}) *CustomError {
+	return NewCustomErrorWithDetails(429, message, err, details)
+}
+
 func NewValidationErrorWithDetails(message string, err error, details interface{}) *CustomError {
 	return NewCustomErrorWithDetails(422, message, err, details)
 }
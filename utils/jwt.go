@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"errors"
 	"malakashuttle/config"
+	"malakashuttle/entities"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,16 +12,22 @@ import (
 
 // JWTClaims represents the JWT claims structure
 type JWTClaims struct {
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	Email        string `json:"email"`
+	Role         string `json:"role"`
+	SessionID    uint   `json:"sid"`
+	TokenVersion uint   `json:"ver"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(email, role string) (string, error) {
+// GenerateToken creates a short-lived access token bound to a login session
+func GenerateToken(user *entities.User, sessionID uint) (string, error) {
 	claims := JWTClaims{
-		Email: email,
-		Role:  role,
+		Email:        user.Email,
+		Role:         user.Role,
+		SessionID:    sessionID,
+		TokenVersion: user.TokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(config.GetJWTDuration())),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -47,3 +56,56 @@ func ValidateToken(tokenString string) (*JWTClaims, error) {
 
 	return nil, err
 }
+
+// UserID returns the user ID from the subject claim
+func (c *JWTClaims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(c.Subject, 10, 32)
+	if err != nil || id == 0 {
+		return 0, errors.New("token has no valid subject")
+	}
+	return uint(id), nil
+}
+
+// TwoFactorChallengeClaims identifies a user who passed the password check and still has to enter a TOTP code
+type TwoFactorChallengeClaims struct {
+	jwt.RegisteredClaims
+}
+
+// GenerateTwoFactorChallengeToken creates the token that is exchanged together with the TOTP code for a session.
+// It is signed with a key derived from JWT_SECRET, so it can never be used as an access token.
+func GenerateTwoFactorChallengeToken(userID uint) (string, error) {
+	claims := TwoFactorChallengeClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(config.GetTwoFactorChallengeTTL())),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(twoFactorChallengeKey())
+}
+
+// ValidateTwoFactorChallengeToken returns the user ID of a valid challenge token
+func ValidateTwoFactorChallengeToken(tokenString string) (uint, error) {
+	claims := &TwoFactorChallengeClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return twoFactorChallengeKey(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return 0, err
+	}
+	if !token.Valid {
+		return 0, errors.New("invalid challenge token")
+	}
+
+	id, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil || id == 0 {
+		return 0, errors.New("challenge token has no valid subject")
+	}
+	return uint(id), nil
+}
+
+func twoFactorChallengeKey() []byte {
+	return append(append([]byte{}, config.GetJWTSecret()...), []byte(":2fa-challenge")...)
+}
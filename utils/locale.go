@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"malakashuttle/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestLocale returns the language of the response: the preference of the authenticated user,
+// otherwise the best match of the Accept-Language header, otherwise the default language
+func RequestLocale(c *gin.Context) i18n.Locale {
+	if principal, ok := GetPrincipal(c); ok {
+		if locale, ok := i18n.Parse(principal.Locale); ok {
+			return locale
+		}
+	}
+	if locale, ok := i18n.FromAcceptLanguage(c.GetHeader("Accept-Language")); ok {
+		return locale
+	}
+	return i18n.Default()
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"malakashuttle/dto"
+	"malakashuttle/i18n"
+)
+
+// WriteManifestCSV writes the passengers of a manifest as CSV with translated headers.
+// Statuses are written as codes so spreadsheets can filter on them.
+func WriteManifestCSV(manifest *dto.ManifestResponse, w io.Writer, locale i18n.Locale) error {
+	t := func(message string) string { return i18n.T(locale, message) }
+	writer := csv.NewWriter(w)
+
+	header := []string{
+		t("Seat"),
+		t("Passenger Name"),
+		t("Booking ID"),
+		t("Payment Status"),
+		t("Payment Method"),
+		t("Pickup Point"),
+		t("Boarding Status"),
+		t("Boarded At"),
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	loc := receiptLocation()
+	for _, passenger := range manifest.Passengers {
+		boardedAt := ""
+		if passenger.BoardedAt != nil {
+			boardedAt = passenger.BoardedAt.In(loc).Format(time.DateTime)
+		}
+		record := []string{
+			csvCell(passenger.SeatNumber),
+			csvCell(passenger.PassengerName),
+			fmt.Sprintf("%d", passenger.BookingID),
+			passenger.PaymentStatus,
+			csvCell(passenger.PaymentMethod),
+			csvCell(passenger.PickupPoint),
+			string(passenger.BoardingStatus),
+			boardedAt,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvCell keeps spreadsheet programs from running a value as a formula by prefixing it with a quote
+// when it starts with a formula character. Passenger names are entered by customers.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+// ErrInvalidPaymentProof is returned when an uploaded payment proof fails content validation
+var ErrInvalidPaymentProof = errors.New("invalid payment proof")
+
+// PaymentProof holds a sanitized payment proof file ready to be stored
+type PaymentProof struct {
+	Content     []byte
+	ContentType string
+	Extension   string
+	SHA256      string
+}
+
+// pdfForbiddenMarkers are PDF name objects that indicate active content
+var pdfForbiddenMarkers = [][]byte{
+	[]byte("/JavaScript"),
+	[]byte("/Launch"),
+	[]byte("/EmbeddedFile"),
+}
+
+// SanitizePaymentProof validates an uploaded payment proof by its content (not the client supplied
+// Content-Type or extension). JPEG and PNG images are decoded and re-encoded, which strips EXIF/GPS
+// metadata, and PDF bank-transfer slips are checked for structure and active content.
+func SanitizePaymentProof(r io.Reader, maxDimension int) (*PaymentProof, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidPaymentProof)
+	}
+
+	// Sniff the real content type from the magic bytes
+	var proof *PaymentProof
+	switch http.DetectContentType(data) {
+	case "image/jpeg", "image/png":
+		proof, err = sanitizeProofImage(data, maxDimension)
+	case "application/pdf":
+		proof, err = sanitizeProofPDF(data)
+	default:
+		return nil, fmt.Errorf("%w: only JPEG, PNG and PDF files are allowed", ErrInvalidPaymentProof)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(proof.Content)
+	proof.SHA256 = hex.EncodeToString(sum[:])
+	return proof, nil
+}
+
+// sanitizeProofImage decodes the image to confirm it is real and re-encodes it without metadata
+func sanitizeProofImage(data []byte, maxDimension int) (*PaymentProof, error) {
+	// Check dimensions from the header before decoding the full image
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: image could not be decoded", ErrInvalidPaymentProof)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("%w: image has invalid dimensions", ErrInvalidPaymentProof)
+	}
+	if cfg.Width > maxDimension || cfg.Height > maxDimension {
+		return nil, fmt.Errorf("%w: image dimensions exceed %dx%d pixels", ErrInvalidPaymentProof, maxDimension, maxDimension)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: image could not be decoded", ErrInvalidPaymentProof)
+	}
+
+	// Re-encode to drop EXIF/GPS and any trailing data
+	var buf bytes.Buffer
+	proof := &PaymentProof{}
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+		proof.ContentType = "image/jpeg"
+		proof.Extension = ".jpg"
+	case "png":
+		err = png.Encode(&buf, img)
+		proof.ContentType = "image/png"
+		proof.Extension = ".png"
+	default:
+		return nil, fmt.Errorf("%w: only JPEG, PNG and PDF files are allowed", ErrInvalidPaymentProof)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode image: %w", err)
+	}
+
+	proof.Content = buf.Bytes()
+	return proof, nil
+}
+
+// sanitizeProofPDF performs structural checks on a PDF and rejects documents with active content
+func sanitizeProofPDF(data []byte) (*PaymentProof, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return nil, fmt.Errorf("%w: malformed PDF header", ErrInvalidPaymentProof)
+	}
+
+	// The end-of-file marker must appear near the end of the document
+	tail := data
+	if len(tail) > 1024 {
+		tail = tail[len(tail)-1024:]
+	}
+	if !bytes.Contains(tail, []byte("%%EOF")) {
+		return nil, fmt.Errorf("%w: malformed PDF trailer", ErrInvalidPaymentProof)
+	}
+
+	for _, marker := range pdfForbiddenMarkers {
+		if bytes.Contains(data, marker) {
+			return nil, fmt.Errorf("%w: PDF contains scripts or embedded files", ErrInvalidPaymentProof)
+		}
+	}
+
+	return &PaymentProof{
+		Content:     data,
+		ContentType: "application/pdf",
+		Extension:   ".pdf",
+	}, nil
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"malakashuttle/dto"
+	"malakashuttle/i18n"
+	"malakashuttle/qrcode"
 
 	"github.com/jung-kurt/gofpdf/v2"
 )
@@ -19,8 +21,12 @@ func NewPDFReceiptGenerator() *PDFReceiptGenerator {
 	return &PDFReceiptGenerator{}
 }
 
-// GenerateBookingReceipt generates a PDF receipt for a booking
-func (p *PDFReceiptGenerator) GenerateBookingReceipt(booking *dto.BookingResponse, outputPath string) error {
+// GenerateBookingReceipt generates a PDF receipt for a booking in the given language.
+// The boarding passes are printed after the receipt, one box per passenger.
+func (p *PDFReceiptGenerator) GenerateBookingReceipt(booking *dto.BookingResponse, passes []BoardingPass, outputPath string, locale i18n.Locale) error {
+	t := func(message string) string { return i18n.T(locale, message) }
+	money := func(amount float64) string { return i18n.FormatCurrency(locale, amount) }
+
 	// Create new PDF
 	pdf := gofpdf.New(gofpdf.OrientationPortrait, gofpdf.UnitMillimeter, gofpdf.PageSizeA4, "")
 	pdf.AddPage()
@@ -33,57 +39,57 @@ func (p *PDFReceiptGenerator) GenerateBookingReceipt(booking *dto.BookingRespons
 	pdf.CellFormat(0, 10, "MALAKA SHUTTLE", "", 1, "C", false, 0, "")
 
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(0, 6, "Jasa Transportasi Terpercaya", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, t("Trusted Transportation Service"), "", 1, "C", false, 0, "")
 	pdf.Ln(10)
 
 	// Title
 	pdf.SetFont("Arial", "B", 14)
-	pdf.CellFormat(0, 8, "BOOKING RECEIPT", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 8, t("BOOKING RECEIPT"), "", 1, "C", false, 0, "")
 	pdf.Ln(12)
 
 	// Booking Information
 	pdf.SetFont("Arial", "B", 10)
-	pdf.CellFormat(40, 6, "Booking ID:", "", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 6, t("Booking ID:"), "", 0, "L", false, 0, "")
 	pdf.SetFont("Arial", "", 10)
 	pdf.CellFormat(0, 6, fmt.Sprintf("#%d", booking.ID), "", 1, "L", false, 0, "")
 
 	pdf.SetFont("Arial", "B", 10)
-	pdf.CellFormat(40, 6, "Booking Date:", "", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 6, t("Booking Date:"), "", 0, "L", false, 0, "")
 	pdf.SetFont("Arial", "", 10)
-	pdf.CellFormat(0, 6, booking.CreatedAt.Format("02 January 2006, 15:04 WIB"), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, i18n.FormatDateTime(locale, booking.CreatedAt.In(receiptLocation()))+" WIB", "", 1, "L", false, 0, "")
 
 	pdf.SetFont("Arial", "B", 10)
-	pdf.CellFormat(40, 6, "Status:", "", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 6, t("Status:"), "", 0, "L", false, 0, "")
 	pdf.SetFont("Arial", "", 10)
 	statusColor := getStatusColor(string(booking.Status))
 	pdf.SetTextColor(statusColor.R, statusColor.G, statusColor.B)
-	pdf.CellFormat(0, 6, string(booking.Status), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, t(statusLabel(string(booking.Status))), "", 1, "L", false, 0, "")
 	pdf.SetTextColor(0, 0, 0) // Reset to black
 	pdf.Ln(10)
 
 	// Schedule Information
 	if booking.Schedule != nil {
 		pdf.SetFont("Arial", "B", 12)
-		pdf.CellFormat(0, 8, "SCHEDULE DETAILS", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, t("SCHEDULE DETAILS"), "", 1, "L", false, 0, "")
 		pdf.Ln(8)
 
 		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(40, 6, "Route:", "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, t("Route:"), "", 0, "L", false, 0, "")
 		pdf.SetFont("Arial", "", 10)
 		pdf.CellFormat(0, 6, fmt.Sprintf("%s → %s", booking.Schedule.Origin, booking.Schedule.Destination), "", 1, "L", false, 0, "")
 
 		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(40, 6, "Departure:", "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, t("Departure:"), "", 0, "L", false, 0, "")
 		pdf.SetFont("Arial", "", 10)
-		pdf.CellFormat(0, 6, booking.Schedule.DepartureTime, "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 6, formatScheduleTime(booking.Schedule.DepartureTime, locale), "", 1, "L", false, 0, "")
 
 		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(40, 6, "Arrival:", "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, t("Arrival:"), "", 0, "L", false, 0, "")
 		pdf.SetFont("Arial", "", 10)
-		pdf.CellFormat(0, 6, booking.Schedule.ArrivalTime, "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 6, formatScheduleTime(booking.Schedule.ArrivalTime, locale), "", 1, "L", false, 0, "")
 
 		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(40, 6, "Duration:", "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, t("Duration:"), "", 0, "L", false, 0, "")
 		pdf.SetFont("Arial", "", 10)
 		pdf.CellFormat(0, 6, booking.Schedule.Duration, "", 1, "L", false, 0, "")
 		pdf.Ln(10)
@@ -92,16 +98,16 @@ func (p *PDFReceiptGenerator) GenerateBookingReceipt(booking *dto.BookingRespons
 	// Passenger Details
 	if len(booking.Passengers) > 0 {
 		pdf.SetFont("Arial", "B", 12)
-		pdf.CellFormat(0, 8, "PASSENGER DETAILS", "", 1, "L", false, 0, "")
+		pdf.CellFormat(0, 8, t("PASSENGER DETAILS"), "", 1, "L", false, 0, "")
 		pdf.Ln(8)
 
 		// Table header
 		pdf.SetFont("Arial", "B", 10)
 		pdf.SetFillColor(240, 240, 240)
-		pdf.CellFormat(10, 8, "No", "1", 0, "C", true, 0, "")
-		pdf.CellFormat(80, 8, "Passenger Name", "1", 0, "C", true, 0, "")
-		pdf.CellFormat(30, 8, "Seat", "1", 0, "C", true, 0, "")
-		pdf.CellFormat(40, 8, "Price", "1", 1, "C", true, 0, "")
+		pdf.CellFormat(10, 8, t("No"), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(80, 8, t("Passenger Name"), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(30, 8, t("Seat"), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(40, 8, t("Price"), "1", 1, "C", true, 0, "")
 
 		// Table content
 		pdf.SetFont("Arial", "", 10)
@@ -112,7 +118,7 @@ func (p *PDFReceiptGenerator) GenerateBookingReceipt(booking *dto.BookingRespons
 			pdf.CellFormat(80, 8, passenger.PassengerName, "1", 0, "L", false, 0, "")
 			pdf.CellFormat(30, 8, passenger.SeatNumber, "1", 0, "C", false, 0, "")
 			if booking.Schedule != nil {
-				pdf.CellFormat(40, 8, formatCurrency(booking.Schedule.Price), "1", 1, "R", false, 0, "")
+				pdf.CellFormat(40, 8, money(booking.Schedule.Price), "1", 1, "R", false, 0, "")
 			} else {
 				pdf.CellFormat(40, 8, "-", "1", 1, "R", false, 0, "")
 			}
@@ -122,38 +128,135 @@ func (p *PDFReceiptGenerator) GenerateBookingReceipt(booking *dto.BookingRespons
 
 	// Payment Summary
 	pdf.SetFont("Arial", "B", 12)
-	pdf.CellFormat(0, 8, "PAYMENT SUMMARY", "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 8, t("PAYMENT SUMMARY"), "", 1, "L", false, 0, "")
 	pdf.Ln(8)
+
+	// Show the unique transfer code that was added to the ticket total
+	if booking.UniqueCode > 0 {
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(120, 6, t("Subtotal:"), "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, money(booking.TotalAmount-float64(booking.UniqueCode)), "", 1, "R", false, 0, "")
+		pdf.CellFormat(120, 6, t("Unique Code:"), "", 0, "L", false, 0, "")
+		pdf.CellFormat(40, 6, money(float64(booking.UniqueCode)), "", 1, "R", false, 0, "")
+	}
+
 	pdf.SetFont("Arial", "B", 10)
-	pdf.CellFormat(120, 6, "Total Amount:", "", 0, "L", false, 0, "")
-	pdf.CellFormat(40, 6, formatCurrency(booking.TotalAmount), "", 1, "R", false, 0, "")
+	pdf.CellFormat(120, 6, t("Total Amount:"), "", 0, "L", false, 0, "")
+	pdf.CellFormat(40, 6, money(booking.TotalAmount), "", 1, "R", false, 0, "")
 	pdf.Ln(5)
 
 	// Payment Status
 	if booking.Status == "success" {
 		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(120, 6, "Payment Status:", "", 0, "L", false, 0, "")
+		pdf.CellFormat(120, 6, t("Payment Status:"), "", 0, "L", false, 0, "")
 		pdf.SetTextColor(0, 150, 0) // Green
-		pdf.CellFormat(40, 6, "PAID", "", 1, "R", false, 0, "")
+		pdf.CellFormat(40, 6, t("PAID"), "", 1, "R", false, 0, "")
 		pdf.SetTextColor(0, 0, 0) // Reset to black
 	} else {
 		pdf.SetFont("Arial", "B", 10)
-		pdf.CellFormat(120, 6, "Payment Status:", "", 0, "L", false, 0, "")
+		pdf.CellFormat(120, 6, t("Payment Status:"), "", 0, "L", false, 0, "")
 		pdf.SetTextColor(200, 0, 0) // Red
-		pdf.CellFormat(40, 6, "PENDING", "", 1, "R", false, 0, "")
+		pdf.CellFormat(40, 6, t("PENDING"), "", 1, "R", false, 0, "")
 		pdf.SetTextColor(0, 0, 0) // Reset to black
 	}
 
 	// Footer
 	pdf.Ln(20)
 	pdf.SetFont("Arial", "I", 8)
-	pdf.CellFormat(0, 6, "Thank you for choosing Malaka Shuttle!", "", 1, "C", false, 0, "")
-	pdf.CellFormat(0, 6, fmt.Sprintf("Generated on %s", time.Now().Format("02 January 2006, 15:04 WIB")), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, t("Thank you for choosing Malaka Shuttle!"), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("%s %s WIB", t("Generated on"), i18n.FormatDateTime(locale, time.Now().In(receiptLocation()))), "", 1, "C", false, 0, "")
+
+	if len(passes) > 0 {
+		if err := addBoardingPasses(pdf, booking, passes, locale); err != nil {
+			return err
+		}
+	}
 
 	// Save PDF
 	return pdf.OutputFileAndClose(outputPath)
 }
 
+// addBoardingPasses prints the boarding passes with their QR code, three per page
+func addBoardingPasses(pdf *gofpdf.Fpdf, booking *dto.BookingResponse, passes []BoardingPass, locale i18n.Locale) error {
+	t := func(message string) string { return i18n.T(locale, message) }
+	const (
+		passHeight = 80.0
+		qrSize     = 60.0
+	)
+
+	for i, pass := range passes {
+		if i%3 == 0 {
+			pdf.AddPage()
+			pdf.SetFont("Arial", "B", 14)
+			pdf.CellFormat(0, 8, t("BOARDING PASS"), "", 1, "C", false, 0, "")
+			pdf.Ln(4)
+		}
+
+		code, err := qrcode.Encode(pass.Code)
+		if err != nil {
+			return fmt.Errorf("failed to encode boarding pass: %w", err)
+		}
+
+		left, top := pdf.GetX(), pdf.GetY()
+		pdf.SetDrawColor(160, 160, 160)
+		pdf.Rect(left, top, 190, passHeight-5, "D")
+		pdf.SetDrawColor(0, 0, 0)
+		drawQRCode(pdf, code, left+5, top+7.5, qrSize)
+
+		// Passenger and trip details next to the code
+		textLeft := left + qrSize + 15
+		pdf.SetXY(textLeft, top+8)
+		rows := [][2]string{
+			{t("Passenger Name"), pass.PassengerName},
+			{t("Seat"), pass.SeatNumber},
+			{t("Booking ID:"), fmt.Sprintf("#%d", booking.ID)},
+		}
+		if booking.Schedule != nil {
+			rows = append(rows,
+				[2]string{t("Route:"), fmt.Sprintf("%s → %s", booking.Schedule.Origin, booking.Schedule.Destination)},
+				[2]string{t("Departure:"), formatScheduleTime(booking.Schedule.DepartureTime, locale)},
+			)
+		}
+		for _, row := range rows {
+			pdf.SetX(textLeft)
+			pdf.SetFont("Arial", "B", 10)
+			pdf.CellFormat(35, 7, strings.TrimSuffix(row[0], ":"), "", 0, "L", false, 0, "")
+			pdf.SetFont("Arial", "", 10)
+			pdf.CellFormat(0, 7, row[1], "", 1, "L", false, 0, "")
+		}
+		pdf.SetX(textLeft)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.MultiCell(105, 5, t("Show this code to the driver when boarding."), "", "L", false)
+
+		pdf.SetXY(left, top+passHeight)
+	}
+	return nil
+}
+
+// drawQRCode draws a QR code with its quiet zone as a square of the given size
+func drawQRCode(pdf *gofpdf.Fpdf, code *qrcode.Code, x, y, size float64) {
+	const quietZone = 4
+	module := size / float64(code.Size()+2*quietZone)
+	x += quietZone * module
+	y += quietZone * module
+
+	pdf.SetFillColor(0, 0, 0)
+	for row := 0; row < code.Size(); row++ {
+		// Draw runs of dark modules as one rectangle
+		for col := 0; col < code.Size(); col++ {
+			if !code.Dark(col, row) {
+				continue
+			}
+			start := col
+			for col+1 < code.Size() && code.Dark(col+1, row) {
+				col++
+			}
+			pdf.Rect(x+float64(start)*module, y+float64(row)*module, float64(col-start+1)*module, module, "F")
+		}
+	}
+	pdf.SetFillColor(255, 255, 255)
+}
+
 // StatusColor represents RGB color
 type StatusColor struct {
 	R, G, B int
@@ -177,6 +280,44 @@ func getStatusColor(status string) StatusColor {
 	}
 }
 
+// statusLabel returns the English label of a booking status, translated like the other receipt texts
+func statusLabel(status string) string {
+	switch status {
+	case "success":
+		return "Success"
+	case "pending":
+		return "Pending"
+	case "waiting_verification":
+		return "Waiting Verification"
+	case "rejected":
+		return "Rejected"
+	case "expired":
+		return "Expired"
+	case "cancelled":
+		return "Cancelled"
+	default:
+		return status
+	}
+}
+
+// formatScheduleTime rewrites a "YYYY-MM-DD HH:mm" schedule time with the month written out
+func formatScheduleTime(value string, locale i18n.Locale) string {
+	parsed, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		return value
+	}
+	return i18n.FormatDateTime(locale, parsed)
+}
+
+// receiptLocation returns the WIB time zone the receipt times are shown in
+func receiptLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
+
 // formatCurrency formats number to Indonesian Rupiah currency
 func formatCurrency(amount float64) string {
 	// Convert to string without decimal
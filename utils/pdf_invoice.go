@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+
+	"malakashuttle/dto"
+
+	"github.com/jung-kurt/gofpdf/v2"
+)
+
+// PDFInvoiceGenerator handles PDF invoice generation
+type PDFInvoiceGenerator struct{}
+
+// NewPDFInvoiceGenerator creates a new PDF invoice generator
+func NewPDFInvoiceGenerator() *PDFInvoiceGenerator {
+	return &PDFInvoiceGenerator{}
+}
+
+// GenerateInvoice generates a PDF invoice with tax breakdown
+func (p *PDFInvoiceGenerator) GenerateInvoice(invoice *dto.InvoiceResponse, outputPath string) error {
+	pdf := gofpdf.New(gofpdf.OrientationPortrait, gofpdf.UnitMillimeter, gofpdf.PageSizeA4, "")
+	pdf.AddPage()
+	pdf.SetTextColor(0, 0, 0)
+
+	// Seller header
+	pdf.SetFont("Arial", "B", 14)
+	pdf.CellFormat(120, 8, invoice.Company.Name, "", 0, "L", false, 0, "")
+	pdf.SetFont("Arial", "B", 18)
+	pdf.CellFormat(0, 8, "INVOICE", "", 1, "R", false, 0, "")
+
+	pdf.SetFont("Arial", "", 9)
+	writePartyLines(pdf, invoice.Company, false)
+	pdf.Ln(6)
+
+	// Invoice information
+	writeLabelValue(pdf, "Invoice No:", invoice.InvoiceNumber)
+	writeLabelValue(pdf, "Invoice Date:", invoice.IssuedAt.Format("02 January 2006"))
+	writeLabelValue(pdf, "Booking ID:", fmt.Sprintf("#%d", invoice.BookingID))
+	pdf.Ln(6)
+
+	// Customer billing information
+	pdf.SetFont("Arial", "B", 11)
+	pdf.CellFormat(0, 7, "BILL TO", "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 6, invoice.BillTo.Name, "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 9)
+	writePartyLines(pdf, invoice.BillTo, true)
+	pdf.Ln(6)
+
+	// Line items
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(240, 240, 240)
+	pdf.CellFormat(10, 8, "No", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(100, 8, "Description", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(15, 8, "Qty", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(30, 8, "Unit Price", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(35, 8, "Amount", "1", 1, "C", true, 0, "")
+
+	pdf.SetFont("Arial", "", 9)
+	for i, item := range invoice.Items {
+		pdf.CellFormat(10, 8, strconv.Itoa(i+1), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(100, 8, truncateText(pdf, item.Description, 98), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(15, 8, strconv.Itoa(item.Quantity), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(30, 8, formatCurrency(item.UnitPrice), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(35, 8, formatCurrency(item.Amount), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Totals with tax breakdown
+	writeTotalLine(pdf, "Subtotal", formatCurrency(invoice.Subtotal), false)
+	if invoice.DiscountAmount > 0 {
+		label := "Discount"
+		if invoice.DiscountNote != "" {
+			label = fmt.Sprintf("Discount (%s)", invoice.DiscountNote)
+		}
+		writeTotalLine(pdf, label, "- "+formatCurrency(invoice.DiscountAmount), false)
+	}
+	writeTotalLine(pdf, "Taxable Amount (DPP)", formatCurrency(invoice.TaxableAmount), false)
+	writeTotalLine(pdf, fmt.Sprintf("PPN %s%%", strconv.FormatFloat(invoice.TaxRate*100, 'f', -1, 64)), formatCurrency(invoice.TaxAmount), false)
+	writeTotalLine(pdf, "Total", formatCurrency(invoice.TotalAmount), true)
+
+	// Footer
+	pdf.Ln(12)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.CellFormat(0, 5, "Prices include PPN. This invoice is valid without signature.", "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, "Payment status: PAID", "", 1, "L", false, 0, "")
+
+	return pdf.OutputFileAndClose(outputPath)
+}
+
+// writePartyLines writes the optional address/contact lines of an invoice party
+func writePartyLines(pdf *gofpdf.Fpdf, party dto.InvoicePartyResponse, includeCompany bool) {
+	lines := []string{}
+	if includeCompany && party.Company != "" {
+		lines = append(lines, party.Company)
+	}
+	if party.Address != "" {
+		lines = append(lines, party.Address)
+	}
+	if party.Phone != "" {
+		lines = append(lines, "Phone: "+party.Phone)
+	}
+	if party.Email != "" {
+		lines = append(lines, "Email: "+party.Email)
+	}
+	if party.NPWP != "" {
+		lines = append(lines, "NPWP: "+party.NPWP)
+	}
+	for _, line := range lines {
+		pdf.CellFormat(0, 5, line, "", 1, "L", false, 0, "")
+	}
+}
+
+// writeLabelValue writes a bold label followed by a value on one line
+func writeLabelValue(pdf *gofpdf.Fpdf, label, value string) {
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(40, 6, label, "", 0, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, value, "", 1, "L", false, 0, "")
+}
+
+// writeTotalLine writes a right aligned total line
+func writeTotalLine(pdf *gofpdf.Fpdf, label, value string, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	pdf.SetFont("Arial", style, 10)
+	pdf.CellFormat(95, 6, "", "", 0, "L", false, 0, "")
+	pdf.CellFormat(60, 6, label, "", 0, "L", false, 0, "")
+	pdf.CellFormat(35, 6, value, "", 1, "R", false, 0, "")
+}
+
+// truncateText shortens text so it fits into the given cell width
+func truncateText(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"malakashuttle/dto"
+	"malakashuttle/entities"
+	"malakashuttle/i18n"
+
+	"github.com/jung-kurt/gofpdf/v2"
+)
+
+// PDFManifestGenerator handles PDF manifest generation
+type PDFManifestGenerator struct{}
+
+// NewPDFManifestGenerator creates a new PDF manifest generator
+func NewPDFManifestGenerator() *PDFManifestGenerator {
+	return &PDFManifestGenerator{}
+}
+
+// manifestColumns are the widths of the manifest table on a landscape A4 page
+var manifestColumns = []struct {
+	title string
+	width float64
+}{
+	{"Seat", 18},
+	{"Passenger Name", 70},
+	{"Booking ID", 28},
+	{"Payment", 40},
+	{"Pickup Point", 80},
+	{"Boarding", 41},
+}
+
+// GenerateManifest writes the passenger manifest of a schedule as PDF in the given language
+func (p *PDFManifestGenerator) GenerateManifest(manifest *dto.ManifestResponse, w io.Writer, locale i18n.Locale) error {
+	t := func(message string) string { return i18n.T(locale, message) }
+	loc := receiptLocation()
+
+	pdf := gofpdf.New(gofpdf.OrientationLandscape, gofpdf.UnitMillimeter, gofpdf.PageSizeA4, "")
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+	pdf.SetTextColor(0, 0, 0)
+
+	// Header
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 10, "MALAKA SHUTTLE", "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "B", 13)
+	pdf.CellFormat(0, 8, t("PASSENGER MANIFEST"), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	// Trip information
+	writeLabelValue(pdf, t("Schedule ID:"), fmt.Sprintf("#%d", manifest.ScheduleID))
+	writeLabelValue(pdf, t("Route:"), fmt.Sprintf("%s → %s", manifest.Origin, manifest.Destination))
+	writeLabelValue(pdf, t("Departure:"), i18n.FormatDateTime(locale, manifest.DepartureTime.In(loc))+" WIB")
+	writeLabelValue(pdf, t("Pickup Point:"), manifest.PickupPoint)
+	if manifest.Cancelled {
+		pdf.SetTextColor(200, 0, 0)
+		writeLabelValue(pdf, t("Status:"), t("Cancelled"))
+		pdf.SetTextColor(0, 0, 0)
+	}
+	pdf.Ln(4)
+
+	// Passenger table
+	writeManifestHeader(pdf, t)
+	pdf.SetFont("Arial", "", 9)
+	for _, passenger := range manifest.Passengers {
+		if pdf.GetY() > 185 {
+			pdf.AddPage()
+			writeManifestHeader(pdf, t)
+			pdf.SetFont("Arial", "", 9)
+		}
+		values := []string{
+			passenger.SeatNumber,
+			passenger.PassengerName,
+			fmt.Sprintf("#%d", passenger.BookingID),
+			manifestPaymentLabel(passenger, locale),
+			passenger.PickupPoint,
+			manifestBoardingLabel(passenger, locale),
+		}
+		for i, column := range manifestColumns {
+			ln, align := 0, "L"
+			if i == len(manifestColumns)-1 {
+				ln = 1
+			}
+			if i == 0 || i == 2 {
+				align = "C"
+			}
+			pdf.CellFormat(column.width, 7, truncateText(pdf, values[i], column.width-2), "1", ln, align, false, 0, "")
+		}
+	}
+	if len(manifest.Passengers) == 0 {
+		pdf.SetFont("Arial", "I", 9)
+		pdf.CellFormat(0, 7, t("No passengers yet"), "1", 1, "C", false, 0, "")
+	}
+	pdf.Ln(6)
+
+	// Seat summary
+	summary := manifest.Summary
+	pdf.SetFont("Arial", "B", 11)
+	pdf.CellFormat(0, 7, t("SEAT SUMMARY"), "", 1, "L", false, 0, "")
+	writeLabelValue(pdf, t("Total Seats:"), fmt.Sprintf("%d", summary.TotalSeats))
+	writeLabelValue(pdf, t("Occupied Seats:"), fmt.Sprintf("%d", summary.OccupiedSeats))
+	writeLabelValue(pdf, t("Checked In:"), fmt.Sprintf("%d", summary.CheckedInPassengers))
+	writeLabelValue(pdf, t("Boarded:"), fmt.Sprintf("%d", summary.BoardedPassengers))
+	writeLabelValue(pdf, t("No-show:"), fmt.Sprintf("%d", summary.NoShowPassengers))
+	writeLabelValue(pdf, t("Empty Seats:"), fmt.Sprintf("%d", summary.EmptySeats))
+	if len(summary.EmptySeatNumbers) > 0 {
+		pdf.SetFont("Arial", "", 10)
+		pdf.MultiCell(0, 6, strings.Join(summary.EmptySeatNumbers, ", "), "", "L", false)
+	}
+
+	// Footer
+	pdf.Ln(6)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.CellFormat(0, 6, fmt.Sprintf("%s %s WIB", t("Generated on"), i18n.FormatDateTime(locale, time.Now().In(loc))), "", 1, "C", false, 0, "")
+
+	return pdf.Output(w)
+}
+
+func writeManifestHeader(pdf *gofpdf.Fpdf, t func(string) string) {
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(240, 240, 240)
+	for i, column := range manifestColumns {
+		ln := 0
+		if i == len(manifestColumns)-1 {
+			ln = 1
+		}
+		pdf.CellFormat(column.width, 8, t(column.title), "1", ln, "C", true, 0, "")
+	}
+	pdf.SetFillColor(255, 255, 255)
+}
+
+// manifestPaymentLabel returns the translated payment status with the payment method
+func manifestPaymentLabel(passenger dto.ManifestPassengerResponse, locale i18n.Locale) string {
+	var label string
+	switch passenger.PaymentStatus {
+	case "success":
+		label = i18n.T(locale, "Paid")
+	case "pending":
+		label = i18n.T(locale, "Pending")
+	case "failed":
+		label = i18n.T(locale, "Failed")
+	default:
+		label = passenger.PaymentStatus
+	}
+	if passenger.PaymentMethod != "" {
+		label += " (" + passenger.PaymentMethod + ")"
+	}
+	return label
+}
+
+// manifestBoardingLabel returns the translated boarding status with the boarding time
+func manifestBoardingLabel(passenger dto.ManifestPassengerResponse, locale i18n.Locale) string {
+	if passenger.BoardingStatus == entities.BoardingStatusBoarded && passenger.BoardedAt != nil {
+		return i18n.T(locale, "Boarded") + " " + passenger.BoardedAt.In(receiptLocation()).Format("15:04")
+	}
+	if label, ok := manifestBoardingStatusLabels[passenger.BoardingStatus]; ok {
+		return i18n.T(locale, label)
+	}
+	return string(passenger.BoardingStatus)
+}
+
+// manifestBoardingStatusLabels are the English labels of the boarding statuses, translated like the other texts
+var manifestBoardingStatusLabels = map[entities.BoardingStatus]string{
+	entities.BoardingStatusNotBoarded: "Not boarded",
+	entities.BoardingStatusCheckedIn:  "Checked in",
+	entities.BoardingStatusBoarded:    "Boarded",
+	entities.BoardingStatusNoShow:     "No-show",
+}
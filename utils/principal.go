@@ -0,0 +1,59 @@
+package utils
+
+import "github.com/gin-gonic/gin"
+
+const principalContextKey = "principal"
+
+// Principal is the authenticated user of a request, loaded once by AuthMiddleware
+type Principal struct {
+	UserID    uint
+	Email     string
+	Role      string
+	SessionID uint
+	// TwoFactorSetupRequired is set when the user's role requires two-factor authentication
+	// and the user has not enabled it yet; only the setup endpoints can be used until then
+	TwoFactorSetupRequired bool
+	// Permissions granted by the user's role, or by the scopes of an API key
+	Permissions map[string]bool
+	// Locale is the language the user prefers, empty to follow the Accept-Language header
+	Locale string
+	// APIKeyID and PartnerID are set when the request is authenticated with a partner API key
+	APIKeyID              uint
+	PartnerID             *uint
+	PartnerCommissionRate float64
+}
+
+// HasRole reports whether the principal has one of the given roles
+func (p *Principal) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission reports whether the principal has one of the given permissions
+func (p *Principal) HasPermission(permissions ...string) bool {
+	for _, permission := range permissions {
+		if p.Permissions[permission] {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPrincipal stores the authenticated principal in the request context
+func SetPrincipal(c *gin.Context, principal *Principal) {
+	c.Set(principalContextKey, principal)
+}
+
+// GetPrincipal returns the authenticated principal of the request
+func GetPrincipal(c *gin.Context) (*Principal, bool) {
+	value, exists := c.Get(principalContextKey)
+	if !exists {
+		return nil, false
+	}
+	principal, ok := value.(*Principal)
+	return principal, ok && principal != nil
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"malakashuttle/constants"
+	"malakashuttle/i18n"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -25,9 +26,12 @@ func NewResponseHelper() *ResponseHelper {
 
 // BuildSuccessResponse membuat response sukses dengan format yang konsisten
 func (r *ResponseHelper) BuildSuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
+	locale := RequestLocale(c)
+	c.Header("Content-Language", string(locale))
+
 	response := JSONResponse{
 		Code:    constants.RESPONSE_SUCCESS,
-		Message: message,
+		Message: i18n.T(locale, message),
 		Data:    data,
 	}
 	c.JSON(statusCode, response)
@@ -83,9 +87,13 @@ func (r *ResponseHelper) BuildErrorResponse(c *gin.Context, err error) {
 		code = constants.RESPONSE_INTERNAL_SERVER_ERROR
 	}
 
+	// Message diterjemahkan ke bahasa request, detail error tetap apa adanya
+	locale := RequestLocale(c)
+	c.Header("Content-Language", string(locale))
+
 	response := JSONResponse{
 		Code:    code,
-		Message: message,
+		Message: i18n.T(locale, message),
 		Data:    responseData,
 	}
 
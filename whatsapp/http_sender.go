@@ -0,0 +1,56 @@
+package whatsapp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"malakashuttle/config"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPSender posts text messages to a WhatsApp Business API compatible endpoint
+type HTTPSender struct {
+	cfg    config.WhatsAppConfig
+	client *http.Client
+}
+
+func NewHTTPSender(cfg config.WhatsAppConfig) *HTTPSender {
+	return &HTTPSender{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *HTTPSender) Send(msg Message) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"to":                strings.TrimPrefix(msg.To, "+"),
+		"type":              "text",
+		"text":              map[string]string{"body": msg.Body},
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.cfg.APIURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.Token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach whatsapp api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("whatsapp api responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package whatsapp
+
+import (
+	"fmt"
+	"malakashuttle/config"
+)
+
+// Message is a WhatsApp text message to a phone number
+type Message struct {
+	To   string
+	Body string
+}
+
+// Sender delivers WhatsApp messages
+type Sender interface {
+	Send(msg Message) error
+}
+
+// NewSenderFromConfig creates the sender selected by WHATSAPP_DRIVER
+func NewSenderFromConfig() (Sender, error) {
+	cfg := config.GetWhatsAppConfig()
+	switch cfg.Driver {
+	case "http":
+		if cfg.APIURL == "" {
+			return nil, fmt.Errorf("WHATSAPP_API_URL is required for the http whatsapp driver")
+		}
+		return NewHTTPSender(cfg), nil
+	case "file":
+		return NewFileSender(cfg.FileDir), nil
+	default:
+		return nil, fmt.Errorf("unsupported whatsapp driver: %s", cfg.Driver)
+	}
+}